@@ -14,6 +14,7 @@ func main() {
 	runner := NewRunner()
 	if err := runner.run(); err != nil {
 		fmt.Fprintf(runner.stdOut, `[]`)
+		log.SetOutput(runner.stdErr)
 		log.Fatalln(err)
 	}
 }
@@ -40,6 +41,7 @@ func (r *Runner) run() error {
 	if err := json.NewDecoder(r.stdIn).Decode(&input); err != nil {
 		return errors.Wrap(err, "Decode")
 	}
+	r.stdErr = shared.NewRedactingWriter(r.stdErr, input.Source)
 	if !input.Source.Valid() {
 		return errors.New("source is invalid")
 	}
@@ -0,0 +1,132 @@
+package bomcsv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// separator joins the values of fields with several values, e.g. dual licenses.
+const separator = "; "
+
+// Columns, which can be exported. They are exported in this order, when no columns are configured.
+var Columns = []string{
+	"component", "version", "license", "origin", "usage", "match_type",
+	"critical", "high", "medium", "low", "policy_status", "ignored",
+	"short_term_upgrade", "long_term_upgrade",
+}
+
+// Validate makes sure, that every column is known.
+func Validate(columns []string) error {
+	for _, column := range columns {
+		known := false
+		for _, c := range Columns {
+			known = known || c == column
+		}
+		if !known {
+			return fmt.Errorf("unknown csv column %v", column)
+		}
+	}
+	return nil
+}
+
+// Write exports a row per component in the BOM. The rows are sorted and values of the same field are sorted and de-duplicated,
+// so that exports of the same BOM are equal. The upgrades are taken from the guidances of the vulnerable components.
+func Write(w io.Writer, columns []string, components []shared.BomComponent, vulnerabilities []shared.VulnerableComponent, guidances guidance.Guidances) error {
+	if len(columns) == 0 {
+		columns = Columns
+	}
+	if err := Validate(columns); err != nil {
+		return err
+	}
+	counts := map[string]map[string]int{}
+	for _, v := range vulnerabilities {
+		if !v.Vulnerability.Open() {
+			continue
+		}
+		key := v.ComponentName + "/" + v.ComponentVersionName
+		if counts[key] == nil {
+			counts[key] = map[string]int{}
+		}
+		counts[key][strings.ToLower(v.Vulnerability.Severity)]++
+	}
+
+	var rows [][]string
+	for _, component := range components {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = value(component, column, counts[component.ComponentName+"/"+component.ComponentVersionName], guidances.Get(component.ComponentName, component.ComponentVersionName))
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return strings.Join(rows[i], "\x00") < strings.Join(rows[j], "\x00")
+	})
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func value(component shared.BomComponent, column string, counts map[string]int, g guidance.Guidance) string {
+	switch column {
+	case "component":
+		return component.ComponentName
+	case "version":
+		return component.ComponentVersionName
+	case "license":
+		var licenses []string
+		for _, license := range component.Licenses {
+			licenses = append(licenses, license.LicenseDisplay)
+		}
+		return join(licenses)
+	case "origin":
+		var origins []string
+		for _, origin := range component.Origins {
+			origins = append(origins, origin.ExternalNamespace+":"+origin.ExternalId)
+		}
+		return join(origins)
+	case "usage":
+		return join(component.Usages)
+	case "match_type":
+		return join(component.MatchTypes)
+	case "critical", "high", "medium", "low":
+		return strconv.Itoa(counts[column])
+	case "policy_status":
+		return component.PolicyStatus
+	case "ignored":
+		return strconv.FormatBool(component.Ignored)
+	case "short_term_upgrade":
+		if g.ShortTerm != nil {
+			return g.ShortTerm.String()
+		}
+	case "long_term_upgrade":
+		if g.LongTerm != nil {
+			return g.LongTerm.String()
+		}
+	}
+	return ""
+}
+
+func join(values []string) string {
+	unique := map[string]bool{}
+	var sorted []string
+	for _, v := range values {
+		if len(v) != 0 && !unique[v] {
+			unique[v] = true
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, separator)
+}
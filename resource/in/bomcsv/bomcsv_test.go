@@ -0,0 +1,69 @@
+package bomcsv_test
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/in/bomcsv"
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var components = []shared.BomComponent{
+	{
+		ComponentName:        "mysql",
+		ComponentVersionName: "8.0.33",
+		Licenses:             []shared.BomLicense{{LicenseDisplay: "GPL 2.0"}, {LicenseDisplay: "Universal FOSS Exception, Version 1.0"}},
+		Origins:              []shared.BomOrigin{{ExternalNamespace: "maven", ExternalId: "com.mysql:mysql-connector-j:8.0.33"}},
+		Usages:               []string{"DYNAMICALLY_LINKED"},
+		MatchTypes:           []string{"FILE_DEPENDENCY_DIRECT", "FILE_DEPENDENCY_TRANSITIVE", "FILE_DEPENDENCY_DIRECT"},
+		PolicyStatus:         "IN_VIOLATION",
+	},
+	{
+		ComponentName:        "log4j",
+		ComponentVersionName: "2.14.1",
+		Licenses:             []shared.BomLicense{{LicenseDisplay: "Apache License 2.0"}},
+		Usages:               []string{"DYNAMICALLY_LINKED"},
+		PolicyStatus:         "NOT_IN_VIOLATION",
+		Ignored:              true,
+	},
+}
+
+var vulnerabilities = []shared.VulnerableComponent{
+	{ComponentName: "log4j", ComponentVersionName: "2.14.1", Vulnerability: shared.VulnerabilityWithRemediation{Severity: "CRITICAL", RemediationStatus: "NEW"}},
+	{ComponentName: "log4j", ComponentVersionName: "2.14.1", Vulnerability: shared.VulnerabilityWithRemediation{Severity: "CRITICAL", RemediationStatus: "PATCHED"}},
+	{ComponentName: "log4j", ComponentVersionName: "2.14.1", Vulnerability: shared.VulnerabilityWithRemediation{Severity: "MEDIUM", RemediationStatus: "NEW"}},
+}
+
+var guidances = guidance.Guidances{
+	guidance.Key("log4j", "2.14.1"): {
+		ShortTerm: &guidance.Recommendation{Version: "2.15.0", Resolves: []string{"CVE-2021-44228"}},
+		LongTerm:  &guidance.Recommendation{Version: "2.17.1", Resolves: []string{}},
+	},
+}
+
+func TestWritesTheBom(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, bomcsv.Write(&b, nil, components, vulnerabilities, guidances))
+	require.Equal(t, `component,version,license,origin,usage,match_type,critical,high,medium,low,policy_status,ignored,short_term_upgrade,long_term_upgrade
+log4j,2.14.1,Apache License 2.0,,DYNAMICALLY_LINKED,,1,0,1,0,NOT_IN_VIOLATION,true,2.15.0 (resolves CVE-2021-44228),2.17.1
+mysql,8.0.33,"GPL 2.0; Universal FOSS Exception, Version 1.0",maven:com.mysql:mysql-connector-j:8.0.33,DYNAMICALLY_LINKED,FILE_DEPENDENCY_DIRECT; FILE_DEPENDENCY_TRANSITIVE,0,0,0,0,IN_VIOLATION,false,,
+`, b.String())
+}
+
+func TestWritesTheConfiguredColumns(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, bomcsv.Write(&b, []string{"version", "component", "critical"}, components, vulnerabilities, nil))
+	require.Equal(t, "version,component,critical\n2.14.1,log4j,1\n8.0.33,mysql,0\n", b.String())
+}
+
+func TestWritesTheSameExportForTheSameBom(t *testing.T) {
+	var a, b bytes.Buffer
+	require.NoError(t, bomcsv.Write(&a, nil, components, vulnerabilities, nil))
+	require.NoError(t, bomcsv.Write(&b, nil, []shared.BomComponent{components[1], components[0]}, vulnerabilities, nil))
+	require.Equal(t, a.String(), b.String())
+}
+
+func TestRejectsUnknownColumns(t *testing.T) {
+	require.EqualError(t, bomcsv.Validate([]string{"component", "price"}), "unknown csv column price")
+}
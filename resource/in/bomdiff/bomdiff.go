@@ -0,0 +1,228 @@
+package bomdiff
+
+import (
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"sort"
+	"strings"
+)
+
+// Bom of a version, as it is compared. Ignored components aren't part of it.
+type Bom struct {
+	Version         string
+	Components      []shared.BomComponent
+	Vulnerabilities []shared.VulnerableComponent
+}
+
+type Diff struct {
+	From               string          `json:"from"`
+	To                 string          `json:"to"`
+	Added              []Component     `json:"added"`
+	Removed            []Component     `json:"removed"`
+	Changed            []VersionChange `json:"changed"`
+	NewVulnerabilities []Vulnerability `json:"newVulnerabilities"`
+	NewViolations      []Component     `json:"newPolicyViolations"`
+}
+
+type Component struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type VersionChange struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type Vulnerability struct {
+	Name      string `json:"name"`
+	Severity  string `json:"severity"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+}
+
+// Compare finds what the BOM of to adds to the BOM of from.
+// Components are matched by name, so that different versions of a component are reported as a version change.
+// Vulnerabilities and policy violations are new, when the component didn't have them before in any version.
+// Only open vulnerabilities of components, which aren't ignored, are reported.
+func Compare(from Bom, to Bom) Diff {
+	diff := Diff{
+		From:               from.Version,
+		To:                 to.Version,
+		Added:              []Component{},
+		Removed:            []Component{},
+		Changed:            []VersionChange{},
+		NewVulnerabilities: []Vulnerability{},
+		NewViolations:      []Component{},
+	}
+	fromVersions := versionsByName(from.Components)
+	toVersions := versionsByName(to.Components)
+	for _, name := range names(fromVersions, toVersions) {
+		removed := subtract(fromVersions[name], toVersions[name])
+		added := subtract(toVersions[name], fromVersions[name])
+		for len(removed) != 0 && len(added) != 0 {
+			diff.Changed = append(diff.Changed, VersionChange{Name: name, From: removed[0], To: added[0]})
+			removed, added = removed[1:], added[1:]
+		}
+		for _, version := range removed {
+			diff.Removed = append(diff.Removed, Component{Name: name, Version: version})
+		}
+		for _, version := range added {
+			diff.Added = append(diff.Added, Component{Name: name, Version: version})
+		}
+	}
+
+	known := map[string]bool{}
+	for _, vulnerable := range from.Vulnerabilities {
+		known[vulnerable.ComponentName+"/"+vulnerable.Vulnerability.Name] = true
+	}
+	ignored := map[string]bool{}
+	for _, component := range to.Components {
+		if component.Ignored {
+			ignored[component.ComponentName+"/"+component.ComponentVersionName] = true
+		}
+	}
+	for _, vulnerable := range to.Vulnerabilities {
+		if !vulnerable.Vulnerability.Open() || ignored[vulnerable.ComponentName+"/"+vulnerable.ComponentVersionName] {
+			continue
+		}
+		key := vulnerable.ComponentName + "/" + vulnerable.Vulnerability.Name
+		if !known[key] {
+			known[key] = true
+			diff.NewVulnerabilities = append(diff.NewVulnerabilities, Vulnerability{
+				Name:      vulnerable.Vulnerability.Name,
+				Severity:  vulnerable.Vulnerability.Severity,
+				Component: vulnerable.ComponentName,
+				Version:   vulnerable.ComponentVersionName,
+			})
+		}
+	}
+	sort.SliceStable(diff.NewVulnerabilities, func(i, j int) bool {
+		a, b := diff.NewVulnerabilities[i], diff.NewVulnerabilities[j]
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		return a.Name < b.Name
+	})
+
+	violating := map[string]bool{}
+	for _, component := range from.Components {
+		if !component.Ignored && component.InViolation() {
+			violating[component.ComponentName] = true
+		}
+	}
+	for _, component := range sorted(to.Components) {
+		if !component.Ignored && component.InViolation() && !violating[component.ComponentName] {
+			diff.NewViolations = append(diff.NewViolations, Component{Name: component.ComponentName, Version: component.ComponentVersionName})
+		}
+	}
+	return diff
+}
+
+// Empty tells whether the BOM didn't change at all.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 &&
+		len(d.NewVulnerabilities) == 0 && len(d.NewViolations) == 0
+}
+
+func versionsByName(components []shared.BomComponent) map[string][]string {
+	versions := map[string][]string{}
+	for _, component := range components {
+		if !component.Ignored {
+			versions[component.ComponentName] = append(versions[component.ComponentName], component.ComponentVersionName)
+		}
+	}
+	for name := range versions {
+		sort.Strings(versions[name])
+	}
+	return versions
+}
+
+func names(a map[string][]string, b map[string][]string) []string {
+	var names []string
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, found := a[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// subtract returns the versions of a, which aren't in b.
+func subtract(a []string, b []string) []string {
+	var rest []string
+	for _, version := range a {
+		found := false
+		for _, other := range b {
+			found = found || version == other
+		}
+		if !found {
+			rest = append(rest, version)
+		}
+	}
+	return rest
+}
+
+func sorted(components []shared.BomComponent) []shared.BomComponent {
+	result := append([]shared.BomComponent{}, components...)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].ComponentName != result[j].ComponentName {
+			return result[i].ComponentName < result[j].ComponentName
+		}
+		return result[i].ComponentVersionName < result[j].ComponentVersionName
+	})
+	return result
+}
+
+// Markdown renders the diff for reviewers, e.g. as a comment of a pull request.
+func (d Diff) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# BOM changes of %v compared to %v\n", escape(d.To), escape(d.From))
+	if d.Empty() {
+		b.WriteString("\nNo changes.\n")
+		return b.String()
+	}
+	section(&b, "Added components", len(d.Added), "| Component | Version |\n|---|---|\n", func(i int) string {
+		return row(d.Added[i].Name, d.Added[i].Version)
+	})
+	section(&b, "Removed components", len(d.Removed), "| Component | Version |\n|---|---|\n", func(i int) string {
+		return row(d.Removed[i].Name, d.Removed[i].Version)
+	})
+	section(&b, "Changed versions", len(d.Changed), "| Component | From | To |\n|---|---|---|\n", func(i int) string {
+		return row(d.Changed[i].Name, d.Changed[i].From, d.Changed[i].To)
+	})
+	section(&b, "New vulnerabilities", len(d.NewVulnerabilities), "| Vulnerability | Severity | Component | Version |\n|---|---|---|---|\n", func(i int) string {
+		v := d.NewVulnerabilities[i]
+		return row(v.Name, v.Severity, v.Component, v.Version)
+	})
+	section(&b, "New policy violations", len(d.NewViolations), "| Component | Version |\n|---|---|\n", func(i int) string {
+		return row(d.NewViolations[i].Name, d.NewViolations[i].Version)
+	})
+	return b.String()
+}
+
+func section(b *strings.Builder, title string, count int, header string, line func(i int) string) {
+	if count == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %v (%v)\n\n%v", title, count, header)
+	for i := 0; i < count; i++ {
+		b.WriteString(line(i))
+	}
+}
+
+func row(cells ...string) string {
+	for i := range cells {
+		cells[i] = escape(cells[i])
+	}
+	return "| " + strings.Join(cells, " | ") + " |\n"
+}
+
+func escape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,136 @@
+package bomdiff_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/in/bomdiff"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func component(name string, version string, policyStatus string) shared.BomComponent {
+	return shared.BomComponent{ComponentName: name, ComponentVersionName: version, PolicyStatus: policyStatus}
+}
+
+func vulnerable(name string, version string, vulnerability string, severity string) shared.VulnerableComponent {
+	return shared.VulnerableComponent{
+		ComponentName:        name,
+		ComponentVersionName: version,
+		Vulnerability:        shared.VulnerabilityWithRemediation{Name: vulnerability, Severity: severity},
+	}
+}
+
+var from = bomdiff.Bom{
+	Version: "1.0.0",
+	Components: []shared.BomComponent{
+		component("log4j", "2.14.1", "IN_VIOLATION"),
+		component("guava", "30.0", "NOT_IN_VIOLATION"),
+		component("junit", "4.13", "NOT_IN_VIOLATION"),
+	},
+	Vulnerabilities: []shared.VulnerableComponent{
+		vulnerable("log4j", "2.14.1", "CVE-2021-44228", "CRITICAL"),
+	},
+}
+
+var to = bomdiff.Bom{
+	Version: "1.1.0",
+	Components: []shared.BomComponent{
+		component("log4j", "2.15.0", "IN_VIOLATION"),
+		component("guava", "30.0", "NOT_IN_VIOLATION"),
+		component("commons-text", "1.9", "IN_VIOLATION"),
+		{ComponentName: "mockito", ComponentVersionName: "4.0", Ignored: true, PolicyStatus: "IN_VIOLATION"},
+	},
+	Vulnerabilities: []shared.VulnerableComponent{
+		vulnerable("log4j", "2.15.0", "CVE-2021-44228", "CRITICAL"),
+		vulnerable("log4j", "2.15.0", "CVE-2021-45046", "CRITICAL"),
+		vulnerable("commons-text", "1.9", "CVE-2022-42889", "CRITICAL"),
+	},
+}
+
+func TestComparesTheBoms(t *testing.T) {
+	diff := bomdiff.Compare(from, to)
+	require.Equal(t, bomdiff.Diff{
+		From:    "1.0.0",
+		To:      "1.1.0",
+		Added:   []bomdiff.Component{{Name: "commons-text", Version: "1.9"}},
+		Removed: []bomdiff.Component{{Name: "junit", Version: "4.13"}},
+		Changed: []bomdiff.VersionChange{{Name: "log4j", From: "2.14.1", To: "2.15.0"}},
+		NewVulnerabilities: []bomdiff.Vulnerability{
+			{Name: "CVE-2022-42889", Severity: "CRITICAL", Component: "commons-text", Version: "1.9"},
+			{Name: "CVE-2021-45046", Severity: "CRITICAL", Component: "log4j", Version: "2.15.0"},
+		},
+		NewViolations: []bomdiff.Component{{Name: "commons-text", Version: "1.9"}},
+	}, diff)
+}
+
+func TestReportsSeveralVersionsOfAComponent(t *testing.T) {
+	diff := bomdiff.Compare(
+		bomdiff.Bom{Components: []shared.BomComponent{component("guava", "30.0", "")}},
+		bomdiff.Bom{Components: []shared.BomComponent{component("guava", "31.0", ""), component("guava", "32.0", "")}},
+	)
+	require.Equal(t, []bomdiff.VersionChange{{Name: "guava", From: "30.0", To: "31.0"}}, diff.Changed)
+	require.Equal(t, []bomdiff.Component{{Name: "guava", Version: "32.0"}}, diff.Added)
+}
+
+func TestReportsOnlyOpenVulnerabilitiesOfComponentsInUse(t *testing.T) {
+	remediated := vulnerable("log4j", "2.15.0", "CVE-2021-45046", "CRITICAL")
+	remediated.Vulnerability.RemediationStatus = "PATCHED"
+	diff := bomdiff.Compare(
+		bomdiff.Bom{},
+		bomdiff.Bom{
+			Components: []shared.BomComponent{
+				component("log4j", "2.15.0", ""),
+				{ComponentName: "mockito", ComponentVersionName: "4.0", Ignored: true},
+			},
+			Vulnerabilities: []shared.VulnerableComponent{
+				vulnerable("log4j", "2.15.0", "CVE-2021-44228", "CRITICAL"),
+				remediated,
+				vulnerable("mockito", "4.0", "CVE-2023-0001", "HIGH"),
+			},
+		},
+	)
+	require.Equal(t, []bomdiff.Vulnerability{
+		{Name: "CVE-2021-44228", Severity: "CRITICAL", Component: "log4j", Version: "2.15.0"},
+	}, diff.NewVulnerabilities)
+}
+
+func TestRendersTheDiffAsMarkdown(t *testing.T) {
+	require.Equal(t, `# BOM changes of 1.1.0 compared to 1.0.0
+
+## Added components (1)
+
+| Component | Version |
+|---|---|
+| commons-text | 1.9 |
+
+## Removed components (1)
+
+| Component | Version |
+|---|---|
+| junit | 4.13 |
+
+## Changed versions (1)
+
+| Component | From | To |
+|---|---|---|
+| log4j | 2.14.1 | 2.15.0 |
+
+## New vulnerabilities (2)
+
+| Vulnerability | Severity | Component | Version |
+|---|---|---|---|
+| CVE-2022-42889 | CRITICAL | commons-text | 1.9 |
+| CVE-2021-45046 | CRITICAL | log4j | 2.15.0 |
+
+## New policy violations (1)
+
+| Component | Version |
+|---|---|
+| commons-text | 1.9 |
+`, bomdiff.Compare(from, to).Markdown())
+}
+
+func TestRendersNoChanges(t *testing.T) {
+	diff := bomdiff.Compare(from, from)
+	require.True(t, diff.Empty())
+	require.Equal(t, "# BOM changes of 1.0.0 compared to 1.0.0\n\nNo changes.\n", diff.Markdown())
+}
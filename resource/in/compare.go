@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/in/bomdiff"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	diffJson     = "bom_diff.json"
+	diffMarkdown = "bom_diff.md"
+)
+
+// compare writes the changes of the BOM of the version compared to the version selected by compareTo into the destination.
+func (r *Runner) compare(source shared.Source, compareTo string, versions []shared.Version, version shared.Version) ([]Meta, error) {
+	var others []shared.Version
+	for _, other := range versions {
+		if other.Name != version.Name {
+			others = append(others, other)
+		}
+	}
+	from, err := shared.SelectVersion(others, compareTo, version.Name)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil {
+		return nil, fmt.Errorf("could not find version %v to compare %v to", compareTo, version.Name)
+	}
+	fromBom, err := r.getBom(source, *from)
+	if err != nil {
+		return nil, err
+	}
+	toBom, err := r.getBom(source, version)
+	if err != nil {
+		return nil, err
+	}
+	diff := bomdiff.Compare(fromBom, toBom)
+
+	b, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(r.path, diffJson), b, 0644); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(r.path, diffMarkdown), []byte(diff.Markdown()), 0644); err != nil {
+		return nil, err
+	}
+	return []Meta{
+		{Name: "comparedTo", Value: from.Name},
+		{Name: "addedComponents", Value: strconv.Itoa(len(diff.Added))},
+		{Name: "removedComponents", Value: strconv.Itoa(len(diff.Removed))},
+		{Name: "changedComponents", Value: strconv.Itoa(len(diff.Changed))},
+		{Name: "newVulnerabilities", Value: strconv.Itoa(len(diff.NewVulnerabilities))},
+		{Name: "newPolicyViolations", Value: strconv.Itoa(len(diff.NewViolations))},
+	}, nil
+}
+
+func (r *Runner) getBom(source shared.Source, version shared.Version) (bomdiff.Bom, error) {
+	components, err := r.api.GetBomComponents(source, &version)
+	if err != nil {
+		return bomdiff.Bom{}, err
+	}
+	vulnerabilities, err := r.api.GetVulnerableComponents(source, &version)
+	if err != nil {
+		return bomdiff.Bom{}, err
+	}
+	return bomdiff.Bom{Version: version.Name, Components: components, Vulnerabilities: vulnerabilities}, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var compared = time.Date(2022, 1, 10, 9, 0, 0, 0, time.UTC)
+
+var comparedVersions = []shared.Version{
+	{Name: "1.0.0", Phase: "RELEASED", Created: compared.Add(-2 * time.Hour), Updated: compared.Add(-2 * time.Hour)},
+	{Name: "1.1.0", Phase: "RELEASED", Created: compared.Add(-time.Hour), Updated: compared.Add(-time.Hour)},
+	{Name: "1.2.0", Phase: "DEVELOPMENT", Created: compared, Updated: compared},
+}
+
+func TestComparesTheBomToTheLatestReleasedVersion(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, compared, `{"compare_to": "latest_released"}`, comparedVersions...)
+	fakeBlackduckApi.GetBomComponentsStub = func(source shared.Source, version *shared.Version) ([]shared.BomComponent, error) {
+		if version.Name == "1.2.0" {
+			return []shared.BomComponent{{ComponentName: "log4j", ComponentVersionName: "2.15.0"}}, nil
+		}
+		return []shared.BomComponent{{ComponentName: "log4j", ComponentVersionName: "2.14.1"}}, nil
+	}
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, version := fakeBlackduckApi.GetBomComponentsArgsForCall(0); version.Name != "1.1.0" {
+		t.Errorf("Expected to compare to 1.1.0, but was %v", version.Name)
+	}
+	if !strings.Contains(r.stdOut.(*bytes.Buffer).String(), `{"name":"comparedTo","value":"1.1.0"},{"name":"addedComponents","value":"0"},`+
+		`{"name":"removedComponents","value":"0"},{"name":"changedComponents","value":"1"}`) {
+		t.Errorf("Expected the comparison in the metadata, but got %v", r.stdOut)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(r.path, "bom_diff.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"changed": [
+    {
+      "name": "log4j",
+      "from": "2.14.1",
+      "to": "2.15.0"
+    }
+  ]`) {
+		t.Errorf("Expected the version change of log4j, but got %v", string(b))
+	}
+	b, err = ioutil.ReadFile(filepath.Join(r.path, "bom_diff.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "| log4j | 2.14.1 | 2.15.0 |") {
+		t.Errorf("Expected the version change of log4j, but got %v", string(b))
+	}
+	if _, err := ioutil.ReadFile("latest_version.json"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestComparesTheBomToThePreviousVersion(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, compared, `{"compare_to": "previous"}`, comparedVersions...)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, version := fakeBlackduckApi.GetVulnerableComponentsArgsForCall(0); version.Name != "1.1.0" {
+		t.Errorf("Expected to compare to 1.1.0, but was %v", version.Name)
+	}
+}
+
+func TestErrorsWhenTheVersionToCompareToIsMissing(t *testing.T) {
+	r, _ := prepareRunner(t, compared, `{"compare_to": "0.9.0"}`, comparedVersions...)
+
+	err := r.run()
+	if err == nil || err.Error() != "could not find version 0.9.0 to compare 1.2.0 to" {
+		t.Errorf("Expected the missing version to fail, but got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/elgohr/concourse-blackduck/shared/sharedfakes"
+	"os"
+	"testing"
+	"time"
+)
+
+const request = `{
+		"source": {
+			"url": "http://blackduck",
+			"username": "username",
+			"password": "password",
+			"name": "project1"
+		},
+		"version": {"ref": "%v"},
+		"params": %v
+	}`
+
+// prepareRunner gets the version of project1, which was updated at the ref, with the params into an empty directory.
+func prepareRunner(t *testing.T, ref time.Time, params string, versions ...shared.Version) (Runner, *sharedfakes.FakeBlackduckApi) {
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1"}, nil)
+	fakeBlackduckApi.GetProjectVersionsReturns(versions, nil)
+	t.Cleanup(func() { _ = os.Remove("latest_version.json") })
+	return Runner{
+		stdIn:         bytes.NewBufferString(fmt.Sprintf(request, ref, params)),
+		stdOut:        &bytes.Buffer{},
+		stdErr:        &bytes.Buffer{},
+		path:          t.TempDir(),
+		pollInterval:  time.Millisecond,
+		reportTimeout: time.Minute,
+		api:           fakeBlackduckApi,
+	}, fakeBlackduckApi
+}
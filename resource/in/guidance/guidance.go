@@ -0,0 +1,86 @@
+package guidance
+
+import (
+	"sort"
+	"strings"
+)
+
+// Guidance for upgrading a vulnerable component version.
+type Guidance struct {
+	Component       string          `json:"component"`
+	Version         string          `json:"version"`
+	Vulnerabilities []string        `json:"vulnerabilities"`
+	ShortTerm       *Recommendation `json:"shortTerm,omitempty"`
+	LongTerm        *Recommendation `json:"longTerm,omitempty"`
+}
+
+// Recommendation is a version to upgrade to with the vulnerabilities, which the upgrade resolves.
+type Recommendation struct {
+	Version  string   `json:"version"`
+	Resolves []string `json:"resolves"`
+}
+
+// String describes the recommendation, e.g. 2.17.1 (resolves CVE-2021-44228).
+func (r Recommendation) String() string {
+	if len(r.Resolves) == 0 {
+		return r.Version
+	}
+	return r.Version + " (resolves " + strings.Join(r.Resolves, ", ") + ")"
+}
+
+// Resolved reports whether upgrading resolves the vulnerability.
+func (r *Recommendation) Resolved(vulnerability string) bool {
+	if r == nil {
+		return false
+	}
+	for _, name := range r.Resolves {
+		if name == vulnerability {
+			return true
+		}
+	}
+	return false
+}
+
+// Guidances map the Key of vulnerable component versions to their guidance.
+type Guidances map[string]Guidance
+
+// Key identifies a component version.
+func Key(component string, version string) string {
+	return component + "/" + version
+}
+
+// Get returns the guidance for the component version. It's empty, when there is none.
+func (g Guidances) Get(component string, version string) Guidance {
+	return g[Key(component, version)]
+}
+
+// Sorted returns the guidances sorted by component and version.
+func (g Guidances) Sorted() []Guidance {
+	sorted := []Guidance{}
+	for _, guidance := range g {
+		sorted = append(sorted, guidance)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Component != sorted[j].Component {
+			return sorted[i].Component < sorted[j].Component
+		}
+		return sorted[i].Version < sorted[j].Version
+	})
+	return sorted
+}
+
+// NewRecommendation resolves the current vulnerabilities, which the recommended version doesn't have anymore.
+func NewRecommendation(version string, current []string, remaining []string) *Recommendation {
+	left := map[string]bool{}
+	for _, name := range remaining {
+		left[name] = true
+	}
+	recommendation := &Recommendation{Version: version, Resolves: []string{}}
+	for _, name := range current {
+		if !left[name] {
+			recommendation.Resolves = append(recommendation.Resolves, name)
+		}
+	}
+	sort.Strings(recommendation.Resolves)
+	return recommendation
+}
@@ -0,0 +1,37 @@
+package guidance_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestResolvesTheVulnerabilitiesWhichTheVersionDoesntHave(t *testing.T) {
+	r := guidance.NewRecommendation("2.15.0", []string{"CVE-2021-45046", "CVE-2021-44228"}, []string{"CVE-2021-45046", "CVE-2021-45105"})
+	require.Equal(t, &guidance.Recommendation{Version: "2.15.0", Resolves: []string{"CVE-2021-44228"}}, r)
+	require.True(t, r.Resolved("CVE-2021-44228"))
+	require.False(t, r.Resolved("CVE-2021-45046"))
+	require.Equal(t, "2.15.0 (resolves CVE-2021-44228)", r.String())
+}
+
+func TestDescribesRecommendationsWhichResolveNothing(t *testing.T) {
+	r := guidance.NewRecommendation("2.15.0", []string{"CVE-2021-45046"}, []string{"CVE-2021-45046"})
+	require.Empty(t, r.Resolves)
+	require.Equal(t, "2.15.0", r.String())
+	var missing *guidance.Recommendation
+	require.False(t, missing.Resolved("CVE-2021-45046"))
+}
+
+func TestSortsTheGuidances(t *testing.T) {
+	g := guidance.Guidances{
+		guidance.Key("log4j", "2.14.1"): {Component: "log4j", Version: "2.14.1"},
+		guidance.Key("guava", "30.0"):   {Component: "guava", Version: "30.0"},
+		guidance.Key("log4j", "2.13.0"): {Component: "log4j", Version: "2.13.0"},
+	}
+	require.Equal(t, []guidance.Guidance{
+		{Component: "guava", Version: "30.0"},
+		{Component: "log4j", Version: "2.13.0"},
+		{Component: "log4j", Version: "2.14.1"},
+	}, g.Sorted())
+	require.Empty(t, g.Get("mockito", "4.0"))
+}
@@ -9,30 +9,38 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"time"
 )
 
 func main() {
 	runner := NewRunner()
 	if err := runner.run(); err != nil {
 		fmt.Fprintf(runner.stdOut, `{}`)
+		log.SetOutput(runner.stdErr)
 		log.Fatalln(err)
 	}
 }
 
 type Runner struct {
-	stdIn  io.Reader
-	stdOut io.Writer
-	stdErr io.Writer
-	api    shared.BlackduckApi
+	stdIn         io.Reader
+	stdOut        io.Writer
+	stdErr        io.Writer
+	path          string
+	pollInterval  time.Duration
+	reportTimeout time.Duration
+	api           shared.BlackduckApi
 }
 
 func NewRunner() Runner {
 	bd := shared.NewBlackduck()
 	return Runner{
-		stdIn:  os.Stdin,
-		stdOut: os.Stdout,
-		stdErr: os.Stderr,
-		api:    &bd,
+		stdIn:         os.Stdin,
+		stdOut:        os.Stdout,
+		stdErr:        os.Stderr,
+		path:          os.Args[1],
+		pollInterval:  5 * time.Second,
+		reportTimeout: noticesTimeout,
+		api:           &bd,
 	}
 }
 
@@ -41,9 +49,13 @@ func (r *Runner) run() error {
 	if err := json.NewDecoder(r.stdIn).Decode(&input); err != nil {
 		return errors.Wrap(err, "Decode")
 	}
+	r.stdErr = shared.NewRedactingWriter(r.stdErr, input.Source)
 	if !input.Source.Valid() {
 		return errors.New("source is invalid")
 	}
+	if err := validReports(input.Params); err != nil {
+		return err
+	}
 
 	project, err := r.api.GetProjectByName(input.Source)
 	if err != nil {
@@ -57,15 +69,27 @@ func (r *Runner) run() error {
 	var output []byte
 	for _, v := range versions {
 		if v.Updated.String() == input.Version.Ref {
+			meta := []Meta{
+				{Name: "versionName", Value: v.Name},
+				{Name: "phase", Value: v.Phase},
+				{Name: "settingUpdatedAt", Value: v.Updated.String()},
+			}
+			meta = append(meta, r.getRisk(input.Source, v)...)
+			if len(input.Params.CompareTo) != 0 {
+				compared, err := r.compare(input.Source, input.Params.CompareTo, versions, v)
+				if err != nil {
+					return err
+				}
+				meta = append(meta, compared...)
+			}
+			if err := r.writeReports(input, v); err != nil {
+				return err
+			}
 			output, err = json.Marshal(Output{
 				Ref: shared.Ref{
 					Ref: v.Updated.String(),
 				},
-				Meta: []Meta{
-					{Name: "versionName", Value: v.Name},
-					{Name: "phase", Value: v.Phase},
-					{Name: "settingUpdatedAt", Value: v.Updated.String()},
-				},
+				Meta: meta,
 			})
 			if err != nil {
 				return errors.Wrap(err, "Marshal")
@@ -8,6 +8,7 @@ import (
 	"github.com/elgohr/concourse-blackduck/shared/sharedfakes"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -32,6 +33,9 @@ func clean(t *testing.T) {
 }
 
 func TestConstructsRunnerCorrectly(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = []string{"programBin", "path-to-destination"}
 	r := NewRunner()
 	if r.stdIn != os.Stdin {
 		t.Error("Didn't set stdIn correctly")
@@ -42,6 +46,15 @@ func TestConstructsRunnerCorrectly(t *testing.T) {
 	if r.stdErr != os.Stderr {
 		t.Error("Didn't set stdErr correctly")
 	}
+	if r.path != "path-to-destination" {
+		t.Error("Expected the path to come from program args")
+	}
+	if r.pollInterval != 5*time.Second {
+		t.Error("Didn't set pollInterval correctly")
+	}
+	if r.reportTimeout != 10*time.Minute {
+		t.Error("Didn't set reportTimeout correctly")
+	}
 	if r.api == nil {
 		t.Error("Didn't set Blackduck Api")
 	}
@@ -60,6 +73,11 @@ func TestQueriesForTheLatestVersionsInChronologicalOrder(t *testing.T) {
 			Phase:   "TEST_PHASE",
 		},
 	}, nil)
+	fakeBlackduckApi.GetRiskProfileReturns(shared.RiskProfile{Categories: map[string]map[string]int{
+		"VULNERABILITY": {"CRITICAL": 1, "HIGH": 2},
+		"LICENSE":       {"HIGH": 3},
+	}}, nil)
+	fakeBlackduckApi.GetPolicyStatusReturns(shared.PolicyStatus{OverallStatus: "IN_VIOLATION"}, nil)
 
 	stdIn.WriteString(fmt.Sprintf(`{
 				"source": {
@@ -75,7 +93,11 @@ func TestQueriesForTheLatestVersionsInChronologicalOrder(t *testing.T) {
 		t.Error(err)
 	}
 
-	expRes := fmt.Sprintf(`{"version":{"ref":"%v"},"metadata":[{"name":"versionName","value":"TEST_VERSION"},{"name":"phase","value":"TEST_PHASE"},{"name":"settingUpdatedAt","value":"%v"}]}`, now, now)
+	expRes := fmt.Sprintf(`{"version":{"ref":"%v"},"metadata":[{"name":"versionName","value":"TEST_VERSION"},{"name":"phase","value":"TEST_PHASE"},{"name":"settingUpdatedAt","value":"%v"},`+
+		`{"name":"securityCritical","value":"1"},{"name":"securityHigh","value":"2"},{"name":"securityMedium","value":"0"},{"name":"securityLow","value":"0"},`+
+		`{"name":"licenseHigh","value":"3"},{"name":"licenseMedium","value":"0"},{"name":"licenseLow","value":"0"},`+
+		`{"name":"operationalHigh","value":"0"},{"name":"operationalMedium","value":"0"},{"name":"operationalLow","value":"0"},`+
+		`{"name":"policyStatus","value":"IN_VIOLATION"}]}`, now, now)
 	if stdOut.String() != expRes {
 		t.Errorf(`Expected: %v
            Got:      %v`, expRes, stdOut.String())
@@ -213,3 +235,36 @@ func TestErrorsWhenGetProjectVersionReturnsAnError(t *testing.T) {
 		t.Errorf("Should return %v, but was %v", msg, err.Error())
 	}
 }
+
+func TestLogsWhenTheRiskProfileCantBeFetched(t *testing.T) {
+	stdIn, stdOut, fakeBlackduckApi, r := setup()
+	stdErr := &bytes.Buffer{}
+	r.stdErr = stdErr
+	defer clean(t)
+
+	now := time.Now()
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "TEST_PROJECT"}, nil)
+	fakeBlackduckApi.GetProjectVersionsReturns([]shared.Version{{Updated: now, Name: "TEST_VERSION"}}, nil)
+	fakeBlackduckApi.GetRiskProfileReturns(shared.RiskProfile{}, errors.New("GetRiskProfile: missing link to the risk profile"))
+
+	stdIn.WriteString(fmt.Sprintf(`{
+				"source": {
+	    			"url": "http://blackduck",
+					"username": "username",
+	    			"password": "password",
+					"name": "project1"
+	  			},
+				"version": {"ref": "%v"}
+			}`, now))
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(stdOut.String(), "policyStatus") {
+		t.Errorf("Expected no risk metadata, but got %v", stdOut.String())
+	}
+	msg := "Could not fetch the risk profile of project1 TEST_VERSION: GetRiskProfile: missing link to the risk profile"
+	if !strings.Contains(stdErr.String(), msg) {
+		t.Errorf("Expected %v to be logged, but got %v", msg, stdErr.String())
+	}
+}
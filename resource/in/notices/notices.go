@@ -0,0 +1,185 @@
+package notices
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/shared"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Data is what the templates of the notices are rendered with.
+type Data struct {
+	Project  string
+	Version  string
+	Notices  []Notice
+	Licenses []License
+}
+
+// Notice of a component version with the names of its licenses and its copyrights.
+type Notice struct {
+	Name       string
+	Version    string
+	Licenses   []string
+	Copyrights []string
+}
+
+func (n Notice) LicenseNames() string {
+	return strings.Join(n.Licenses, ", ")
+}
+
+type License struct {
+	Name string
+	Text string
+}
+
+// Key identifies a component version to find its copyrights.
+func Key(component shared.BomComponent) string {
+	return component.ComponentName + "/" + component.ComponentVersionName
+}
+
+// New lists every component version once, sorted by name and version. Ignored components aren't shipped and therefore not listed.
+// Texts map the names of licenses to their texts, which are listed once for all components.
+// Copyrights map the Key of a component to its copyrights.
+func New(project string, version string, components []shared.BomComponent, texts map[string]string, copyrights map[string][]string) Data {
+	data := Data{Project: project, Version: version, Notices: []Notice{}, Licenses: []License{}}
+	byKey := map[string]*Notice{}
+	var keys []string
+	licenses := map[string]bool{}
+	for _, component := range components {
+		if component.Ignored {
+			continue
+		}
+		key := Key(component)
+		notice, found := byKey[key]
+		if !found {
+			notice = &Notice{Name: component.ComponentName, Version: component.ComponentVersionName}
+			byKey[key] = notice
+			keys = append(keys, key)
+		}
+		for _, license := range component.Licenses {
+			notice.Licenses = append(notice.Licenses, license.LicenseDisplay)
+			licenses[license.LicenseDisplay] = true
+		}
+		notice.Copyrights = append(notice.Copyrights, copyrights[key]...)
+	}
+	for _, key := range keys {
+		notice := byKey[key]
+		notice.Licenses = unique(notice.Licenses)
+		notice.Copyrights = unique(notice.Copyrights)
+		data.Notices = append(data.Notices, *notice)
+	}
+	sort.SliceStable(data.Notices, func(i, j int) bool {
+		a, b := data.Notices[i], data.Notices[j]
+		if !strings.EqualFold(a.Name, b.Name) {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		return a.Version < b.Version
+	})
+	for _, name := range unique(keysOf(licenses)) {
+		data.Licenses = append(data.Licenses, License{Name: name, Text: strings.TrimSpace(texts[name])})
+	}
+	return data
+}
+
+func keysOf(m map[string]bool) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// unique sorts the values and removes empty and duplicate ones.
+func unique(values []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if len(v) != 0 && !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Text renders the notices with the template, or the default template when it's empty.
+func (d Data) Text(text string) ([]byte, error) {
+	if len(text) == 0 {
+		text = DefaultText
+	}
+	t, err := template.New("NOTICES.txt").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	err = t.Execute(&b, d)
+	return b.Bytes(), err
+}
+
+// Html renders the notices with the template, or the default template when it's empty.
+func (d Data) Html(text string) ([]byte, error) {
+	if len(text) == 0 {
+		text = DefaultHtml
+	}
+	t, err := htmltemplate.New("NOTICES.html").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	err = t.Execute(&b, d)
+	return b.Bytes(), err
+}
+
+const DefaultText = `THIRD-PARTY NOTICES
+
+{{.Project}} {{.Version}} includes the following third-party components.
+{{range .Notices}}
+--------------------------------------------------------------------------------
+{{.Name}} {{.Version}}
+{{- range .Copyrights}}
+{{.}}
+{{- end}}
+{{- if .Licenses}}
+Licenses: {{.LicenseNames}}
+{{- end}}
+{{end}}
+================================================================================
+LICENSES
+{{range .Licenses}}
+--------------------------------------------------------------------------------
+{{.Name}}
+{{- if .Text}}
+
+{{.Text}}
+{{- end}}
+{{end}}`
+
+const DefaultHtml = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Third-party notices of {{.Project}} {{.Version}}</title></head>
+<body>
+<h1>Third-party notices</h1>
+<p>{{.Project}} {{.Version}} includes the following third-party components.</p>
+{{- range .Notices}}
+<h2>{{.Name}} {{.Version}}</h2>
+{{- range .Copyrights}}
+<p>{{.}}</p>
+{{- end}}
+{{- if .Licenses}}
+<p>Licenses: {{.LicenseNames}}</p>
+{{- end}}
+{{- end}}
+<h1>Licenses</h1>
+{{- range .Licenses}}
+<h2>{{.Name}}</h2>
+{{- if .Text}}
+<pre>{{.Text}}</pre>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`
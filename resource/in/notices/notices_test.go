@@ -0,0 +1,81 @@
+package notices_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/in/notices"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var components = []shared.BomComponent{
+	{ComponentName: "log4j", ComponentVersionName: "2.14.1", Licenses: []shared.BomLicense{{LicenseDisplay: "Apache License 2.0"}}},
+	{ComponentName: "Commons Text", ComponentVersionName: "1.9", Licenses: []shared.BomLicense{{LicenseDisplay: "Apache License 2.0"}}},
+	{ComponentName: "log4j", ComponentVersionName: "2.14.1", Licenses: []shared.BomLicense{{LicenseDisplay: "Apache License 2.0"}, {LicenseDisplay: "MIT License"}}},
+	{ComponentName: "junit", ComponentVersionName: "4.13", Ignored: true, Licenses: []shared.BomLicense{{LicenseDisplay: "EPL 1.0"}}},
+}
+
+var texts = map[string]string{"Apache License 2.0": "Apache text\n", "MIT License": "MIT text"}
+
+var copyrights = map[string][]string{
+	"log4j/2.14.1": {"Copyright 1999-2021 Apache Software Foundation", "Copyright 1999-2021 Apache Software Foundation", " "},
+}
+
+func TestListsEveryComponentOnce(t *testing.T) {
+	data := notices.New("project1", "1.0.0", components, texts, copyrights)
+	require.Equal(t, []notices.Notice{
+		{Name: "Commons Text", Version: "1.9", Licenses: []string{"Apache License 2.0"}},
+		{
+			Name:       "log4j",
+			Version:    "2.14.1",
+			Licenses:   []string{"Apache License 2.0", "MIT License"},
+			Copyrights: []string{"Copyright 1999-2021 Apache Software Foundation"},
+		},
+	}, data.Notices)
+	require.Equal(t, []notices.License{{Name: "Apache License 2.0", Text: "Apache text"}, {Name: "MIT License", Text: "MIT text"}}, data.Licenses)
+}
+
+func TestRendersText(t *testing.T) {
+	b, err := notices.New("project1", "1.0.0", components, texts, copyrights).Text("")
+	require.NoError(t, err)
+	require.Equal(t, `THIRD-PARTY NOTICES
+
+project1 1.0.0 includes the following third-party components.
+
+--------------------------------------------------------------------------------
+Commons Text 1.9
+Licenses: Apache License 2.0
+
+--------------------------------------------------------------------------------
+log4j 2.14.1
+Copyright 1999-2021 Apache Software Foundation
+Licenses: Apache License 2.0, MIT License
+
+================================================================================
+LICENSES
+
+--------------------------------------------------------------------------------
+Apache License 2.0
+
+Apache text
+
+--------------------------------------------------------------------------------
+MIT License
+
+MIT text
+`, string(b))
+}
+
+func TestRendersHtml(t *testing.T) {
+	b, err := notices.New("project1", "1.0.0", []shared.BomComponent{{ComponentName: "<script>"}}, nil, nil).Html("")
+	require.NoError(t, err)
+	require.Contains(t, string(b), "<h2>&lt;script&gt; </h2>")
+}
+
+func TestRendersCustomTemplates(t *testing.T) {
+	data := notices.New("project1", "1.0.0", components, texts, copyrights)
+	b, err := data.Text("{{range .Notices}}{{.Name}};{{end}}")
+	require.NoError(t, err)
+	require.Equal(t, "Commons Text;log4j;", string(b))
+	_, err = data.Html("{{.Unknown}}")
+	require.Error(t, err)
+}
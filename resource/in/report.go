@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/in/bomcsv"
+	"github.com/elgohr/concourse-blackduck/in/bomdiff"
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/elgohr/concourse-blackduck/in/notices"
+	"github.com/elgohr/concourse-blackduck/in/sarif"
+	"github.com/elgohr/concourse-blackduck/in/summary"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/elgohr/concourse-blackduck/shared/junit"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	formatSummary = "summary"
+	formatSarif   = "sarif"
+	formatJunit   = "junit"
+	formatCsv     = "csv"
+	formatNotices = "notices"
+
+	noticesFromBom    = "bom"
+	noticesFromReport = "report"
+	// noticesTimeout limits the wait for the notices report of Blackduck.
+	noticesTimeout = 10 * time.Minute
+
+	summaryMarkdown = "summary.md"
+	summaryHtml     = "summary.html"
+	sarifLog        = "results.sarif"
+	junitReport     = "junit.xml"
+	bomCsv          = "bom.csv"
+	noticesText     = "NOTICES.txt"
+	noticesHtml     = "NOTICES.html"
+)
+
+var formats = []string{formatSummary, formatSarif, formatJunit, formatCsv, formatNotices}
+
+// validReports makes sure, that the reports can be written, before anything is fetched.
+func validReports(params shared.Params) error {
+	if err := bomcsv.Validate(params.CsvColumns); err != nil {
+		return err
+	}
+	if source := params.NoticesSource; len(source) != 0 && source != noticesFromBom && source != noticesFromReport {
+		return fmt.Errorf("unknown notices_source %v", source)
+	}
+	for _, format := range params.Formats {
+		valid := false
+		for _, known := range formats {
+			valid = valid || format == known
+		}
+		if !valid {
+			return fmt.Errorf("unknown format %v", format)
+		}
+	}
+	return nil
+}
+
+// writeReports writes the requested formats of the version into the destination.
+// The BOM is only fetched, when a format is requested, and the upgrade guidance, when a format recommends upgrades.
+func (r *Runner) writeReports(input shared.Request, version shared.Version) error {
+	if len(input.Params.Formats) == 0 {
+		return nil
+	}
+	bom, err := r.getBom(input.Source, version)
+	if err != nil {
+		return err
+	}
+	var guidances guidance.Guidances
+	if needsGuidance(input.Params) {
+		if guidances, err = r.getGuidance(input.Source, bom); err != nil {
+			return err
+		}
+		if err := r.writeGuidance(guidances); err != nil {
+			return err
+		}
+	}
+	for _, format := range input.Params.Formats {
+		switch format {
+		case formatSummary:
+			data := summary.New(input.Source.Name, version, bom.Components, bom.Vulnerabilities, guidances)
+			if err := r.writeSummary(data, input.Params); err != nil {
+				return err
+			}
+		case formatSarif:
+			if err := r.writeSarif(input.Source, bom, guidances); err != nil {
+				return err
+			}
+		case formatJunit:
+			if err := r.writeJunit(input.Source, bom); err != nil {
+				return err
+			}
+		case formatCsv:
+			if err := r.writeCsv(bom, input.Params.CsvColumns, guidances); err != nil {
+				return err
+			}
+		case formatNotices:
+			if err := r.writeNotices(input, version, bom); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Runner) writeSummary(data summary.Data, params shared.Params) error {
+	markdown, err := data.Markdown(params.SummaryTemplate)
+	if err != nil {
+		return fmt.Errorf("could not render %v: %w", summaryMarkdown, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(r.path, summaryMarkdown), markdown, 0644); err != nil {
+		return err
+	}
+	html, err := data.Html(params.SummaryHtml)
+	if err != nil {
+		return fmt.Errorf("could not render %v: %w", summaryHtml, err)
+	}
+	return ioutil.WriteFile(filepath.Join(r.path, summaryHtml), html, 0644)
+}
+
+func (r *Runner) writeSarif(source shared.Source, bom bomdiff.Bom, guidances guidance.Guidances) error {
+	locations, err := r.getLocations(source, bom)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(sarif.Convert(bom.Vulnerabilities, locations, guidances), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(r.path, sarifLog), b, 0644)
+}
+
+// getLocations finds the files, which the vulnerable components were matched in.
+// When a component was matched in several files, the first path is taken to be deterministic.
+func (r *Runner) getLocations(source shared.Source, bom bomdiff.Bom) (map[string]string, error) {
+	vulnerable := map[string]bool{}
+	for _, v := range bom.Vulnerabilities {
+		vulnerable[sarif.Key(v.ComponentName, v.ComponentVersionName)] = true
+	}
+	locations := map[string]string{}
+	for _, component := range bom.Components {
+		key := sarif.Key(component.ComponentName, component.ComponentVersionName)
+		if !vulnerable[key] || len(component.Meta.GetLinkFor("matched-files")) == 0 {
+			continue
+		}
+		files, err := r.api.GetMatchedFiles(source, component)
+		if err != nil {
+			return nil, err
+		}
+		var paths []string
+		for _, file := range files {
+			if path := strings.TrimPrefix(file.FilePath.Path, "/"); len(path) != 0 {
+				paths = append(paths, path)
+			}
+		}
+		if len(paths) != 0 {
+			sort.Strings(paths)
+			locations[key] = paths[0]
+		}
+	}
+	return locations, nil
+}
+
+// writeJunit reports the enabled policy rules as test suites of the components in the BOM.
+func (r *Runner) writeJunit(source shared.Source, bom bomdiff.Bom) error {
+	suites, err := junit.FromBom(r.api, source, bom.Version, bom.Components)
+	if err != nil {
+		return err
+	}
+	b, err := suites.Marshal()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(r.path, junitReport), b, 0644)
+}
+
+func (r *Runner) writeCsv(bom bomdiff.Bom, columns []string, guidances guidance.Guidances) error {
+	f, err := os.Create(filepath.Join(r.path, bomCsv))
+	if err != nil {
+		return err
+	}
+	if err := bomcsv.Write(f, columns, bom.Components, bom.Vulnerabilities, guidances); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeNotices writes the notices as text and HTML, either from the BOM or as Blackduck reports them.
+func (r *Runner) writeNotices(input shared.Request, version shared.Version, bom bomdiff.Bom) error {
+	if input.Params.NoticesSource == noticesFromReport {
+		for _, report := range [][2]string{{noticesText, shared.ReportFormatText}, {noticesHtml, shared.ReportFormatHtml}} {
+			content, err := r.getNoticesReport(input.Source, version, report[1])
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filepath.Join(r.path, report[0]), []byte(content), 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data, err := r.getNotices(input.Source, bom)
+	if err != nil {
+		return err
+	}
+	text, err := data.Text(input.Params.NoticesTemplate)
+	if err != nil {
+		return fmt.Errorf("could not render %v: %w", noticesText, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(r.path, noticesText), text, 0644); err != nil {
+		return err
+	}
+	html, err := data.Html(input.Params.NoticesHtml)
+	if err != nil {
+		return fmt.Errorf("could not render %v: %w", noticesHtml, err)
+	}
+	return ioutil.WriteFile(filepath.Join(r.path, noticesHtml), html, 0644)
+}
+
+// getNotices fetches the texts of the licenses and the active copyrights of the components, which aren't ignored.
+// Each license text is only fetched once.
+func (r *Runner) getNotices(source shared.Source, bom bomdiff.Bom) (notices.Data, error) {
+	texts := map[string]string{}
+	copyrights := map[string][]string{}
+	for _, component := range bom.Components {
+		if component.Ignored {
+			continue
+		}
+		for _, license := range component.Licenses {
+			if _, found := texts[license.LicenseDisplay]; found || len(license.License) == 0 {
+				continue
+			}
+			text, err := r.api.GetLicenseText(source, license)
+			if err != nil {
+				return notices.Data{}, err
+			}
+			texts[license.LicenseDisplay] = text
+		}
+		for _, origin := range component.Origins {
+			if len(origin.Origin) == 0 {
+				continue
+			}
+			found, err := r.api.GetCopyrights(source, origin)
+			if err != nil {
+				return notices.Data{}, err
+			}
+			for _, copyright := range found {
+				if copyright.Active {
+					copyrights[notices.Key(component)] = append(copyrights[notices.Key(component)], copyright.Text)
+				}
+			}
+		}
+	}
+	return notices.New(source.Name, bom.Version, bom.Components, texts, copyrights), nil
+}
+
+// getNoticesReport has Blackduck generate its notices report and waits for its content.
+func (r *Runner) getNoticesReport(source shared.Source, version shared.Version, format string) (string, error) {
+	report, err := r.api.CreateNoticesReport(source, &version, format)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.reportTimeout)
+	defer cancel()
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for report.Status != shared.ReportStatusCompleted {
+		if report.Status == shared.ReportStatusFailed {
+			return "", fmt.Errorf("the %v notices report of %v failed", format, version.Name)
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("the %v notices report of %v wasn't completed within %v", format, version.Name, r.reportTimeout)
+		case <-ticker.C:
+		}
+		if report, err = r.api.GetReport(source, report); err != nil {
+			return "", err
+		}
+	}
+	return r.api.GetReportContent(source, report)
+}
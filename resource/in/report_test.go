@@ -0,0 +1,268 @@
+package main
+
+import (
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var reported = time.Date(2022, 1, 10, 9, 0, 0, 0, time.UTC)
+
+var reportedVersion = shared.Version{Name: "1.0.0", Updated: reported, Meta: shared.Meta{Href: "http://blackduck/api/projects/1/versions/2"}}
+
+var log4jComponent = shared.BomComponent{ComponentName: "log4j", ComponentVersionName: "2.14.1", PolicyStatus: "IN_VIOLATION"}
+
+var log4jVulnerability = shared.VulnerableComponent{
+	ComponentName:        "log4j",
+	ComponentVersionName: "2.14.1",
+	Vulnerability:        shared.VulnerabilityWithRemediation{Name: "CVE-2021-44228", Severity: "CRITICAL", RemediationStatus: "NEW"},
+}
+
+func TestWritesTheSummary(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["summary"]}`, reportedVersion)
+	fakeBlackduckApi.GetBomComponentsReturns([]shared.BomComponent{log4jComponent}, nil)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(r.path, "summary.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "| log4j | 2.14.1 | 1 | 0 | 0 | 0 |") {
+		t.Errorf("Expected log4j in the summary, but got %v", string(b))
+	}
+	b, err = ioutil.ReadFile(filepath.Join(r.path, "summary.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `<a href="http://blackduck/api/projects/1/versions/2/components">`) {
+		t.Errorf("Expected a link to Blackduck in the summary, but got %v", string(b))
+	}
+}
+
+func TestWritesTheSummaryWithCustomTemplates(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["summary"], "summary_template": "{{.Risk.Critical}} critical", "summary_html_template": "<b>{{.Version}}</b>"}`, reportedVersion)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(r.path, "summary.md")); string(b) != "1 critical" {
+		t.Errorf("Expected the custom markdown template, but got %v", string(b))
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(r.path, "summary.html")); string(b) != "<b>1.0.0</b>" {
+		t.Errorf("Expected the custom html template, but got %v", string(b))
+	}
+}
+
+func TestFetchesNoBomWithoutFormats(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{}`, reportedVersion)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.GetBomComponentsCallCount() != 0 {
+		t.Error("Should not have fetched the BOM")
+	}
+}
+
+func TestErrorsOnUnknownFormats(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["pdf"]}`, reportedVersion)
+
+	if err := r.run(); err == nil || err.Error() != "unknown format pdf" {
+		t.Errorf("Expected the unknown format to fail, but got %v", err)
+	}
+	if fakeBlackduckApi.GetProjectByNameCallCount() != 0 {
+		t.Error("Should not have called Blackduck")
+	}
+}
+
+func TestWritesSarif(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["sarif"]}`, reportedVersion)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+	fakeBlackduckApi.GetBomComponentsReturns([]shared.BomComponent{{
+		ComponentName:        "log4j",
+		ComponentVersionName: "2.14.1",
+		Meta:                 shared.Meta{Links: []shared.Link{{Rel: "matched-files", Href: "log4j-files"}}},
+	}, {
+		ComponentName:        "guava",
+		ComponentVersionName: "30.0",
+		Meta:                 shared.Meta{Links: []shared.Link{{Rel: "matched-files", Href: "guava-files"}}},
+	}}, nil)
+	fakeBlackduckApi.GetMatchedFilesReturns([]shared.MatchedFile{
+		{FilePath: shared.FilePath{Path: "/services/pom.xml"}},
+		{FilePath: shared.FilePath{Path: "/pom.xml"}},
+	}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.GetMatchedFilesCallCount() != 1 {
+		t.Errorf("Expected only the matched files of vulnerable components, but got %v calls", fakeBlackduckApi.GetMatchedFilesCallCount())
+	}
+	b, err := ioutil.ReadFile(filepath.Join(r.path, "results.sarif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"uri": "pom.xml"`) || !strings.Contains(string(b), `"ruleId": "CVE-2021-44228"`) {
+		t.Errorf("Expected log4j declared in pom.xml, but got %v", string(b))
+	}
+}
+
+func TestWritesJunit(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["junit"]}`, reportedVersion)
+	fakeBlackduckApi.GetBomComponentsReturns([]shared.BomComponent{
+		{ComponentName: "log4j", ComponentVersionName: "2.14.1", PolicyStatus: "IN_VIOLATION"},
+		{ComponentName: "guava", ComponentVersionName: "30.0", PolicyStatus: "NOT_IN_VIOLATION"},
+		{ComponentName: "junit", ComponentVersionName: "4.13", PolicyStatus: "IN_VIOLATION", Ignored: true},
+	}, nil)
+	fakeBlackduckApi.GetPolicyRulesReturns([]shared.PolicyRule{
+		{Name: "No Critical Vulnerabilities", Enabled: true},
+		{Name: "Disabled", Enabled: false},
+	}, nil)
+	fakeBlackduckApi.GetComponentPolicyRulesReturns([]shared.PolicyRule{
+		{Name: "No Critical Vulnerabilities", PolicyApprovalStatus: "IN_VIOLATION"},
+	}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.GetComponentPolicyRulesCallCount() != 1 {
+		t.Errorf("Expected only the policy rules of log4j to be fetched, but got %v calls", fakeBlackduckApi.GetComponentPolicyRulesCallCount())
+	}
+	b, err := ioutil.ReadFile(filepath.Join(r.path, "junit.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<testsuite name="No Critical Vulnerabilities" tests="3" failures="1" skipped="1">`,
+		`<failure message="log4j 2.14.1 violates No Critical Vulnerabilities" type="PolicyViolation"></failure>`,
+	} {
+		if !strings.Contains(string(b), expected) {
+			t.Errorf("Expected %v in %v", expected, string(b))
+		}
+	}
+	if strings.Contains(string(b), "Disabled") {
+		t.Errorf("Should not report disabled policy rules, but got %v", string(b))
+	}
+}
+
+func TestWritesCsv(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["csv"], "csv_columns": ["component", "critical"]}`, reportedVersion)
+	fakeBlackduckApi.GetBomComponentsReturns([]shared.BomComponent{log4jComponent}, nil)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(r.path, "bom.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "component,critical\nlog4j,1\n" {
+		t.Errorf("Expected the configured columns, but got %v", string(b))
+	}
+}
+
+func TestErrorsOnUnknownCsvColumns(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["csv"], "csv_columns": ["price"]}`, reportedVersion)
+
+	if err := r.run(); err == nil || err.Error() != "unknown csv column price" {
+		t.Errorf("Expected the unknown column to fail, but got %v", err)
+	}
+	if fakeBlackduckApi.GetProjectByNameCallCount() != 0 {
+		t.Error("Should not have called Blackduck")
+	}
+}
+
+func TestWritesNoticesFromTheBom(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["notices"]}`, reportedVersion)
+	fakeBlackduckApi.GetBomComponentsReturns([]shared.BomComponent{
+		{
+			ComponentName:        "log4j",
+			ComponentVersionName: "2.14.1",
+			Licenses:             []shared.BomLicense{{LicenseDisplay: "Apache License 2.0", License: "apache"}},
+			Origins:              []shared.BomOrigin{{Origin: "log4j-origin"}},
+		},
+		{
+			ComponentName:        "commons-text",
+			ComponentVersionName: "1.9",
+			Licenses:             []shared.BomLicense{{LicenseDisplay: "Apache License 2.0", License: "apache"}},
+		},
+	}, nil)
+	fakeBlackduckApi.GetLicenseTextReturns("Apache text", nil)
+	fakeBlackduckApi.GetCopyrightsReturns([]shared.Copyright{
+		{Text: "Copyright Apache Software Foundation", Active: true},
+		{Text: "Copyright Someone Else", Active: false},
+	}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.GetLicenseTextCallCount() != 1 {
+		t.Errorf("Expected the license text to be fetched once, but got %v calls", fakeBlackduckApi.GetLicenseTextCallCount())
+	}
+	b, err := ioutil.ReadFile(filepath.Join(r.path, "NOTICES.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "log4j 2.14.1\nCopyright Apache Software Foundation\nLicenses: Apache License 2.0") ||
+		strings.Contains(string(b), "Someone Else") {
+		t.Errorf("Expected the active copyrights of log4j, but got %v", string(b))
+	}
+	if _, err := ioutil.ReadFile(filepath.Join(r.path, "NOTICES.html")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWritesTheNoticesReportOfBlackduck(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["notices"], "notices_source": "report"}`, reportedVersion)
+	fakeBlackduckApi.CreateNoticesReportStub = func(source shared.Source, version *shared.Version, format string) (shared.Report, error) {
+		return shared.Report{ReportFormat: format, Status: "IN_PROGRESS"}, nil
+	}
+	fakeBlackduckApi.GetReportStub = func(source shared.Source, report shared.Report) (shared.Report, error) {
+		report.Status = "COMPLETED"
+		return report, nil
+	}
+	fakeBlackduckApi.GetReportContentStub = func(source shared.Source, report shared.Report) (string, error) {
+		return report.ReportFormat + " notices", nil
+	}
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(r.path, "NOTICES.txt")); string(b) != "TEXT notices" {
+		t.Errorf("Expected the text report, but got %v", string(b))
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(r.path, "NOTICES.html")); string(b) != "HTML notices" {
+		t.Errorf("Expected the html report, but got %v", string(b))
+	}
+	if fakeBlackduckApi.GetLicenseTextCallCount() != 0 {
+		t.Error("Should not have fetched license texts")
+	}
+}
+
+func TestErrorsWhenTheNoticesReportFails(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["notices"], "notices_source": "report"}`, reportedVersion)
+	fakeBlackduckApi.CreateNoticesReportReturns(shared.Report{Status: "FAILED"}, nil)
+
+	if err := r.run(); err == nil || err.Error() != "the TEXT notices report of 1.0.0 failed" {
+		t.Errorf("Expected the failed report to fail, but got %v", err)
+	}
+}
+
+func TestErrorsWhenTheNoticesReportIsNotCompletedInTime(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["notices"], "notices_source": "report"}`, reportedVersion)
+	fakeBlackduckApi.CreateNoticesReportReturns(shared.Report{Status: "IN_PROGRESS"}, nil)
+	fakeBlackduckApi.GetReportReturns(shared.Report{Status: "IN_PROGRESS"}, nil)
+	r.reportTimeout = 10 * time.Millisecond
+
+	if err := r.run(); err == nil || err.Error() != "the TEXT notices report of 1.0.0 wasn't completed within 10ms" {
+		t.Errorf("Expected the report to time out, but got %v", err)
+	}
+}
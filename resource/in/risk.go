@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+)
+
+// getRisk returns the risk profile and the policy status of the version as metadata.
+// As the version can be fetched without them, it's only logged, when they can't be fetched.
+func (r *Runner) getRisk(source shared.Source, version shared.Version) []Meta {
+	risk, err := shared.GetRisk(r.api, source, &version)
+	if err != nil {
+		fmt.Fprintf(r.stdErr, "Could not fetch the risk profile of %v %v: %v\n", source.Name, version.Name, err)
+		return nil
+	}
+	var meta []Meta
+	for _, m := range risk {
+		meta = append(meta, Meta{Name: m.Name, Value: m.Value})
+	}
+	return meta
+}
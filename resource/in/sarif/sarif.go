@@ -0,0 +1,184 @@
+package sarif
+
+import (
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Log is the subset of SARIF 2.1.0, which is needed to report vulnerable components.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	InformationUri string `json:"informationUri"`
+	Rules          []Rule `json:"rules"`
+}
+
+type Rule struct {
+	Id                   string        `json:"id"`
+	ShortDescription     Message       `json:"shortDescription"`
+	FullDescription      *Message      `json:"fullDescription,omitempty"`
+	HelpUri              string        `json:"helpUri,omitempty"`
+	DefaultConfiguration Configuration `json:"defaultConfiguration"`
+	Properties           Properties    `json:"properties"`
+}
+
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+type Properties struct {
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+	Tags             []string `json:"tags"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Result struct {
+	RuleId       string        `json:"ruleId"`
+	Level        string        `json:"level"`
+	Message      Message       `json:"message"`
+	Locations    []Location    `json:"locations,omitempty"`
+	Suppressions []Suppression `json:"suppressions,omitempty"`
+	Properties   *Upgrades     `json:"properties,omitempty"`
+}
+
+// Upgrades are the recommended versions of the vulnerable component with the vulnerabilities they resolve.
+type Upgrades struct {
+	ShortTerm *guidance.Recommendation `json:"shortTermUpgrade,omitempty"`
+	LongTerm  *guidance.Recommendation `json:"longTermUpgrade,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+}
+
+type ArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+// Suppression marks vulnerabilities, which were remediated in Blackduck.
+type Suppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// Level maps the severity of Blackduck to the level of SARIF.
+func Level(severity string) string {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "LOW":
+		return "note"
+	}
+	return "warning"
+}
+
+// Key identifies a component version to find its location.
+func Key(component string, version string) string {
+	return component + "/" + version
+}
+
+// Convert reports every vulnerability as a result of the rule of its CVE or BDSA.
+// Locations map the Key of a component to the manifest, which declares it. Components without a location are reported without one.
+// When an upgrade of the guidance resolves the vulnerability, the message recommends it.
+func Convert(vulnerabilities []shared.VulnerableComponent, locations map[string]string, guidances guidance.Guidances) Log {
+	rules := map[string]Rule{}
+	results := []Result{}
+	for _, v := range vulnerabilities {
+		vulnerability := v.Vulnerability
+		if _, found := rules[vulnerability.Name]; !found {
+			rules[vulnerability.Name] = newRule(vulnerability)
+		}
+		result := Result{
+			RuleId:  vulnerability.Name,
+			Level:   Level(vulnerability.Severity),
+			Message: Message{Text: fmt.Sprintf("%v in %v %v (%v)", vulnerability.Name, v.ComponentName, v.ComponentVersionName, vulnerability.Severity)},
+		}
+		if g := guidances.Get(v.ComponentName, v.ComponentVersionName); g.ShortTerm != nil || g.LongTerm != nil {
+			result.Properties = &Upgrades{ShortTerm: g.ShortTerm, LongTerm: g.LongTerm}
+			if g.ShortTerm.Resolved(vulnerability.Name) {
+				result.Message.Text += fmt.Sprintf(". Upgrade to %v to resolve it.", g.ShortTerm.Version)
+			} else if g.LongTerm.Resolved(vulnerability.Name) {
+				result.Message.Text += fmt.Sprintf(". Upgrade to %v to resolve it.", g.LongTerm.Version)
+			}
+		}
+		if uri, found := locations[Key(v.ComponentName, v.ComponentVersionName)]; found {
+			result.Locations = []Location{{PhysicalLocation{ArtifactLocation{Uri: uri}}}}
+		}
+		if !vulnerability.Open() {
+			justification := vulnerability.RemediationStatus
+			if len(vulnerability.RemediationComment) != 0 {
+				justification += ": " + vulnerability.RemediationComment
+			}
+			result.Suppressions = []Suppression{{Kind: "external", Justification: justification}}
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].RuleId != results[j].RuleId {
+			return results[i].RuleId < results[j].RuleId
+		}
+		return results[i].Message.Text < results[j].Message.Text
+	})
+
+	driver := Driver{Name: "Black Duck", InformationUri: "https://www.blackduck.com", Rules: []Rule{}}
+	for _, rule := range rules {
+		driver.Rules = append(driver.Rules, rule)
+	}
+	sort.Slice(driver.Rules, func(i, j int) bool {
+		return driver.Rules[i].Id < driver.Rules[j].Id
+	})
+	return Log{
+		Version: Version,
+		Schema:  Schema,
+		Runs:    []Run{{Tool: Tool{Driver: driver}, Results: results}},
+	}
+}
+
+func newRule(vulnerability shared.VulnerabilityWithRemediation) Rule {
+	rule := Rule{
+		Id:                   vulnerability.Name,
+		ShortDescription:     Message{Text: fmt.Sprintf("%v (%v)", vulnerability.Name, vulnerability.Severity)},
+		DefaultConfiguration: Configuration{Level: Level(vulnerability.Severity)},
+		Properties:           Properties{Tags: []string{"security", "vulnerability"}},
+	}
+	if len(vulnerability.Description) != 0 {
+		rule.FullDescription = &Message{Text: vulnerability.Description}
+	}
+	if strings.HasPrefix(vulnerability.Name, "CVE-") {
+		rule.HelpUri = "https://nvd.nist.gov/vuln/detail/" + vulnerability.Name
+	}
+	if vulnerability.OverallScore > 0 {
+		rule.Properties.SecuritySeverity = strconv.FormatFloat(vulnerability.OverallScore, 'f', 1, 64)
+	}
+	return rule
+}
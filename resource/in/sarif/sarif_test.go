@@ -0,0 +1,114 @@
+package sarif_test
+
+import (
+	"encoding/json"
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/elgohr/concourse-blackduck/in/sarif"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func vulnerable(name string, version string, vulnerability shared.VulnerabilityWithRemediation) shared.VulnerableComponent {
+	return shared.VulnerableComponent{ComponentName: name, ComponentVersionName: version, Vulnerability: vulnerability}
+}
+
+func TestMapsSeveritiesToLevels(t *testing.T) {
+	for severity, level := range map[string]string{
+		"CRITICAL": "error",
+		"HIGH":     "error",
+		"MEDIUM":   "warning",
+		"LOW":      "note",
+		"":         "warning",
+	} {
+		require.Equal(t, level, sarif.Level(severity), severity)
+	}
+}
+
+func TestConvertsVulnerableComponents(t *testing.T) {
+	log := sarif.Convert([]shared.VulnerableComponent{
+		vulnerable("log4j", "2.14.1", shared.VulnerabilityWithRemediation{Name: "CVE-2021-44228", Description: "JNDI lookups", Severity: "CRITICAL", OverallScore: 10, RemediationStatus: "NEW"}),
+		vulnerable("log4j", "2.14.1", shared.VulnerabilityWithRemediation{Name: "BDSA-2021-3779", Severity: "MEDIUM", RemediationStatus: "NOT_AFFECTED", RemediationComment: "not used"}),
+		vulnerable("log4j-core", "2.14.1", shared.VulnerabilityWithRemediation{Name: "CVE-2021-44228", Severity: "CRITICAL", OverallScore: 10, RemediationStatus: "NEW"}),
+	}, map[string]string{sarif.Key("log4j", "2.14.1"): "pom.xml"}, nil)
+
+	b, err := json.MarshalIndent(log, "", "  ")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "version": "2.1.0",
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "runs": [{
+    "tool": {"driver": {
+      "name": "Black Duck",
+      "informationUri": "https://www.blackduck.com",
+      "rules": [
+        {
+          "id": "BDSA-2021-3779",
+          "shortDescription": {"text": "BDSA-2021-3779 (MEDIUM)"},
+          "defaultConfiguration": {"level": "warning"},
+          "properties": {"tags": ["security", "vulnerability"]}
+        },
+        {
+          "id": "CVE-2021-44228",
+          "shortDescription": {"text": "CVE-2021-44228 (CRITICAL)"},
+          "fullDescription": {"text": "JNDI lookups"},
+          "helpUri": "https://nvd.nist.gov/vuln/detail/CVE-2021-44228",
+          "defaultConfiguration": {"level": "error"},
+          "properties": {"security-severity": "10.0", "tags": ["security", "vulnerability"]}
+        }
+      ]
+    }},
+    "results": [
+      {
+        "ruleId": "BDSA-2021-3779",
+        "level": "warning",
+        "message": {"text": "BDSA-2021-3779 in log4j 2.14.1 (MEDIUM)"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "pom.xml"}}}],
+        "suppressions": [{"kind": "external", "justification": "NOT_AFFECTED: not used"}]
+      },
+      {
+        "ruleId": "CVE-2021-44228",
+        "level": "error",
+        "message": {"text": "CVE-2021-44228 in log4j 2.14.1 (CRITICAL)"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "pom.xml"}}}]
+      },
+      {
+        "ruleId": "CVE-2021-44228",
+        "level": "error",
+        "message": {"text": "CVE-2021-44228 in log4j-core 2.14.1 (CRITICAL)"}
+      }
+    ]
+  }]
+}`, string(b))
+}
+
+func TestConvertsNoVulnerabilities(t *testing.T) {
+	b, err := json.Marshal(sarif.Convert(nil, nil, nil))
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"rules":[]}},"results":[]`)
+}
+
+func TestRecommendsTheUpgradeWhichResolvesTheVulnerability(t *testing.T) {
+	log := sarif.Convert([]shared.VulnerableComponent{
+		vulnerable("log4j", "2.14.1", shared.VulnerabilityWithRemediation{Name: "CVE-2021-44228", Severity: "CRITICAL", RemediationStatus: "NEW"}),
+		vulnerable("log4j", "2.14.1", shared.VulnerabilityWithRemediation{Name: "CVE-2021-45046", Severity: "CRITICAL", RemediationStatus: "NEW"}),
+		vulnerable("log4j", "2.14.1", shared.VulnerabilityWithRemediation{Name: "CVE-2021-45105", Severity: "HIGH", RemediationStatus: "NEW"}),
+	}, nil, guidance.Guidances{
+		guidance.Key("log4j", "2.14.1"): {
+			ShortTerm: &guidance.Recommendation{Version: "2.15.0", Resolves: []string{"CVE-2021-44228"}},
+			LongTerm:  &guidance.Recommendation{Version: "2.17.1", Resolves: []string{"CVE-2021-44228", "CVE-2021-45046"}},
+		},
+	})
+
+	results := log.Runs[0].Results
+	require.Len(t, results, 3)
+	require.Equal(t, "CVE-2021-44228 in log4j 2.14.1 (CRITICAL). Upgrade to 2.15.0 to resolve it.", results[0].Message.Text)
+	require.Equal(t, "CVE-2021-45046 in log4j 2.14.1 (CRITICAL). Upgrade to 2.17.1 to resolve it.", results[1].Message.Text)
+	require.Equal(t, "CVE-2021-45105 in log4j 2.14.1 (HIGH)", results[2].Message.Text)
+	b, err := json.Marshal(results[2].Properties)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "shortTermUpgrade": {"version": "2.15.0", "resolves": ["CVE-2021-44228"]},
+  "longTermUpgrade": {"version": "2.17.1", "resolves": ["CVE-2021-44228", "CVE-2021-45046"]}
+}`, string(b))
+}
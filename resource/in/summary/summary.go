@@ -0,0 +1,215 @@
+package summary
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/elgohr/concourse-blackduck/shared"
+	htmltemplate "html/template"
+	"sort"
+	"text/template"
+)
+
+// TopCount is the number of vulnerable components, which are listed in the summary.
+const TopCount = 10
+
+// Data is what the templates of the summary are rendered with.
+type Data struct {
+	Project       string
+	Version       string
+	Phase         string
+	Url           string
+	Components    int
+	Risk          Risk
+	Violations    []Component
+	TopVulnerable []VulnerableComponent
+	Vulnerable    int
+}
+
+// Risk counts the open vulnerabilities per severity.
+type Risk struct {
+	Critical int
+	High     int
+	Medium   int
+	Low      int
+}
+
+func (r Risk) Total() int {
+	return r.Critical + r.High + r.Medium + r.Low
+}
+
+func (r *Risk) add(severity string) {
+	switch severity {
+	case "CRITICAL":
+		r.Critical++
+	case "HIGH":
+		r.High++
+	case "MEDIUM":
+		r.Medium++
+	case "LOW":
+		r.Low++
+	}
+}
+
+type Component struct {
+	Name    string
+	Version string
+}
+
+type VulnerableComponent struct {
+	Component
+	Risk            Risk
+	Vulnerabilities []string
+	ShortTerm       *guidance.Recommendation
+	LongTerm        *guidance.Recommendation
+}
+
+// New summarizes the BOM of a version. Ignored components and remediated vulnerabilities don't count.
+// The vulnerable components are recommended the upgrades of their guidance.
+func New(project string, version shared.Version, components []shared.BomComponent, vulnerabilities []shared.VulnerableComponent, guidances guidance.Guidances) Data {
+	data := Data{
+		Project:       project,
+		Version:       version.Name,
+		Phase:         version.Phase,
+		Url:           version.Meta.Href + "/components",
+		Violations:    []Component{},
+		TopVulnerable: []VulnerableComponent{},
+	}
+	for _, component := range components {
+		if component.Ignored {
+			continue
+		}
+		data.Components++
+		if component.InViolation() {
+			data.Violations = append(data.Violations, Component{Name: component.ComponentName, Version: component.ComponentVersionName})
+		}
+	}
+	sort.Slice(data.Violations, func(i, j int) bool {
+		return less(data.Violations[i], data.Violations[j])
+	})
+
+	var vulnerable []VulnerableComponent
+	index := map[Component]int{}
+	for _, v := range vulnerabilities {
+		if !v.Vulnerability.Open() {
+			continue
+		}
+		data.Risk.add(v.Vulnerability.Severity)
+		component := Component{Name: v.ComponentName, Version: v.ComponentVersionName}
+		i, found := index[component]
+		if !found {
+			i = len(vulnerable)
+			index[component] = i
+			vulnerable = append(vulnerable, VulnerableComponent{Component: component})
+		}
+		vulnerable[i].Risk.add(v.Vulnerability.Severity)
+		vulnerable[i].Vulnerabilities = append(vulnerable[i].Vulnerabilities, v.Vulnerability.Name)
+	}
+	sort.Slice(vulnerable, func(i, j int) bool {
+		a, b := vulnerable[i].Risk, vulnerable[j].Risk
+		if a != b {
+			return riskier(a, b)
+		}
+		return less(vulnerable[i].Component, vulnerable[j].Component)
+	})
+	for i := range vulnerable {
+		sort.Strings(vulnerable[i].Vulnerabilities)
+		g := guidances.Get(vulnerable[i].Name, vulnerable[i].Version)
+		vulnerable[i].ShortTerm, vulnerable[i].LongTerm = g.ShortTerm, g.LongTerm
+	}
+	data.Vulnerable = len(vulnerable)
+	if len(vulnerable) > TopCount {
+		vulnerable = vulnerable[:TopCount]
+	}
+	data.TopVulnerable = append(data.TopVulnerable, vulnerable...)
+	return data
+}
+
+// riskier compares the risks by their most severe vulnerabilities.
+func riskier(a Risk, b Risk) bool {
+	for _, counts := range [][2]int{{a.Critical, b.Critical}, {a.High, b.High}, {a.Medium, b.Medium}, {a.Low, b.Low}} {
+		if counts[0] != counts[1] {
+			return counts[0] > counts[1]
+		}
+	}
+	return false
+}
+
+func less(a Component, b Component) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.Version < b.Version
+}
+
+// Markdown renders the summary with the template, or the default template when it's empty.
+func (d Data) Markdown(text string) ([]byte, error) {
+	if len(text) == 0 {
+		text = DefaultMarkdown
+	}
+	t, err := template.New("summary.md").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	err = t.Execute(&b, d)
+	return b.Bytes(), err
+}
+
+// Html renders the summary with the template, or the default template when it's empty.
+// Contrary to Markdown, the data is escaped by the template.
+func (d Data) Html(text string) ([]byte, error) {
+	if len(text) == 0 {
+		text = DefaultHtml
+	}
+	t, err := htmltemplate.New("summary.html").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	err = t.Execute(&b, d)
+	return b.Bytes(), err
+}
+
+const DefaultMarkdown = `## Black Duck: {{.Project}} {{.Version}}
+
+| Critical | High | Medium | Low | Components | Policy violations |
+|---|---|---|---|---|---|
+| {{.Risk.Critical}} | {{.Risk.High}} | {{.Risk.Medium}} | {{.Risk.Low}} | {{.Components}} | {{len .Violations}} |
+{{if .Violations}}
+### Policy violations
+
+{{range .Violations}}* {{.Name}} {{.Version}}
+{{end}}{{end}}{{if .TopVulnerable}}
+### Top vulnerable components
+
+| Component | Version | Critical | High | Medium | Low | Short-term upgrade | Long-term upgrade |
+|---|---|---|---|---|---|---|---|
+{{range .TopVulnerable}}| {{.Name}} | {{.Version}} | {{.Risk.Critical}} | {{.Risk.High}} | {{.Risk.Medium}} | {{.Risk.Low}} | {{with .ShortTerm}}{{.}}{{end}} | {{with .LongTerm}}{{.}}{{end}} |
+{{end}}{{end}}
+[Open in Black Duck]({{.Url}})
+`
+
+const DefaultHtml = `<h2>Black Duck: {{.Project}} {{.Version}}</h2>
+<table>
+<tr><th>Critical</th><th>High</th><th>Medium</th><th>Low</th><th>Components</th><th>Policy violations</th></tr>
+<tr><td>{{.Risk.Critical}}</td><td>{{.Risk.High}}</td><td>{{.Risk.Medium}}</td><td>{{.Risk.Low}}</td><td>{{.Components}}</td><td>{{len .Violations}}</td></tr>
+</table>
+{{- if .Violations}}
+<h3>Policy violations</h3>
+<ul>
+{{- range .Violations}}
+<li>{{.Name}} {{.Version}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .TopVulnerable}}
+<h3>Top vulnerable components</h3>
+<table>
+<tr><th>Component</th><th>Version</th><th>Critical</th><th>High</th><th>Medium</th><th>Low</th><th>Short-term upgrade</th><th>Long-term upgrade</th></tr>
+{{- range .TopVulnerable}}
+<tr><td>{{.Name}}</td><td>{{.Version}}</td><td>{{.Risk.Critical}}</td><td>{{.Risk.High}}</td><td>{{.Risk.Medium}}</td><td>{{.Risk.Low}}</td><td>{{with .ShortTerm}}{{.}}{{end}}</td><td>{{with .LongTerm}}{{.}}{{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<p><a href="{{.Url}}">Open in Black Duck</a></p>
+`
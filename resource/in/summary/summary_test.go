@@ -0,0 +1,111 @@
+package summary_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/elgohr/concourse-blackduck/in/summary"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+var version = shared.Version{Name: "1.0.0", Phase: "DEVELOPMENT", Meta: shared.Meta{Href: "https://blackduck/api/projects/1/versions/2"}}
+
+var components = []shared.BomComponent{
+	{ComponentName: "log4j", ComponentVersionName: "2.14.1", PolicyStatus: "IN_VIOLATION"},
+	{ComponentName: "commons-text", ComponentVersionName: "1.9", PolicyStatus: "IN_VIOLATION_OVERRIDDEN"},
+	{ComponentName: "guava", ComponentVersionName: "30.0", PolicyStatus: "NOT_IN_VIOLATION"},
+	{ComponentName: "mockito", ComponentVersionName: "4.0", PolicyStatus: "IN_VIOLATION", Ignored: true},
+}
+
+func vulnerable(name string, version string, vulnerability string, severity string, status string) shared.VulnerableComponent {
+	return shared.VulnerableComponent{
+		ComponentName:        name,
+		ComponentVersionName: version,
+		Vulnerability:        shared.VulnerabilityWithRemediation{Name: vulnerability, Severity: severity, RemediationStatus: status},
+	}
+}
+
+var vulnerabilities = []shared.VulnerableComponent{
+	vulnerable("guava", "30.0", "CVE-2020-8908", "LOW", "NEW"),
+	vulnerable("guava", "30.0", "CVE-2023-2976", "HIGH", "NEW"),
+	vulnerable("log4j", "2.14.1", "CVE-2021-45046", "CRITICAL", "NEW"),
+	vulnerable("log4j", "2.14.1", "CVE-2021-44228", "CRITICAL", "NEEDS_REVIEW"),
+	vulnerable("commons-text", "1.9", "CVE-2022-42889", "CRITICAL", "PATCHED"),
+}
+
+var guidances = guidance.Guidances{
+	guidance.Key("log4j", "2.14.1"): {
+		ShortTerm: &guidance.Recommendation{Version: "2.15.0", Resolves: []string{"CVE-2021-44228"}},
+		LongTerm:  &guidance.Recommendation{Version: "2.17.1", Resolves: []string{"CVE-2021-44228", "CVE-2021-45046"}},
+	},
+}
+
+func TestSummarizesTheBom(t *testing.T) {
+	data := summary.New("project1", version, components, vulnerabilities, guidances)
+	require.Equal(t, "https://blackduck/api/projects/1/versions/2/components", data.Url)
+	require.Equal(t, 3, data.Components)
+	require.Equal(t, summary.Risk{Critical: 2, High: 1, Low: 1}, data.Risk)
+	require.Equal(t, 4, data.Risk.Total())
+	require.Equal(t, []summary.Component{{Name: "log4j", Version: "2.14.1"}}, data.Violations)
+	require.Equal(t, []summary.VulnerableComponent{
+		{
+			Component:       summary.Component{Name: "log4j", Version: "2.14.1"},
+			Risk:            summary.Risk{Critical: 2},
+			Vulnerabilities: []string{"CVE-2021-44228", "CVE-2021-45046"},
+			ShortTerm:       &guidance.Recommendation{Version: "2.15.0", Resolves: []string{"CVE-2021-44228"}},
+			LongTerm:        &guidance.Recommendation{Version: "2.17.1", Resolves: []string{"CVE-2021-44228", "CVE-2021-45046"}},
+		},
+		{Component: summary.Component{Name: "guava", Version: "30.0"}, Risk: summary.Risk{High: 1, Low: 1}, Vulnerabilities: []string{"CVE-2020-8908", "CVE-2023-2976"}},
+	}, data.TopVulnerable)
+}
+
+func TestListsOnlyTheTopVulnerableComponents(t *testing.T) {
+	var many []shared.VulnerableComponent
+	for i := 0; i < summary.TopCount+5; i++ {
+		many = append(many, vulnerable(string(rune('a'+i)), "1", "CVE-1", "LOW", "NEW"))
+	}
+	data := summary.New("project1", version, nil, many, nil)
+	require.Len(t, data.TopVulnerable, summary.TopCount)
+	require.Equal(t, summary.TopCount+5, data.Vulnerable)
+}
+
+func TestRendersMarkdown(t *testing.T) {
+	b, err := summary.New("project1", version, components, vulnerabilities, guidances).Markdown("")
+	require.NoError(t, err)
+	require.Equal(t, `## Black Duck: project1 1.0.0
+
+| Critical | High | Medium | Low | Components | Policy violations |
+|---|---|---|---|---|---|
+| 2 | 1 | 0 | 1 | 3 | 1 |
+
+### Policy violations
+
+* log4j 2.14.1
+
+### Top vulnerable components
+
+| Component | Version | Critical | High | Medium | Low | Short-term upgrade | Long-term upgrade |
+|---|---|---|---|---|---|---|---|
+| log4j | 2.14.1 | 2 | 0 | 0 | 0 | 2.15.0 (resolves CVE-2021-44228) | 2.17.1 (resolves CVE-2021-44228, CVE-2021-45046) |
+| guava | 30.0 | 0 | 1 | 0 | 1 |  |  |
+
+[Open in Black Duck](https://blackduck/api/projects/1/versions/2/components)
+`, string(b))
+}
+
+func TestRendersEscapedHtml(t *testing.T) {
+	b, err := summary.New("<project>", version, nil, nil, nil).Html("")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(b), "<h2>Black Duck: &lt;project&gt; 1.0.0</h2>"), string(b))
+	require.NotContains(t, string(b), "Policy violations</h3>")
+}
+
+func TestRendersCustomTemplates(t *testing.T) {
+	data := summary.New("project1", version, components, vulnerabilities, guidances)
+	b, err := data.Markdown("{{.Project}}: {{.Risk.Total}} open vulnerabilities")
+	require.NoError(t, err)
+	require.Equal(t, "project1: 4 open vulnerabilities", string(b))
+	_, err = data.Html("{{.Unknown}}")
+	require.Error(t, err)
+}
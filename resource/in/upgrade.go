@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/elgohr/concourse-blackduck/in/bomdiff"
+	"github.com/elgohr/concourse-blackduck/in/guidance"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+)
+
+const upgradeGuidance = "upgrade_guidance.json"
+
+// upgradedFormats are the formats, which recommend upgrades of the vulnerable components.
+var upgradedFormats = []string{formatSummary, formatSarif, formatCsv}
+
+func needsGuidance(params shared.Params) bool {
+	for _, format := range params.Formats {
+		for _, upgraded := range upgradedFormats {
+			if format == upgraded {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getGuidance fetches the upgrade guidance of every component with open vulnerabilities
+// and finds the vulnerabilities, which the recommended versions resolve.
+// The vulnerabilities of each recommended version are only fetched once.
+func (r *Runner) getGuidance(source shared.Source, bom bomdiff.Bom) (guidance.Guidances, error) {
+	open := map[string][]string{}
+	for _, v := range bom.Vulnerabilities {
+		if v.Vulnerability.Open() {
+			key := guidance.Key(v.ComponentName, v.ComponentVersionName)
+			open[key] = append(open[key], v.Vulnerability.Name)
+		}
+	}
+	remaining := map[string][]string{}
+	recommend := func(version *shared.UpgradeVersion, current []string) (*guidance.Recommendation, error) {
+		if version == nil || len(version.Version) == 0 {
+			return nil, nil
+		}
+		if _, found := remaining[version.Version]; !found {
+			vulnerabilities, err := r.api.GetComponentVersionVulnerabilities(source, version.Version)
+			if err != nil {
+				return nil, err
+			}
+			names := []string{}
+			for _, v := range vulnerabilities {
+				names = append(names, v.Name)
+			}
+			remaining[version.Version] = names
+		}
+		return guidance.NewRecommendation(version.VersionName, current, remaining[version.Version]), nil
+	}
+
+	guidances := guidance.Guidances{}
+	for _, component := range bom.Components {
+		key := guidance.Key(component.ComponentName, component.ComponentVersionName)
+		current, vulnerable := open[key]
+		if !vulnerable || component.Ignored || len(component.ComponentVersion) == 0 {
+			continue
+		}
+		upgrade, err := r.api.GetUpgradeGuidance(source, component)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(current)
+		g := guidance.Guidance{Component: component.ComponentName, Version: component.ComponentVersionName, Vulnerabilities: current}
+		if g.ShortTerm, err = recommend(upgrade.ShortTerm, current); err != nil {
+			return nil, err
+		}
+		if g.LongTerm, err = recommend(upgrade.LongTerm, current); err != nil {
+			return nil, err
+		}
+		guidances[key] = g
+	}
+	return guidances, nil
+}
+
+func (r *Runner) writeGuidance(guidances guidance.Guidances) error {
+	b, err := json.MarshalIndent(guidances.Sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(r.path, upgradeGuidance), b, 0644)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var guidedComponents = []shared.BomComponent{
+	{ComponentName: "log4j", ComponentVersionName: "2.14.1", ComponentVersion: "http://blackduck/api/components/1/versions/2"},
+	{ComponentName: "guava", ComponentVersionName: "30.0", ComponentVersion: "http://blackduck/api/components/3/versions/4"},
+}
+
+var log4jGuidance = shared.UpgradeGuidance{
+	ShortTerm: &shared.UpgradeVersion{Version: "http://blackduck/api/components/1/versions/5", VersionName: "2.15.0"},
+	LongTerm:  &shared.UpgradeVersion{Version: "http://blackduck/api/components/1/versions/5", VersionName: "2.15.0"},
+}
+
+func TestAttachesTheUpgradeGuidanceToVulnerableComponents(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["summary", "sarif", "csv"]}`, reportedVersion)
+	fakeBlackduckApi.GetBomComponentsReturns(guidedComponents, nil)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+	fakeBlackduckApi.GetUpgradeGuidanceReturns(log4jGuidance, nil)
+	fakeBlackduckApi.GetComponentVersionVulnerabilitiesReturns([]shared.ComponentVulnerability{{Name: "CVE-2021-45046"}}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.GetUpgradeGuidanceCallCount() != 1 {
+		t.Errorf("Expected the guidance of the vulnerable component only, but was fetched %v times", fakeBlackduckApi.GetUpgradeGuidanceCallCount())
+	}
+	if _, component := fakeBlackduckApi.GetUpgradeGuidanceArgsForCall(0); component.ComponentName != "log4j" {
+		t.Errorf("Expected the guidance of log4j, but got %v", component.ComponentName)
+	}
+	if fakeBlackduckApi.GetComponentVersionVulnerabilitiesCallCount() != 1 {
+		t.Errorf("Expected the vulnerabilities of the recommended version to be fetched once, but were fetched %v times", fakeBlackduckApi.GetComponentVersionVulnerabilitiesCallCount())
+	}
+	if _, version := fakeBlackduckApi.GetComponentVersionVulnerabilitiesArgsForCall(0); version != "http://blackduck/api/components/1/versions/5" {
+		t.Errorf("Expected the vulnerabilities of the recommended version, but got %v", version)
+	}
+
+	b, _ := ioutil.ReadFile(filepath.Join(r.path, "upgrade_guidance.json"))
+	if !strings.Contains(string(b), `"shortTerm": {
+      "version": "2.15.0",
+      "resolves": [
+        "CVE-2021-44228"
+      ]
+    }`) {
+		t.Errorf("Expected the guidance of log4j, but got %v", string(b))
+	}
+	b, _ = ioutil.ReadFile(filepath.Join(r.path, "summary.md"))
+	if !strings.Contains(string(b), "| log4j | 2.14.1 | 1 | 0 | 0 | 0 | 2.15.0 (resolves CVE-2021-44228) | 2.15.0 (resolves CVE-2021-44228) |") {
+		t.Errorf("Expected the upgrades in the summary, but got %v", string(b))
+	}
+	b, _ = ioutil.ReadFile(filepath.Join(r.path, "results.sarif"))
+	if !strings.Contains(string(b), "Upgrade to 2.15.0 to resolve it.") {
+		t.Errorf("Expected the upgrade in the SARIF log, but got %v", string(b))
+	}
+	b, _ = ioutil.ReadFile(filepath.Join(r.path, "bom.csv"))
+	if !strings.Contains(string(b), ",2.15.0 (resolves CVE-2021-44228),2.15.0 (resolves CVE-2021-44228)\n") {
+		t.Errorf("Expected the upgrades in the csv, but got %v", string(b))
+	}
+}
+
+func TestFetchesNoGuidanceForFormatsWithoutUpgrades(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["junit"]}`, reportedVersion)
+	fakeBlackduckApi.GetBomComponentsReturns(guidedComponents, nil)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.GetUpgradeGuidanceCallCount() != 0 {
+		t.Error("Expected no guidance to be fetched")
+	}
+	if _, err := ioutil.ReadFile(filepath.Join(r.path, "upgrade_guidance.json")); err == nil {
+		t.Error("Expected no upgrade_guidance.json")
+	}
+}
+
+func TestErrorsWhenTheGuidanceCantBeFetched(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, reported, `{"formats": ["summary"]}`, reportedVersion)
+	fakeBlackduckApi.GetBomComponentsReturns(guidedComponents, nil)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+	fakeBlackduckApi.GetUpgradeGuidanceReturns(shared.UpgradeGuidance{}, errors.New("ERROR"))
+
+	if err := r.run(); err == nil || err.Error() != "ERROR" {
+		t.Errorf("Expected the error of the guidance, but got %v", err)
+	}
+}
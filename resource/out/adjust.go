@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/out/adjustment"
+	"github.com/elgohr/concourse-blackduck/out/interpreter"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"path/filepath"
+	"strconv"
+)
+
+// adjust applies the adjustments of a file to the components in the BOM of a version.
+// Components, which already match the file, aren't updated, so that it can be applied with every put.
+func (r *Runner) adjust(input shared.Request) error {
+	file, digest, err := adjustment.Load(filepath.Join(r.path, input.Params.AdjustmentFile))
+	if err != nil {
+		return err
+	}
+	project, err := r.api.GetProjectByName(input.Source)
+	if err != nil {
+		return err
+	}
+	versions, err := r.api.GetProjectVersions(input.Source, project)
+	if err != nil {
+		return err
+	}
+	version, err := findVersion(versions, input.Params)
+	if err != nil {
+		return err
+	}
+	components, err := r.api.GetBomComponents(input.Source, &version)
+	if err != nil {
+		return err
+	}
+
+	plan := adjustment.Diff(file, components)
+	fmt.Fprint(r.stdErr, plan.String())
+	if input.Params.DryRun {
+		fmt.Fprintln(r.stdErr, "Nothing was applied, as this is a dry run.")
+	} else {
+		// several adjustments can change the same component, so each change builds upon the earlier ones
+		adjusted := map[string]shared.BomComponent{}
+		for _, change := range plan.Changes {
+			component, found := adjusted[change.Component.Meta.Href]
+			if !found {
+				component = change.Component
+			}
+			if err := r.applyAdjustment(input.Source, &component, change); err != nil {
+				return fmt.Errorf("could not adjust %v: %w", change.Adjustment, err)
+			}
+			if err := r.api.UpdateBomComponent(input.Source, component); err != nil {
+				return fmt.Errorf("could not adjust %v: %w", change.Adjustment, err)
+			}
+			adjusted[component.Meta.Href] = component
+		}
+	}
+
+	response := interpreter.Response{
+		Id: shared.Ref{Ref: "adjust-" + digest},
+		MetaData: []interpreter.MetaData{
+			{Name: "name", Value: input.Source.Name},
+			{Name: "version", Value: version.Name},
+			{Name: "dryRun", Value: strconv.FormatBool(input.Params.DryRun)},
+			{Name: "changed", Value: strconv.Itoa(len(plan.Changes))},
+			{Name: "unmatched", Value: strconv.Itoa(len(plan.Unmatched))},
+		},
+	}
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	_, err = r.stdOut.Write(b)
+	return err
+}
+
+// applyAdjustment resolves the license and origin of the change on Blackduck and sets them on the component.
+func (r *Runner) applyAdjustment(source shared.Source, component *shared.BomComponent, change adjustment.Change) error {
+	if change.Ignore != nil {
+		component.Ignored = *change.Ignore
+	}
+	if len(change.Usage) != 0 {
+		component.Usages = []string{change.Usage}
+	}
+	if len(change.License) != 0 {
+		href, err := r.api.GetLicenseUrl(source, change.License)
+		if err != nil {
+			return err
+		}
+		component.Licenses = []shared.BomLicense{{LicenseDisplay: change.License, License: href}}
+	}
+	if change.Origin != nil {
+		origins, err := r.api.GetComponentOrigins(source, *component)
+		if err != nil {
+			return err
+		}
+		href := ""
+		for _, origin := range origins {
+			if origin.OriginName == change.Origin.Namespace && origin.OriginId == change.Origin.Id {
+				href = origin.Meta.Href
+			}
+		}
+		if len(href) == 0 {
+			return fmt.Errorf("no origin matching %v", change.Origin)
+		}
+		component.Origins = []shared.BomOrigin{{
+			ExternalNamespace: change.Origin.Namespace,
+			ExternalId:        change.Origin.Id,
+			Origin:            href,
+		}}
+	}
+	return nil
+}
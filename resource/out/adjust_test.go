@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const adjustments = "adjustments:\n" +
+	"- {component: junit, ignore: true}\n" +
+	"- {component: commons-text, license: Apache License 2.0, origin: {namespace: maven, id: 'org.apache.commons:commons-text:1.9'}}\n" +
+	"- {component: guava, usage: DYNAMICALLY_LINKED}\n"
+
+var adjustableComponents = []shared.BomComponent{{
+	ComponentName:        "junit",
+	ComponentVersionName: "4.13",
+	Usages:               []string{"DYNAMICALLY_LINKED"},
+	Meta:                 shared.Meta{Href: "junit-bom"},
+}, {
+	ComponentName:        "commons-text",
+	ComponentVersionName: "1.9",
+	ComponentVersion:     "commons-text-1.9",
+	Usages:               []string{"DYNAMICALLY_LINKED"},
+	Licenses:             []shared.BomLicense{{LicenseDisplay: "Unknown License", License: "unknown"}},
+	Meta:                 shared.Meta{Href: "commons-text-bom"},
+}}
+
+var commonsTextOrigins = []shared.Origin{
+	{OriginName: "maven", OriginId: "org.apache.commons:commons-text:1.8", Meta: shared.Meta{Href: "origin-1.8"}},
+	{OriginName: "maven", OriginId: "org.apache.commons:commons-text:1.9", Meta: shared.Meta{Href: "origin-1.9"}},
+}
+
+func adjustRequest(dryRun bool) string {
+	return request("", fmt.Sprintf(`{"action": "adjust", "adjustment_file": "source-code/adjustments.yml", "version_name": "1.0.0", "dry_run": %v}`, dryRun))
+}
+
+func TestAppliesAdjustmentsToTheBom(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, adjustRequest(false), shared.Version{Name: "0.9.0"}, shared.Version{Name: "1.0.0"})
+	writeFile(t, r.path, "source-code/adjustments.yml", adjustments)
+	fakeBlackduckApi.GetBomComponentsReturns(adjustableComponents, nil)
+	fakeBlackduckApi.GetLicenseUrlReturns("apache-2.0", nil)
+	fakeBlackduckApi.GetComponentOriginsReturns(commonsTextOrigins, nil)
+	stdOut := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	r.stdOut = stdOut
+	r.stdErr = stdErr
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, version := fakeBlackduckApi.GetBomComponentsArgsForCall(0); version.Name != "1.0.0" {
+		t.Errorf("Expected the components of 1.0.0, but got %v", version.Name)
+	}
+	if fakeBlackduckApi.UpdateBomComponentCallCount() != 2 {
+		t.Fatalf("Expected 2 updates, but got %v", fakeBlackduckApi.UpdateBomComponentCallCount())
+	}
+	if _, junit := fakeBlackduckApi.UpdateBomComponentArgsForCall(0); junit.Meta.Href != "junit-bom" || !junit.Ignored {
+		t.Errorf("Expected junit to be ignored, but got %v", junit)
+	}
+	_, commonsText := fakeBlackduckApi.UpdateBomComponentArgsForCall(1)
+	if !reflect.DeepEqual(commonsText.Licenses, []shared.BomLicense{{LicenseDisplay: "Apache License 2.0", License: "apache-2.0"}}) {
+		t.Errorf("Expected the license to be overridden, but got %v", commonsText.Licenses)
+	}
+	if len(commonsText.Origins) != 1 || commonsText.Origins[0].Origin != "origin-1.9" {
+		t.Errorf("Expected the origin to be overridden, but got %v", commonsText.Origins)
+	}
+	if _, name := fakeBlackduckApi.GetLicenseUrlArgsForCall(0); name != "Apache License 2.0" {
+		t.Errorf("Expected the license to be looked up by name, but got %v", name)
+	}
+	if !strings.Contains(stdErr.String(), "junit 4.13: ignored false -> true") {
+		t.Errorf("Expected the change to be reported, but got %v", stdErr.String())
+	}
+	expRes := `{"version":{"ref":"adjust-[0-9a-f]{64}"},"metadata":\[` +
+		`{"name":"name","value":"project1"},` +
+		`{"name":"version","value":"1.0.0"},` +
+		`{"name":"dryRun","value":"false"},` +
+		`{"name":"changed","value":"2"},` +
+		`{"name":"unmatched","value":"1"}\]}`
+	if !regexp.MustCompile("^" + expRes + "$").MatchString(stdOut.String()) {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+}
+
+func TestOnlyReportsTheAdjustmentsOnDryRuns(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, adjustRequest(true), shared.Version{Name: "1.0.0"})
+	writeFile(t, r.path, "source-code/adjustments.yml", adjustments)
+	fakeBlackduckApi.GetBomComponentsReturns(adjustableComponents, nil)
+	fakeBlackduckApi.GetLicenseUrlReturns("apache-2.0", nil)
+	fakeBlackduckApi.GetComponentOriginsReturns(commonsTextOrigins, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.UpdateBomComponentCallCount() != 0 {
+		t.Error("Should not have adjusted anything on a dry run")
+	}
+}
+
+func TestFailsWhenTheOriginIsUnknown(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, adjustRequest(false), shared.Version{Name: "1.0.0"})
+	writeFile(t, r.path, "source-code/adjustments.yml", adjustments)
+	fakeBlackduckApi.GetBomComponentsReturns(adjustableComponents, nil)
+	fakeBlackduckApi.GetLicenseUrlReturns("apache-2.0", nil)
+
+	err := r.run()
+	if err == nil || err.Error() != "could not adjust commons-text: no origin matching maven:org.apache.commons:commons-text:1.9" {
+		t.Errorf("Expected the unknown origin to fail, but got %v", err)
+	}
+}
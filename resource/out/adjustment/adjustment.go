@@ -0,0 +1,193 @@
+package adjustment
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"strings"
+)
+
+type File struct {
+	Adjustments []Adjustment `yaml:"adjustments"`
+}
+
+// Adjustment of a component, in every version of the component unless a version is given.
+// Only the adjustments, which are set, are managed.
+type Adjustment struct {
+	Component string  `yaml:"component"`
+	Version   string  `yaml:"version"`
+	Ignore    *bool   `yaml:"ignore"`
+	Origin    *Origin `yaml:"origin"`
+	License   string  `yaml:"license"`
+	Usage     string  `yaml:"usage"`
+}
+
+// Origin is given by its namespace and external id, e.g. maven and org.apache.commons:commons-text:1.9.
+type Origin struct {
+	Namespace string `yaml:"namespace"`
+	Id        string `yaml:"id"`
+}
+
+func (o Origin) String() string {
+	return o.Namespace + ":" + o.Id
+}
+
+func (a Adjustment) String() string {
+	if len(a.Version) == 0 {
+		return a.Component
+	}
+	return a.Component + " " + a.Version
+}
+
+func (a Adjustment) matches(component shared.BomComponent) bool {
+	return strings.EqualFold(a.Component, component.ComponentName) &&
+		(len(a.Version) == 0 || a.Version == component.ComponentVersionName)
+}
+
+// Load reads the adjustments and returns a digest of the file.
+func Load(file string) (File, string, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return File{}, "", err
+	}
+	var f File
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil {
+		return File{}, "", fmt.Errorf("%v is invalid: %w", file, err)
+	}
+	for i, adjustment := range f.Adjustments {
+		if err := adjustment.validate(); err != nil {
+			return File{}, "", fmt.Errorf("%v is invalid: adjustment %v: %w", file, i+1, err)
+		}
+	}
+	hash := sha256.Sum256(content)
+	return f, hex.EncodeToString(hash[:]), nil
+}
+
+func (a Adjustment) validate() error {
+	if len(a.Component) == 0 {
+		return fmt.Errorf("component is mandatory")
+	}
+	if a.Origin != nil && (len(a.Origin.Namespace) == 0 || len(a.Origin.Id) == 0) {
+		return fmt.Errorf("origin needs a namespace and id")
+	}
+	if len(a.Usage) != 0 {
+		valid := false
+		for _, usage := range shared.Usages {
+			valid = valid || usage == a.Usage
+		}
+		if !valid {
+			return fmt.Errorf("usage %v is unknown", a.Usage)
+		}
+	}
+	if a.Ignore == nil && a.Origin == nil && len(a.License) == 0 && len(a.Usage) == 0 {
+		return fmt.Errorf("%v doesn't adjust anything", a)
+	}
+	return nil
+}
+
+// Change of a component in the BOM. Only the differing adjustments are set.
+type Change struct {
+	Component  shared.BomComponent
+	Adjustment Adjustment
+	Ignore     *bool
+	Origin     *Origin
+	License    string
+	Usage      string
+	Details    []string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%v %v: %v", c.Component.ComponentName, c.Component.ComponentVersionName, strings.Join(c.Details, ", "))
+}
+
+type Plan struct {
+	Changes   []Change
+	Unmatched []Adjustment
+}
+
+// Diff plans the changes of the components, which don't match their adjustments yet.
+// Components, which already match, aren't touched, so that applying the same file again doesn't change anything.
+func Diff(f File, components []shared.BomComponent) Plan {
+	var plan Plan
+	for _, adjustment := range f.Adjustments {
+		matched := false
+		for _, component := range components {
+			if !adjustment.matches(component) {
+				continue
+			}
+			matched = true
+			change := Change{Component: component, Adjustment: adjustment}
+			if adjustment.Ignore != nil && *adjustment.Ignore != component.Ignored {
+				change.Ignore = adjustment.Ignore
+				change.Details = append(change.Details, fmt.Sprintf("ignored %v -> %v", component.Ignored, *adjustment.Ignore))
+			}
+			if adjustment.Origin != nil && !hasOrigin(component, *adjustment.Origin) {
+				change.Origin = adjustment.Origin
+				change.Details = append(change.Details, fmt.Sprintf("origin %v -> %v", origins(component), adjustment.Origin))
+			}
+			if len(adjustment.License) != 0 && !hasLicense(component, adjustment.License) {
+				change.License = adjustment.License
+				change.Details = append(change.Details, fmt.Sprintf("license %v -> %v", licenses(component), adjustment.License))
+			}
+			if len(adjustment.Usage) != 0 && !(len(component.Usages) == 1 && component.Usages[0] == adjustment.Usage) {
+				change.Usage = adjustment.Usage
+				change.Details = append(change.Details, fmt.Sprintf("usage %v -> %v", strings.Join(component.Usages, " AND "), adjustment.Usage))
+			}
+			if len(change.Details) != 0 {
+				plan.Changes = append(plan.Changes, change)
+			}
+		}
+		if !matched {
+			plan.Unmatched = append(plan.Unmatched, adjustment)
+		}
+	}
+	return plan
+}
+
+func hasOrigin(component shared.BomComponent, origin Origin) bool {
+	return len(component.Origins) == 1 &&
+		component.Origins[0].ExternalNamespace == origin.Namespace &&
+		component.Origins[0].ExternalId == origin.Id
+}
+
+func hasLicense(component shared.BomComponent, license string) bool {
+	return len(component.Licenses) == 1 && component.Licenses[0].LicenseDisplay == license
+}
+
+func origins(component shared.BomComponent) string {
+	var names []string
+	for _, origin := range component.Origins {
+		names = append(names, origin.ExternalNamespace+":"+origin.ExternalId)
+	}
+	return strings.Join(names, " AND ")
+}
+
+func licenses(component shared.BomComponent) string {
+	var names []string
+	for _, license := range component.Licenses {
+		names = append(names, license.LicenseDisplay)
+	}
+	return strings.Join(names, " AND ")
+}
+
+func (p Plan) String() string {
+	var b strings.Builder
+	if len(p.Changes) == 0 {
+		b.WriteString("No changes. The BOM matches the adjustments.\n")
+	} else {
+		fmt.Fprintf(&b, "Adjusting %v components:\n", len(p.Changes))
+		for _, change := range p.Changes {
+			fmt.Fprintf(&b, "  %v\n", change)
+		}
+	}
+	for _, adjustment := range p.Unmatched {
+		fmt.Fprintf(&b, "Unmatched: %v isn't in the BOM of this version\n", adjustment)
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package adjustment_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/out/adjustment"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+const adjustments = `
+adjustments:
+- component: junit
+  ignore: true
+- component: commons-text
+  version: "1.9"
+  origin: {namespace: maven, id: "org.apache.commons:commons-text:1.9"}
+  license: Apache License 2.0
+- component: guava
+  usage: DYNAMICALLY_LINKED
+`
+
+func TestLoadsTheAdjustments(t *testing.T) {
+	f, digest, err := adjustment.Load(writeFile(t, adjustments))
+	require.NoError(t, err)
+	require.Len(t, digest, 64)
+	require.Len(t, f.Adjustments, 3)
+	require.Equal(t, adjustment.Adjustment{
+		Component: "commons-text",
+		Version:   "1.9",
+		Origin:    &adjustment.Origin{Namespace: "maven", Id: "org.apache.commons:commons-text:1.9"},
+		License:   "Apache License 2.0",
+	}, f.Adjustments[1])
+	require.True(t, *f.Adjustments[0].Ignore)
+}
+
+func TestRejectsInvalidAdjustments(t *testing.T) {
+	for content, message := range map[string]string{
+		"adjustments: [{ignore: true}]":                           "adjustment 1: component is mandatory",
+		"adjustments: [{component: a, usage: USED}]":              "adjustment 1: usage USED is unknown",
+		"adjustments: [{component: a, origin: {namespace: npm}}]": "adjustment 1: origin needs a namespace and id",
+		"adjustments: [{component: a, version: '1'}]":             "adjustment 1: a 1 doesn't adjust anything",
+	} {
+		file := writeFile(t, content)
+		_, _, err := adjustment.Load(file)
+		require.EqualError(t, err, file+" is invalid: "+message)
+	}
+}
+
+func TestRejectsUnknownFields(t *testing.T) {
+	_, _, err := adjustment.Load(writeFile(t, "adjustments: [{component: a, ignored: true}]"))
+	require.Error(t, err)
+}
+
+func TestPlansTheChangesOfDifferingComponents(t *testing.T) {
+	f, _, err := adjustment.Load(writeFile(t, adjustments))
+	require.NoError(t, err)
+	plan := adjustment.Diff(f, []shared.BomComponent{
+		{ComponentName: "JUnit", ComponentVersionName: "4.12", Ignored: true},
+		{ComponentName: "junit", ComponentVersionName: "4.13"},
+		{ComponentName: "commons-text", ComponentVersionName: "1.8"},
+		{
+			ComponentName:        "commons-text",
+			ComponentVersionName: "1.9",
+			Licenses:             []shared.BomLicense{{LicenseDisplay: "Unknown License"}},
+			Origins:              []shared.BomOrigin{{ExternalNamespace: "github", ExternalId: "apache/commons-text:1.9"}},
+		},
+	})
+
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, "4.13", plan.Changes[0].Component.ComponentVersionName)
+	require.True(t, *plan.Changes[0].Ignore)
+	require.Nil(t, plan.Changes[1].Ignore)
+	require.Equal(t, "Apache License 2.0", plan.Changes[1].License)
+	require.Equal(t, `Adjusting 2 components:
+  junit 4.13: ignored false -> true
+  commons-text 1.9: origin github:apache/commons-text:1.9 -> maven:org.apache.commons:commons-text:1.9, license Unknown License -> Apache License 2.0
+Unmatched: guava isn't in the BOM of this version
+`, plan.String())
+}
+
+func TestPlansNothingWhenTheBomMatches(t *testing.T) {
+	f, _, err := adjustment.Load(writeFile(t, adjustments))
+	require.NoError(t, err)
+	plan := adjustment.Diff(f, []shared.BomComponent{
+		{ComponentName: "junit", ComponentVersionName: "4.13", Ignored: true},
+		{
+			ComponentName:        "commons-text",
+			ComponentVersionName: "1.9",
+			Licenses:             []shared.BomLicense{{LicenseDisplay: "Apache License 2.0"}},
+			Origins:              []shared.BomOrigin{{ExternalNamespace: "maven", ExternalId: "org.apache.commons:commons-text:1.9"}},
+		},
+		{ComponentName: "guava", ComponentVersionName: "31.1", Usages: []string{"DYNAMICALLY_LINKED"}},
+	})
+	require.Empty(t, plan.Changes)
+	require.Empty(t, plan.Unmatched)
+	require.Equal(t, "No changes. The BOM matches the adjustments.\n", plan.String())
+}
+
+func writeFile(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "adjustments.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+	return file
+}
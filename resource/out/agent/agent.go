@@ -0,0 +1,124 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const jarPattern = "synopsys-detect-*.jar"
+
+var (
+	ErrNotFound    = errors.New("could not find the scanner, please open an issue on Github")
+	versionPattern = regexp.MustCompile(`^synopsys-detect-(\d+)(?:\.(\d+))?(?:\.(\d+))?.*\.jar$`)
+)
+
+type Agent struct {
+	Path    string
+	Version Version
+}
+
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+func (v Version) less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// Find returns the latest Detect jar matching the constraint.
+// The directories are searched in order, so that bundled jars are preferred over downloaded ones.
+// An empty constraint matches every version, "7" matches 7.x.x and "7.1" matches 7.1.x.
+func Find(constraint string, dirs ...string) (Agent, error) {
+	prefix, err := parseConstraint(constraint)
+	if err != nil {
+		return Agent{}, err
+	}
+	var found bool
+	for _, dir := range dirs {
+		if len(dir) == 0 {
+			continue
+		}
+		jars, _ := filepath.Glob(filepath.Join(dir, jarPattern))
+		var best *Agent
+		for _, jar := range jars {
+			version, ok := parseJarName(filepath.Base(jar))
+			if !ok {
+				continue
+			}
+			found = true
+			if !matches(version, prefix) {
+				continue
+			}
+			if best == nil || best.Version.less(version) {
+				best = &Agent{Path: jar, Version: version}
+			}
+		}
+		if best != nil {
+			return *best, nil
+		}
+	}
+	if !found || len(constraint) == 0 {
+		return Agent{}, ErrNotFound
+	}
+	return Agent{}, fmt.Errorf("could not find detect version %v in %v", constraint, strings.Join(dirs, ", "))
+}
+
+func parseJarName(name string) (Version, bool) {
+	parts := versionPattern.FindStringSubmatch(name)
+	if parts == nil {
+		return Version{}, false
+	}
+	var numbers [3]int
+	for i, part := range parts[1:] {
+		if len(part) != 0 {
+			numbers[i], _ = strconv.Atoi(part)
+		}
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, true
+}
+
+func parseConstraint(constraint string) ([]int, error) {
+	constraint = strings.TrimPrefix(strings.TrimSpace(constraint), "v")
+	if len(constraint) == 0 || constraint == "latest" {
+		return nil, nil
+	}
+	parts := strings.Split(constraint, ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid detect version %v", constraint)
+	}
+	prefix := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid detect version %v", constraint)
+		}
+		prefix[i] = number
+	}
+	return prefix, nil
+}
+
+func matches(version Version, prefix []int) bool {
+	numbers := []int{version.Major, version.Minor, version.Patch}
+	for i, number := range prefix {
+		if numbers[i] != number {
+			return false
+		}
+	}
+	return true
+}
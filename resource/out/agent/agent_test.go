@@ -0,0 +1,81 @@
+package agent_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/out/agent"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func prepareJars(t *testing.T, names ...string) string {
+	dir := t.TempDir()
+	for _, name := range names {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(""), 0666))
+	}
+	return dir
+}
+
+func TestFindsTheLatestVersionWithoutConstraint(t *testing.T) {
+	dir := prepareJars(t, "synopsys-detect-6.9.1.jar", "synopsys-detect-7.10.0.jar", "synopsys-detect-7.9.3.jar")
+	a, err := agent.Find("", dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "synopsys-detect-7.10.0.jar"), a.Path)
+	require.Equal(t, "7.10.0", a.Version.String())
+}
+
+func TestFindsTheLatestVersionMatchingTheConstraint(t *testing.T) {
+	dir := prepareJars(t, "synopsys-detect-6.9.1.jar", "synopsys-detect-6.9.0.jar", "synopsys-detect-6.8.4.jar", "synopsys-detect-7.1.0.jar")
+	for constraint, expected := range map[string]string{
+		"6":      "6.9.1",
+		"6.8":    "6.8.4",
+		"6.9.0":  "6.9.0",
+		"v7":     "7.1.0",
+		"latest": "7.1.0",
+	} {
+		a, err := agent.Find(constraint, dir)
+		require.NoError(t, err, constraint)
+		require.Equal(t, expected, a.Version.String(), constraint)
+	}
+}
+
+func TestFallsBackToTheDownloadDirectory(t *testing.T) {
+	bundled := prepareJars(t, "synopsys-detect-7.1.0.jar")
+	downloaded := prepareJars(t, "synopsys-detect-6.9.1.jar")
+	a, err := agent.Find("6", bundled, downloaded)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(downloaded, "synopsys-detect-6.9.1.jar"), a.Path)
+}
+
+func TestPrefersBundledJars(t *testing.T) {
+	bundled := prepareJars(t, "synopsys-detect-7.1.0.jar")
+	downloaded := prepareJars(t, "synopsys-detect-7.1.0.jar", "synopsys-detect-8.0.0.jar")
+	a, err := agent.Find("", bundled, downloaded)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(bundled, "synopsys-detect-7.1.0.jar"), a.Path)
+}
+
+func TestErrorsWhenTheVersionIsNotAvailable(t *testing.T) {
+	bundled := prepareJars(t, "synopsys-detect-7.1.0.jar")
+	downloaded := prepareJars(t, "synopsys-detect-6.9.1.jar")
+	_, err := agent.Find("5", bundled, downloaded)
+	require.EqualError(t, err, "could not find detect version 5 in "+bundled+", "+downloaded)
+}
+
+func TestErrorsWhenNoJarIsAvailable(t *testing.T) {
+	_, err := agent.Find("7", t.TempDir(), "/not_here")
+	require.Equal(t, agent.ErrNotFound, err)
+}
+
+func TestErrorsWhenTheConstraintIsInvalid(t *testing.T) {
+	dir := prepareJars(t, "synopsys-detect-7.1.0.jar")
+	_, err := agent.Find("7.x", dir)
+	require.EqualError(t, err, "invalid detect version 7.x")
+}
+
+func TestIgnoresJarsWithoutVersion(t *testing.T) {
+	dir := prepareJars(t, "synopsys-detect-latest.jar", "synopsys-detect-7.1.0-SNAPSHOT.jar")
+	a, err := agent.Find("", dir)
+	require.NoError(t, err)
+	require.Equal(t, "7.1.0", a.Version.String())
+}
@@ -0,0 +1,159 @@
+package artifact
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type Limits struct {
+	MaxCount int
+	MaxBytes int64
+}
+
+type Artifact struct {
+	Path string
+	Size int64
+}
+
+// Collection contains the artifacts, which were matched below Root.
+type Collection struct {
+	Root      string
+	Artifacts []Artifact
+	Bytes     int64
+}
+
+// Collect matches the patterns against all files below root.
+// Besides the syntax of filepath.Match, ** matches any number of directories.
+func Collect(root string, patterns []string, limits Limits) (Collection, error) {
+	for _, pattern := range patterns {
+		if err := validatePattern(pattern); err != nil {
+			return Collection{}, err
+		}
+	}
+	collection := Collection{Root: root}
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, pattern := range patterns {
+			if match(strings.Split(pattern, "/"), strings.Split(rel, "/")) {
+				collection.Artifacts = append(collection.Artifacts, Artifact{Path: rel, Size: info.Size()})
+				collection.Bytes += info.Size()
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Collection{}, err
+	}
+	sort.Slice(collection.Artifacts, func(i, j int) bool {
+		return collection.Artifacts[i].Path < collection.Artifacts[j].Path
+	})
+	if len(collection.Artifacts) == 0 {
+		return Collection{}, fmt.Errorf("no artifacts match %v", strings.Join(patterns, ", "))
+	}
+	if limits.MaxCount > 0 && len(collection.Artifacts) > limits.MaxCount {
+		return Collection{}, fmt.Errorf("%d artifacts exceed the limit of %d", len(collection.Artifacts), limits.MaxCount)
+	}
+	if limits.MaxBytes > 0 && collection.Bytes > limits.MaxBytes {
+		return Collection{}, fmt.Errorf("%d bytes of artifacts exceed the limit of %d", collection.Bytes, limits.MaxBytes)
+	}
+	return collection, nil
+}
+
+func (c Collection) Paths() []string {
+	paths := make([]string, len(c.Artifacts))
+	for i, a := range c.Artifacts {
+		paths[i] = a.Path
+	}
+	return paths
+}
+
+// Target returns the file, which is uploaded by the binary scanner.
+// As the scanner only takes a single file, several artifacts are zipped into tmpDir.
+func (c Collection) Target(tmpDir string) (string, error) {
+	if len(c.Artifacts) == 1 {
+		return filepath.Join(c.Root, filepath.FromSlash(c.Artifacts[0].Path)), nil
+	}
+	target := filepath.Join(tmpDir, "artifacts.zip")
+	f, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	writer := zip.NewWriter(f)
+	for _, a := range c.Artifacts {
+		if err := c.addToZip(writer, a); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+func (c Collection) addToZip(writer *zip.Writer, a Artifact) error {
+	content, err := os.Open(filepath.Join(c.Root, filepath.FromSlash(a.Path)))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	entry, err := writer.Create(a.Path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, content)
+	return err
+}
+
+func validatePattern(pattern string) error {
+	if len(pattern) == 0 || path.IsAbs(pattern) || filepath.IsAbs(pattern) {
+		return fmt.Errorf("artifact pattern %v must be relative to the build directory", pattern)
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == ".." {
+			return fmt.Errorf("artifact pattern %v must be relative to the build directory", pattern)
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("artifact pattern %v is invalid", pattern)
+		}
+	}
+	return nil
+}
+
+func match(pattern []string, file []string) bool {
+	if len(pattern) == 0 {
+		return len(file) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(file); i++ {
+			if match(pattern[1:], file[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(file) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], file[0]); !ok {
+		return false
+	}
+	return match(pattern[1:], file[1:])
+}
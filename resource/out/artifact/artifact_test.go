@@ -0,0 +1,97 @@
+package artifact_test
+
+import (
+	"archive/zip"
+	"github.com/elgohr/concourse-blackduck/out/artifact"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func prepareBuildDir(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestCollectsMatchingArtifacts(t *testing.T) {
+	dir := prepareBuildDir(t, map[string]string{
+		"vendor/lib.jar":             "12345",
+		"vendor/nested/firmware.bin": "123",
+		"vendor/readme.txt":          "1",
+		"other/app.zip":              "12",
+	})
+	c, err := artifact.Collect(dir, []string{"vendor/**/*.jar", "vendor/**/*.bin", "*/app.zip"}, artifact.Limits{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"other/app.zip", "vendor/lib.jar", "vendor/nested/firmware.bin"}, c.Paths())
+	require.Equal(t, int64(10), c.Bytes)
+}
+
+func TestCollectsArtifactsOnlyOnce(t *testing.T) {
+	dir := prepareBuildDir(t, map[string]string{"vendor/lib.jar": "12345"})
+	c, err := artifact.Collect(dir, []string{"vendor/*.jar", "**/lib.jar"}, artifact.Limits{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"vendor/lib.jar"}, c.Paths())
+}
+
+func TestErrorsWhenNoArtifactMatches(t *testing.T) {
+	dir := prepareBuildDir(t, map[string]string{"vendor/lib.jar": "12345"})
+	_, err := artifact.Collect(dir, []string{"*.zip"}, artifact.Limits{})
+	require.EqualError(t, err, "no artifacts match *.zip")
+}
+
+func TestErrorsWhenTheLimitsAreExceeded(t *testing.T) {
+	dir := prepareBuildDir(t, map[string]string{"a.jar": "12345", "b.jar": "12345"})
+	_, err := artifact.Collect(dir, []string{"*.jar"}, artifact.Limits{MaxCount: 1})
+	require.EqualError(t, err, "2 artifacts exceed the limit of 1")
+	_, err = artifact.Collect(dir, []string{"*.jar"}, artifact.Limits{MaxBytes: 9})
+	require.EqualError(t, err, "10 bytes of artifacts exceed the limit of 9")
+}
+
+func TestErrorsWhenPatternsLeaveTheBuildDirectory(t *testing.T) {
+	dir := prepareBuildDir(t, map[string]string{"a.jar": "1"})
+	for _, pattern := range []string{"/etc/*", "../*.jar", "vendor/../../*"} {
+		_, err := artifact.Collect(dir, []string{pattern}, artifact.Limits{})
+		require.EqualError(t, err, "artifact pattern "+pattern+" must be relative to the build directory")
+	}
+}
+
+func TestErrorsWhenPatternsAreInvalid(t *testing.T) {
+	dir := prepareBuildDir(t, map[string]string{"a.jar": "1"})
+	_, err := artifact.Collect(dir, []string{"[.jar"}, artifact.Limits{})
+	require.EqualError(t, err, "artifact pattern [.jar is invalid")
+}
+
+func TestUsesSingleArtifactsAsTarget(t *testing.T) {
+	dir := prepareBuildDir(t, map[string]string{"vendor/lib.jar": "12345"})
+	c, err := artifact.Collect(dir, []string{"vendor/lib.jar"}, artifact.Limits{})
+	require.NoError(t, err)
+	target, err := c.Target(t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "vendor", "lib.jar"), target)
+}
+
+func TestZipsSeveralArtifactsAsTarget(t *testing.T) {
+	dir := prepareBuildDir(t, map[string]string{"vendor/lib.jar": "12345", "firmware.bin": "123"})
+	c, err := artifact.Collect(dir, []string{"**/*.jar", "*.bin"}, artifact.Limits{})
+	require.NoError(t, err)
+	tmpDir := t.TempDir()
+	target, err := c.Target(tmpDir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(tmpDir, "artifacts.zip"), target)
+
+	archive, err := zip.OpenReader(target)
+	require.NoError(t, err)
+	defer archive.Close()
+	var names []string
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{"firmware.bin", "vendor/lib.jar"}, names)
+}
@@ -0,0 +1,118 @@
+package bdio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	JsonLdExtension = ".jsonld"
+	Bdio2Extension  = ".bdio"
+)
+
+// Collect copies the BDIO documents and signature scan results of an offline scan into destination.
+// The paths below the output directory of Detect are kept, so that the files of several runs don't collide.
+func Collect(outputDir string, destination string) (files []string, digest string, err error) {
+	hash := sha256.New()
+	err = filepath.Walk(outputDir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || !isScanOutput(file) {
+			return nil
+		}
+		rel, err := filepath.Rel(outputDir, file)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(file, filepath.Join(destination, rel), hash); err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(files) == 0 {
+		return nil, "", fmt.Errorf("the offline scan didn't generate any BDIO files")
+	}
+	return files, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Find returns the BDIO documents below dir, which can be uploaded to Blackduck.
+func Find(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() && isBdio(file) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("could not find any BDIO files in %v", dir)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Digest identifies the content of the files.
+func Digest(files []string) (string, error) {
+	hash := sha256.New()
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(hash, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func isBdio(file string) bool {
+	return strings.HasSuffix(file, JsonLdExtension) || strings.HasSuffix(file, Bdio2Extension)
+}
+
+func isScanOutput(file string) bool {
+	if isBdio(file) {
+		return true
+	}
+	// the signature scanner writes its offline results as json into a data directory
+	return strings.HasSuffix(file, ".json") && filepath.Base(filepath.Dir(file)) == "data"
+}
+
+func copyFile(source string, target string, hash io.Writer) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.MultiWriter(out, hash), in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,83 @@
+package bdio_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/out/bdio"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func prepareFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestCollectsTheOutputOfOfflineScans(t *testing.T) {
+	outputDir := prepareFiles(t, map[string]string{
+		"runs/1/bdio/project1_1_0_0.jsonld":                                  "bdio",
+		"runs/1/bdio/project1_1_0_0.bdio":                                    "bdio2",
+		"runs/1/scan/BlackDuckScanOutput/2023-10-19/data/project1_scan.json": "signature",
+		"runs/1/scan/BlackDuckScanOutput/2023-10-19/log/scan.cli.log":        "log",
+		"runs/1/status/status.json":                                          "status",
+	})
+	destination := filepath.Join(t.TempDir(), "bdio")
+
+	files, digest, err := bdio.Collect(outputDir, destination)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"runs/1/bdio/project1_1_0_0.bdio",
+		"runs/1/bdio/project1_1_0_0.jsonld",
+		"runs/1/scan/BlackDuckScanOutput/2023-10-19/data/project1_scan.json",
+	}, files)
+	require.Len(t, digest, 64)
+	b, err := ioutil.ReadFile(filepath.Join(destination, "runs/1/scan/BlackDuckScanOutput/2023-10-19/data/project1_scan.json"))
+	require.NoError(t, err)
+	require.Equal(t, "signature", string(b))
+}
+
+func TestErrorsWhenNothingWasGenerated(t *testing.T) {
+	outputDir := prepareFiles(t, map[string]string{"runs/1/status/status.json": "status"})
+	_, _, err := bdio.Collect(outputDir, t.TempDir())
+	require.EqualError(t, err, "the offline scan didn't generate any BDIO files")
+}
+
+func TestFindsBdioDocuments(t *testing.T) {
+	dir := prepareFiles(t, map[string]string{
+		"b/project1.jsonld": "bdio",
+		"a/project2.jsonld": "bdio",
+		"a/project3.bdio":   "bdio2",
+		"a/data/scan.json":  "signature",
+	})
+	files, err := bdio.Find(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "a/project2.jsonld"),
+		filepath.Join(dir, "a/project3.bdio"),
+		filepath.Join(dir, "b/project1.jsonld"),
+	}, files)
+}
+
+func TestErrorsWhenNoBdioDocumentsAreFound(t *testing.T) {
+	dir := t.TempDir()
+	_, err := bdio.Find(dir)
+	require.EqualError(t, err, "could not find any BDIO files in "+dir)
+}
+
+func TestDigestsTheContentOfTheFiles(t *testing.T) {
+	dir := prepareFiles(t, map[string]string{"a.jsonld": "a", "b.jsonld": "b", "c.jsonld": "a"})
+	ab, err := bdio.Digest([]string{filepath.Join(dir, "a.jsonld"), filepath.Join(dir, "b.jsonld")})
+	require.NoError(t, err)
+	cb, err := bdio.Digest([]string{filepath.Join(dir, "c.jsonld"), filepath.Join(dir, "b.jsonld")})
+	require.NoError(t, err)
+	require.Equal(t, ab, cb)
+	a, err := bdio.Digest([]string{filepath.Join(dir, "a.jsonld")})
+	require.NoError(t, err)
+	require.NotEqual(t, ab, a)
+}
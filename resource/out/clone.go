@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cloneTimeout limits the wait for Blackduck to copy the BOM into a cloned version.
+const cloneTimeout = time.Minute
+
+// cloneVersion creates the version as a clone of an earlier one, so that its triage isn't lost.
+// Blackduck takes the clone categories from the project, which are therefore updated when they are configured differently.
+func (r *Runner) cloneVersion(ctx context.Context, source shared.Source, project *shared.Project, from shared.Version, version shared.Version, stdErr io.Writer) error {
+	categories := project.CloneCategories
+	if configured := source.Version.CloneCategories; configured != nil && !sameCategories(configured, categories) {
+		fmt.Fprintf(stdErr, "Setting the clone categories of %v to %v\n", project.Name, strings.Join(configured, ", "))
+		updated := *project
+		updated.CloneCategories = configured
+		if err := r.api.UpdateProject(source, updated); err != nil {
+			return err
+		}
+		categories = configured
+	}
+
+	fmt.Fprintf(stdErr, "Cloning version %v from %v\n", version.Name, from.Name)
+	version.CloneFrom = from.Meta.Href
+	created, err := r.api.CreateVersion(source, project, version)
+	if err != nil {
+		return err
+	}
+	if !hasCategory(categories, shared.CloneCategoryComponents) {
+		fmt.Fprintf(stdErr, "Not verifying the clone, as %v isn't cloned\n", shared.CloneCategoryComponents)
+		return nil
+	}
+	return r.verifyClone(ctx, source, from, created)
+}
+
+// verifyClone waits until the cloned version has as many components as the version it was cloned from.
+func (r *Runner) verifyClone(ctx context.Context, source shared.Source, from shared.Version, created *shared.Version) error {
+	expected, err := r.api.GetComponentCount(source, &from)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(cloneTimeout)
+	for {
+		count, err := r.api.GetComponentCount(source, created)
+		if err != nil {
+			return err
+		}
+		if count >= expected {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("version %v wasn't cloned from %v: it has %v of %v components", created.Name, from.Name, count, expected)
+		}
+		if err := r.sleep(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func sameCategories(a []string, b []string) bool {
+	set := func(categories []string) string {
+		unique := map[string]bool{}
+		for _, category := range categories {
+			unique[category] = true
+		}
+		var sorted []string
+		for category := range unique {
+			sorted = append(sorted, category)
+		}
+		sort.Strings(sorted)
+		return strings.Join(sorted, ",")
+	}
+	return set(a) == set(b)
+}
+
+func hasCategory(categories []string, category string) bool {
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
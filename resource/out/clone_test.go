@@ -0,0 +1,103 @@
+package main
+
+import (
+	"github.com/elgohr/concourse-blackduck/shared"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var cloneVersions = []shared.Version{
+	{Name: "1.0.0", Phase: "RELEASED", Meta: shared.Meta{Href: "version-1.0.0"}},
+	{Name: "1.1.0", Phase: "DEVELOPMENT", Meta: shared.Meta{Href: "version-1.1.0"}},
+}
+
+func cloneRequest(version string) string {
+	return request(`"version": `+version, `{"directory": ".", "version_name": "1.2.0"}`)
+}
+
+func TestClonesNewVersionsFromThePreviousVersion(t *testing.T) {
+	r, fakeBlackduckApi := prepareScan(t, cloneRequest(`{"clone_from": "previous"}`), "--- Overall Status: SUCCESS", cloneVersions...)
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1", CloneCategories: []string{"COMPONENT_DATA"}}, nil)
+	fakeBlackduckApi.CreateVersionReturns(&shared.Version{Name: "1.2.0", Meta: shared.Meta{Href: "version-1.2.0"}}, nil)
+	fakeBlackduckApi.GetComponentCountReturnsOnCall(0, 12, nil)
+	fakeBlackduckApi.GetComponentCountReturnsOnCall(1, 3, nil)
+	fakeBlackduckApi.GetComponentCountReturnsOnCall(2, 12, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	_, _, version := fakeBlackduckApi.CreateVersionArgsForCall(0)
+	if version.Name != "1.2.0" || version.CloneFrom != "version-1.1.0" {
+		t.Errorf("Expected 1.2.0 to be cloned from 1.1.0, but got %v", version)
+	}
+	if fakeBlackduckApi.UpdateProjectCallCount() != 0 {
+		t.Error("Should not have changed matching clone categories")
+	}
+	if _, from := fakeBlackduckApi.GetComponentCountArgsForCall(0); from.Name != "1.1.0" {
+		t.Errorf("Expected the components of 1.1.0 to be counted, but was %v", from.Name)
+	}
+	if fakeBlackduckApi.GetComponentCountCallCount() != 3 {
+		t.Errorf("Expected to wait until all components were cloned, but counted %v times", fakeBlackduckApi.GetComponentCountCallCount())
+	}
+}
+
+func TestSetsTheCloneCategoriesBeforeCloning(t *testing.T) {
+	r, fakeBlackduckApi := prepareScan(t, cloneRequest(`{"clone_from": "latest_released", "clone_categories": ["VULN_DATA"]}`), "--- Overall Status: SUCCESS", cloneVersions...)
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1", CloneCategories: []string{"COMPONENT_DATA"}}, nil)
+	fakeBlackduckApi.CreateVersionReturns(&shared.Version{Name: "1.2.0", Meta: shared.Meta{Href: "version-1.2.0"}}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	_, project := fakeBlackduckApi.UpdateProjectArgsForCall(0)
+	if len(project.CloneCategories) != 1 || project.CloneCategories[0] != "VULN_DATA" {
+		t.Errorf("Expected the clone categories to be VULN_DATA, but were %v", project.CloneCategories)
+	}
+	_, _, version := fakeBlackduckApi.CreateVersionArgsForCall(0)
+	if version.CloneFrom != "version-1.0.0" {
+		t.Errorf("Expected 1.2.0 to be cloned from 1.0.0, but got %v", version.CloneFrom)
+	}
+	if fakeBlackduckApi.GetComponentCountCallCount() != 0 {
+		t.Error("Should not have verified a clone without components")
+	}
+}
+
+func TestErrorsWhenTheCloneSourceIsMissing(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, cloneRequest(`{"clone_from": "0.9.0"}`), cloneVersions...)
+	r.agentDir, _ = prepareMockAgentFile(t)
+	r.exec = func(name string, arg ...string) *exec.Cmd {
+		t.Error("Should not have started Detect")
+		return exec.Command("true")
+	}
+
+	if err := r.run(); err == nil || err.Error() != "could not find version 0.9.0 to clone from" {
+		t.Errorf("Should have errored with could not find version 0.9.0 to clone from, but was %v", err)
+	}
+	if fakeBlackduckApi.CreateVersionCallCount() != 0 {
+		t.Error("Should not have created the version")
+	}
+}
+
+func TestStopsVerifyingTheCloneOnSignals(t *testing.T) {
+	r, fakeBlackduckApi := prepareScan(t, cloneRequest(`{"clone_from": "previous"}`), "--- Overall Status: SUCCESS", cloneVersions...)
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1", CloneCategories: []string{"COMPONENT_DATA"}}, nil)
+	fakeBlackduckApi.CreateVersionReturns(&shared.Version{Name: "1.2.0", Meta: shared.Meta{Href: "version-1.2.0"}}, nil)
+	signals := make(chan os.Signal, 1)
+	r.signals = signals
+	r.pollInterval = time.Hour
+	fakeBlackduckApi.GetComponentCountStub = func(source shared.Source, version *shared.Version) (int, error) {
+		if version.Name == "1.1.0" {
+			return 12, nil
+		}
+		signals <- syscall.SIGTERM
+		return 3, nil
+	}
+
+	errMsg := "scan was interrupted"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+}
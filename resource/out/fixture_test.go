@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/elgohr/concourse-blackduck/shared/sharedfakes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// request puts the params into a request for project1, whose source is extended by the given fields.
+func request(source string, params string) string {
+	if len(source) != 0 {
+		source = ",\n\t\t\t" + source
+	}
+	return `{
+		"source": {
+			"url": "https://BLACKDUCK",
+			"username": "username",
+			"password": "password",
+			"name": "project1"` + source + `
+		},
+		"params": ` + params + `
+	}`
+}
+
+// prepareRunner runs the request against project1 with the given versions in an empty build directory.
+func prepareRunner(t *testing.T, request string, versions ...shared.Version) (Runner, *sharedfakes.FakeBlackduckApi) {
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1"}, nil)
+	fakeBlackduckApi.GetProjectVersionsReturns(versions, nil)
+	return Runner{
+		stdIn:  bytes.NewBufferString(request),
+		stdOut: &bytes.Buffer{},
+		stdErr: &bytes.Buffer{},
+		path:   t.TempDir(),
+		now:    time.Now,
+		api:    fakeBlackduckApi,
+	}, fakeBlackduckApi
+}
+
+// prepareScan is prepareRunner for scans, which Detect finishes with the given output.
+func prepareScan(t *testing.T, request string, output string, versions ...shared.Version) (Runner, *sharedfakes.FakeBlackduckApi) {
+	r, fakeBlackduckApi := prepareRunner(t, request, versions...)
+	r.agentDir, _ = prepareMockAgentFile(t)
+	r.exec = func(name string, arg ...string) *exec.Cmd {
+		return exec.Command("echo", output)
+	}
+	return r, fakeBlackduckApi
+}
+
+func writeFile(t *testing.T, dir string, name string, content string) {
+	file := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
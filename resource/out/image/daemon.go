@@ -0,0 +1,78 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultDockerHost = "unix:///var/run/docker.sock"
+
+// Daemon is the Docker daemon, which the Docker Inspector of Detect pulls referenced images through.
+type Daemon struct {
+	client http.Client
+	url    string
+}
+
+type inspection struct {
+	RepoDigests []string `json:"RepoDigests"`
+}
+
+// NewDaemon connects to the host like the Docker Inspector does, i.e. to DOCKER_HOST or the local socket.
+func NewDaemon(host string) Daemon {
+	if len(host) == 0 {
+		host = defaultDockerHost
+	}
+	d := Daemon{client: http.Client{Timeout: 30 * time.Second}, url: host}
+	if socket := strings.TrimPrefix(host, "unix://"); socket != host {
+		d.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		}
+		d.url = "http://docker"
+	} else if address := strings.TrimPrefix(host, "tcp://"); address != host {
+		d.url = "http://" + address
+	}
+	return d
+}
+
+// Digest returns the digest, which the reference was resolved to, when the image was pulled.
+func (d Daemon) Digest(ref string) (string, error) {
+	if i := strings.LastIndex(ref, "@"); i != -1 {
+		return ref[i+1:], nil
+	}
+	res, err := d.client.Get(d.url + "/images/" + ref + "/json")
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not inspect %v: %v", ref, res.Status)
+	}
+	var i inspection
+	if err := json.NewDecoder(res.Body).Decode(&i); err != nil {
+		return "", err
+	}
+	for _, repoDigest := range i.RepoDigests {
+		if strings.HasPrefix(repoDigest, repository(ref)+"@") {
+			return strings.TrimPrefix(repoDigest, repository(ref)+"@"), nil
+		}
+	}
+	if len(i.RepoDigests) != 0 {
+		return i.RepoDigests[0][strings.LastIndex(i.RepoDigests[0], "@")+1:], nil
+	}
+	return "", fmt.Errorf("%v wasn't pulled from a registry", ref)
+}
+
+// repository strips the tag from the reference, e.g. registry.example.com:5000/app from registry.example.com:5000/app:1.0.0.
+func repository(ref string) string {
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i]
+	}
+	return ref
+}
@@ -0,0 +1,60 @@
+package image_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/out/image"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func inspectImage(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	require.Equal(t, "/images/registry.example.com:5000/app:1.0.0/json", r.URL.Path)
+	_, _ = w.Write([]byte(`{"RepoDigests":["mirror.example.com/app@` + fileDigest + `","registry.example.com:5000/app@` + indexDigest + `"]}`))
+}
+
+func TestResolvesTheDigestOfTheReferenceThroughTheDaemon(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inspectImage(t, w, r)
+	}))
+	defer ts.Close()
+
+	digest, err := image.NewDaemon("tcp://" + strings.TrimPrefix(ts.URL, "http://")).Digest("registry.example.com:5000/app:1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, indexDigest, digest)
+}
+
+func TestConnectsToTheSocketOfTheDaemon(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inspectImage(t, w, r)
+	}))
+	ts.Listener = listener
+	ts.Start()
+	defer ts.Close()
+
+	digest, err := image.NewDaemon("unix://" + socket).Digest("registry.example.com:5000/app:1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, indexDigest, digest)
+}
+
+func TestTakesTheDigestOfPinnedReferences(t *testing.T) {
+	digest, err := image.NewDaemon("tcp://localhost:0").Digest("registry.example.com/app@" + indexDigest)
+	require.NoError(t, err)
+	require.Equal(t, indexDigest, digest)
+}
+
+func TestErrorsWhenTheImageIsUnknownToTheDaemon(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	_, err := image.NewDaemon("tcp://" + strings.TrimPrefix(ts.URL, "http://")).Digest("app:1.0.0")
+	require.EqualError(t, err, "could not inspect app:1.0.0: 404 Not Found")
+}
@@ -0,0 +1,156 @@
+package image
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ociLayoutFile = "oci-layout"
+	ociIndexFile  = "index.json"
+	digestFile    = "digest"
+)
+
+// Image is an image archive, which can be inspected by Detect.
+type Image struct {
+	Tar    string
+	Digest string
+}
+
+type index struct {
+	Manifests []struct {
+		Digest string `json:"digest"`
+	} `json:"manifests"`
+}
+
+// Prepare returns the archive of an image.tar (docker save or OCI) or an OCI layout directory.
+// OCI layout directories are archived into tmpDir, as Detect only inspects archives.
+// The digest is taken from the digest file, which is written by registry-image and docker-image,
+// or from the OCI index of the image.
+func Prepare(path string, tmpDir string) (Image, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Image{}, fmt.Errorf("could not find image %v", path)
+	}
+	if info.IsDir() {
+		return prepareLayout(path, tmpDir)
+	}
+	digest, err := readDigestFile(filepath.Join(filepath.Dir(path), digestFile))
+	if err != nil {
+		digest, _ = readDigestFromArchive(path)
+	}
+	return Image{Tar: path, Digest: digest}, nil
+}
+
+func prepareLayout(dir string, tmpDir string) (Image, error) {
+	if _, err := os.Stat(filepath.Join(dir, ociLayoutFile)); err != nil {
+		return Image{}, fmt.Errorf("%v is no OCI layout directory", dir)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, ociIndexFile))
+	if err != nil {
+		return Image{}, err
+	}
+	digest, err := digestFromIndex(b)
+	if err != nil {
+		return Image{}, err
+	}
+	if fileDigest, err := readDigestFile(filepath.Join(filepath.Dir(dir), digestFile)); err == nil {
+		digest = fileDigest
+	}
+	archive := filepath.Join(tmpDir, "image.tar")
+	if err := archiveDirectory(dir, archive); err != nil {
+		return Image{}, err
+	}
+	return Image{Tar: archive, Digest: digest}, nil
+}
+
+func readDigestFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func readDigestFromArchive(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	reader := tar.NewReader(f)
+	for {
+		header, err := reader.Next()
+		if err != nil {
+			return "", err
+		}
+		if filepath.Clean(header.Name) == ociIndexFile {
+			b, err := ioutil.ReadAll(reader)
+			if err != nil {
+				return "", err
+			}
+			return digestFromIndex(b)
+		}
+	}
+}
+
+func digestFromIndex(b []byte) (string, error) {
+	var i index
+	if err := json.Unmarshal(b, &i); err != nil {
+		return "", err
+	}
+	if len(i.Manifests) == 0 {
+		return "", errors.New("the image index contains no manifest")
+	}
+	return i.Manifests[0].Digest, nil
+}
+
+func archiveDirectory(dir string, archive string) error {
+	f, err := os.Create(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writer := tar.NewWriter(f)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil || name == "." {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if err := writer.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		content, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer content.Close()
+		_, err = io.Copy(writer, content)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,95 @@
+package image_test
+
+import (
+	"archive/tar"
+	"github.com/elgohr/concourse-blackduck/out/image"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	indexDigest = "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	fileDigest  = "sha256:0e5eb2f9b7a9e1b5b3f0b4d1a8e2b1c1f0d5f6b0a5c1f3e8d3b6c0a9f8e7d6c5"
+	ociIndex    = `{"schemaVersion":2,"manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"` + indexDigest + `","size":7143}]}`
+)
+
+func writeTar(t *testing.T, path string, files map[string]string) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	w := tar.NewWriter(f)
+	for name, content := range files {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestUsesTheDigestFileNextToTheArchive(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "image.tar")
+	writeTar(t, archive, map[string]string{"manifest.json": "[]"})
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "digest"), []byte(fileDigest+"\n"), 0644))
+
+	img, err := image.Prepare(archive, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, archive, img.Tar)
+	require.Equal(t, fileDigest, img.Digest)
+}
+
+func TestReadsTheDigestFromAnOciArchive(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "image.tar")
+	writeTar(t, archive, map[string]string{"oci-layout": `{"imageLayoutVersion":"1.0.0"}`, "index.json": ociIndex})
+
+	img, err := image.Prepare(archive, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, indexDigest, img.Digest)
+}
+
+func TestHasNoDigestForDockerArchivesWithoutDigestFile(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "image.tar")
+	writeTar(t, archive, map[string]string{"manifest.json": "[]"})
+
+	img, err := image.Prepare(archive, t.TempDir())
+	require.NoError(t, err)
+	require.Empty(t, img.Digest)
+}
+
+func TestArchivesOciLayoutDirectories(t *testing.T) {
+	layout := filepath.Join(t.TempDir(), "oci")
+	require.NoError(t, os.MkdirAll(filepath.Join(layout, "blobs", "sha256"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(layout, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(layout, "index.json"), []byte(ociIndex), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(layout, "blobs", "sha256", "abc"), []byte("blob"), 0644))
+	tmpDir := t.TempDir()
+
+	img, err := image.Prepare(layout, tmpDir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(tmpDir, "image.tar"), img.Tar)
+	require.Equal(t, indexDigest, img.Digest)
+
+	f, err := os.Open(img.Tar)
+	require.NoError(t, err)
+	defer f.Close()
+	var names []string
+	r := tar.NewReader(f)
+	for header, err := r.Next(); err == nil; header, err = r.Next() {
+		names = append(names, header.Name)
+	}
+	require.ElementsMatch(t, []string{"blobs", "blobs/sha256", "blobs/sha256/abc", "index.json", "oci-layout"}, names)
+}
+
+func TestErrorsWhenTheDirectoryIsNoOciLayout(t *testing.T) {
+	dir := t.TempDir()
+	_, err := image.Prepare(dir, t.TempDir())
+	require.EqualError(t, err, dir+" is no OCI layout directory")
+}
+
+func TestErrorsWhenTheImageIsMissing(t *testing.T) {
+	_, err := image.Prepare("/not_here/image.tar", t.TempDir())
+	require.EqualError(t, err, "could not find image /not_here/image.tar")
+}
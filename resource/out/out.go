@@ -2,42 +2,79 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/elgohr/concourse-blackduck/out/agent"
+	"github.com/elgohr/concourse-blackduck/out/artifact"
+	"github.com/elgohr/concourse-blackduck/out/bdio"
+	"github.com/elgohr/concourse-blackduck/out/image"
 	"github.com/elgohr/concourse-blackduck/out/interpreter"
+	"github.com/elgohr/concourse-blackduck/out/process"
+	"github.com/elgohr/concourse-blackduck/out/rapid"
 	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/elgohr/concourse-blackduck/shared/junit"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 )
 
+// defaultMappingTimeout limits the wait for the code locations of uploaded BDIO documents, when no timeout is configured.
+const defaultMappingTimeout = 10 * time.Minute
+
 func main() {
 	runner := NewRunner()
 	if err := runner.run(); err != nil {
+		log.SetOutput(runner.stdErr)
 		log.Fatalln(err)
 	}
 }
 
 type Runner struct {
-	stdIn    io.Reader
-	stdOut   io.Writer
-	stdErr   io.Writer
-	path     string
-	agentDir string
-	exec     func(name string, arg ...string) *exec.Cmd
+	stdIn        io.Reader
+	stdOut       io.Writer
+	stdErr       io.Writer
+	path         string
+	agentDir     string
+	downloadDir  string
+	exec         func(name string, arg ...string) *exec.Cmd
+	signals      chan os.Signal
+	gracePeriod  time.Duration
+	pollInterval time.Duration
+	now          func() time.Time
+	imageDigest  func(ref string) (string, error)
+	api          shared.BlackduckApi
 }
 
 func NewRunner() Runner {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	bd := shared.NewBlackduck()
 	return Runner{
-		stdIn:    os.Stdin,
-		stdOut:   os.Stdout,
-		stdErr:   os.Stderr,
-		path:     os.Args[1],
-		agentDir: "/opt/resource",
-		exec:     exec.Command,
+		stdIn:        os.Stdin,
+		stdOut:       os.Stdout,
+		stdErr:       os.Stderr,
+		path:         os.Args[1],
+		agentDir:     "/opt/resource",
+		downloadDir:  "/opt/detect",
+		exec:         exec.Command,
+		signals:      signals,
+		gracePeriod:  30 * time.Second,
+		pollInterval: 5 * time.Second,
+		now:          time.Now,
+		imageDigest:  image.NewDaemon(os.Getenv("DOCKER_HOST")).Digest,
+		api:          &bd,
 	}
 }
 
@@ -46,47 +83,664 @@ func (r *Runner) run() error {
 	if err := json.NewDecoder(r.stdIn).Decode(&input); err != nil {
 		return err
 	}
+	redactingWriter := shared.NewRedactingWriter(r.stdErr, input.Source)
+	r.stdErr = redactingWriter
 	if !input.Source.Valid() {
 		return errors.New("missing mandatory source field")
 	}
 	if !input.Params.Valid() {
 		return errors.New("missing mandatory params field")
 	}
+	if input.Params.Action != shared.ActionScan {
+		err := r.runAction(input)
+		if flushErr := redactingWriter.Flush(); err == nil {
+			err = flushErr
+		}
+		return err
+	}
+	if err := input.Params.ValidateJava(); err != nil {
+		return err
+	}
+	timeout, err := input.Params.GetTimeout()
+	if err != nil {
+		return err
+	}
 
-	agentJar, _ := filepath.Glob(r.agentDir + "/synopsys-detect-*.jar")
-	if len(agentJar) != 1 {
-		return errors.New("could not find the scanner, please open an issue on Github")
+	ctx, interrupt := process.WithInterrupt(context.Background())
+	defer interrupt(nil)
+	go r.forwardSignals(ctx.Done(), interrupt)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	cmd := r.exec("java", getArguments(agentJar[0], input)...)
-	cmd.Dir = r.path + "/" + input.Params.Directory
-	cmd.Stderr = r.stdErr
-	buf := bytes.Buffer{}
-	cmd.Stdout = io.MultiWriter(&buf, r.stdErr)
+	detect, err := agent.Find(input.Source.DetectVersion, r.agentDir, r.downloadDir)
+	if err != nil {
+		return err
+	}
 
-	if err := cmd.Run(); err != nil {
+	java, err := r.getJava(input.Params)
+	if err != nil {
 		return err
 	}
 
-	outContent := buf.String()
-	response, err := interpreter.NewResponse(outContent)
+	s := scanner{
+		ctx:     ctx,
+		java:    java,
+		detect:  detect,
+		input:   input,
+		timeout: timeout,
+	}
+	var response interpreter.Response
+	if len(input.Params.Directories) != 0 {
+		response, err = r.scanDirectories(s, input.Params.Directories)
+	} else if input.Params.ScansImage() {
+		response, err = r.scanImage(s, input.Params)
+	} else if len(input.Params.Artifacts) != 0 {
+		response, err = r.scanArtifacts(s, input.Params)
+	} else {
+		response, err = r.scan(s, shared.Directory{Path: input.Params.Directory, VersionName: input.Params.VersionName}, r.stdErr)
+	}
+	// rapid and offline scans don't create versions on Blackduck
+	if err == nil && !input.Params.ScansRapidly() && !input.Params.Offline && input.Source.Retention.Configured() {
+		err = r.enforceRetention(input, response)
+	}
+	// the risk of several directories is added before their metadata is prefixed by their path
+	if err == nil && !input.Params.ScansRapidly() && !input.Params.Offline && len(input.Params.Directories) == 0 {
+		r.addRisk(input.Source, &response, r.stdErr)
+	}
+	// the report is written after failed policy checks as well, as they are what it reports
+	if len(input.Params.JunitFile) != 0 && !input.Params.ScansRapidly() && len(response.MetaData) != 0 {
+		if junitErr := r.writeBomJunit(input, response); err == nil {
+			err = junitErr
+		}
+	}
+	if flushErr := redactingWriter.Flush(); err == nil {
+		err = flushErr
+	}
+	if len(response.MetaData) == 0 {
+		return err
+	}
+	response.MetaData = append(response.MetaData, interpreter.MetaData{
+		Name:  "detectVersion",
+		Value: detect.Version.String(),
+	})
 	b, marshErr := json.Marshal(response)
 	if marshErr != nil {
 		return marshErr
 	}
-	fmt.Fprintf(r.stdOut, string(b))
+	_, _ = r.stdOut.Write(b)
+	return err
+}
+
+// forwardSignals interrupts on the first signal, until done is closed.
+func (r *Runner) forwardSignals(done <-chan struct{}, interrupt func(os.Signal)) {
+	select {
+	case s := <-r.signals:
+		interrupt(s)
+	case <-done:
+	}
+}
+
+// runAction runs the actions, which don't scan and therefore don't need Detect.
+// Signals stop the actions, which wait for Blackduck.
+func (r *Runner) runAction(input shared.Request) error {
+	ctx, interrupt := process.WithInterrupt(context.Background())
+	defer interrupt(nil)
+	go r.forwardSignals(ctx.Done(), interrupt)
+	switch input.Params.Action {
+	case shared.ActionUploadBdio:
+		return r.uploadBdio(ctx, input)
+	case shared.ActionSyncProject:
+		return r.syncProject(input)
+	case shared.ActionCleanup:
+		return r.cleanup(input)
+	case shared.ActionSetPhase:
+		return r.setPhase(input)
+	case shared.ActionRemediate:
+		return r.remediate(input)
+	case shared.ActionAdjust:
+		return r.adjust(input)
+	}
+	return fmt.Errorf("unknown action %v", input.Params.Action)
+}
+
+type scanner struct {
+	ctx     context.Context
+	java    string
+	detect  agent.Agent
+	input   shared.Request
+	timeout time.Duration
+}
+
+// scan runs Detect on a single directory.
+// The response is empty, when Detect couldn't finish.
+func (r *Runner) scan(s scanner, directory shared.Directory, stdErr io.Writer) (interpreter.Response, error) {
+	if s.input.Params.ScansRapidly() {
+		return r.scanRapidly(s, directory, stdErr)
+	}
+	if s.input.Params.Offline {
+		return r.scanOffline(s, directory, stdErr)
+	}
+	if err := r.prepareProject(s.ctx, s.input.Source, directory, stdErr); err != nil {
+		return interpreter.Response{}, err
+	}
+	output, err := r.execute(s, directory, stdErr)
+	if err != nil {
+		return interpreter.Response{}, err
+	}
+	return interpreter.NewResponse(output)
+}
+
+// prepareProject creates the project and version with the configured settings before the scan,
+// as Detect would create them with default settings otherwise. Existing versions are updated, when they differ.
+func (r *Runner) prepareProject(ctx context.Context, source shared.Source, directory shared.Directory, stdErr io.Writer) error {
+	if !source.Project.Configured() && !source.Version.Configured() {
+		return nil
+	}
+	source.Name = getProjectName(source, directory)
+	project, err := r.api.GetProjectByName(source)
+	if errors.Is(err, shared.ErrProjectNotFound) {
+		fmt.Fprintf(stdErr, "Creating project %v\n", source.Name)
+		project, err = r.api.CreateProject(source, source.Project.NewProject(source.Name))
+	} else if err == nil && source.Project.Configured() {
+		err = r.updateProject(source, *project, stdErr)
+	}
+	if err != nil {
+		return err
+	}
+	if len(directory.VersionName) == 0 {
+		return nil
+	}
+	versions, err := r.api.GetProjectVersions(source, project)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if version.Name != directory.VersionName {
+			continue
+		}
+		if !source.Version.Apply(&version) {
+			return nil
+		}
+		fmt.Fprintf(stdErr, "Updating version %v\n", version.Name)
+		return r.api.UpdateVersion(source, version)
+	}
+	version := source.Version.NewVersion(directory.VersionName)
+	from, err := source.Version.SelectCloneSource(versions, version.Name)
+	if err != nil {
+		return err
+	}
+	if from != nil {
+		return r.cloneVersion(ctx, source, project, *from, version, stdErr)
+	}
+	fmt.Fprintf(stdErr, "Creating version %v\n", version.Name)
+	_, err = r.api.CreateVersion(source, project, version)
 	return err
 }
 
-func getArguments(agentJar string, input shared.Request) []string {
-	args := []string{
+// updateProject applies the configured settings to an existing project, when they differ.
+func (r *Runner) updateProject(source shared.Source, project shared.Project, stdErr io.Writer) error {
+	settings := source.Project
+	if len(settings.Group) != 0 && !strings.HasPrefix(settings.Group, "http") {
+		group, err := r.api.GetProjectGroupUrl(source, settings.Group)
+		if err != nil {
+			return err
+		}
+		settings.Group = group
+	}
+	if !settings.Apply(&project) {
+		return nil
+	}
+	fmt.Fprintf(stdErr, "Updating project %v\n", project.Name)
+	return r.api.UpdateProject(source, project)
+}
+
+func (r *Runner) execute(s scanner, directory shared.Directory, stdErr io.Writer) (string, error) {
+	if err := s.ctx.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", fmt.Errorf("%w after %v", process.ErrTimeout, s.timeout)
+		}
+		return "", process.ErrInterrupted
+	}
+	cmd := r.exec(s.java, getArguments(s.detect.Path, s.input, directory)...)
+	cmd.Dir = r.path + "/" + directory.Path
+	if len(s.input.Params.JavaHome) != 0 {
+		cmd.Env = append(os.Environ(), "JAVA_HOME="+filepath.Dir(filepath.Dir(s.java)))
+	}
+	cmd.Stderr = stdErr
+	buf := bytes.Buffer{}
+	cmd.Stdout = io.MultiWriter(&buf, stdErr)
+
+	err := process.Run(s.ctx, cmd, r.gracePeriod)
+	if errors.Is(err, process.ErrTimeout) {
+		return buf.String(), fmt.Errorf("%w after %v", err, s.timeout)
+	}
+	return buf.String(), err
+}
+
+// scanRapidly runs an ephemeral scan, which doesn't persist a project version on Blackduck.
+// The policy violations are taken from the result file, which Detect writes into its output directory.
+func (r *Runner) scanRapidly(s scanner, directory shared.Directory, stdErr io.Writer) (interpreter.Response, error) {
+	outputDir, err := ioutil.TempDir("", "blackduck-rapid")
+	if err != nil {
+		return interpreter.Response{}, err
+	}
+	defer os.RemoveAll(outputDir)
+	properties := map[string]string{}
+	for key, value := range directory.Properties {
+		properties[key] = value
+	}
+	// The configured properties can't override the scan mode or the directory, which the result is read from.
+	properties["detect.blackduck.scan.mode"] = "RAPID"
+	properties["detect.output.path"] = outputDir
+	directory.Properties = properties
+
+	output, err := r.execute(s, directory, stdErr)
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return interpreter.Response{}, err
+	}
+	result, loadErr := rapid.Load(outputDir)
+	if loadErr != nil {
+		if err != nil {
+			return interpreter.Response{}, err
+		}
+		return interpreter.Response{}, loadErr
+	}
+	fmt.Fprint(stdErr, result.Summary())
+	if len(s.input.Params.JunitFile) != 0 {
+		if err := r.writeJunit(result, directory, s.input.Params.JunitFile); err != nil {
+			return interpreter.Response{}, err
+		}
+	}
+
+	detected, statusErr := interpreter.NewResponse(output)
+	response := interpreter.Response{Id: shared.Ref{Ref: "rapid-" + result.Digest}}
+	for _, m := range detected.MetaData {
+		if len(m.Value) != 0 {
+			response.MetaData = append(response.MetaData, m)
+		}
+	}
+	response.MetaData = append(response.MetaData,
+		interpreter.MetaData{Name: "scanMode", Value: "RAPID"},
+		interpreter.MetaData{Name: "components", Value: strconv.Itoa(len(result.Components))},
+		interpreter.MetaData{Name: "violations", Value: strconv.Itoa(len(result.Violations()))},
+	)
+	if statusErr != nil {
+		return response, statusErr
+	}
+	return response, err
+}
+
+// writeJunit reports the policy violations of the rapid scan as JUnit XML into the junit_file.
+// As only violated policies are known, there is a suite per violated policy.
+func (r *Runner) writeJunit(result rapid.Result, directory shared.Directory, junitFile string) error {
+	var components []junit.Component
+	for _, c := range result.Components {
+		components = append(components, junit.Component{Name: c.Name, Version: c.Version, Violated: c.Policies()})
+	}
+	return r.writeJunitFile(junit.New(directory.Path, nil, components), junitFile)
+}
+
+// writeBomJunit reports the policy compliance of the scanned version as JUnit XML into the junit_file like get does.
+func (r *Runner) writeBomJunit(input shared.Request, response interpreter.Response) error {
+	source, versionName := scannedVersion(input.Source, response)
+	if len(versionName) == 0 {
+		return errors.New("could not find the scanned version to write the junit_file")
+	}
+	version, err := r.getVersion(source, versionName)
+	if err != nil {
+		return err
+	}
+	components, err := r.api.GetBomComponents(source, &version)
+	if err != nil {
+		return err
+	}
+	suites, err := junit.FromBom(r.api, source, version.Name, components)
+	if err != nil {
+		return err
+	}
+	return r.writeJunitFile(suites, input.Params.JunitFile)
+}
+
+func (r *Runner) writeJunitFile(suites junit.TestSuites, junitFile string) error {
+	b, err := suites.Marshal()
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(r.path, junitFile)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, b, 0644)
+}
+
+// scanOffline runs Detect without contacting Blackduck.
+// The generated BDIO and signature scan files are collected into the bdio_directory, so that they can be uploaded later.
+func (r *Runner) scanOffline(s scanner, directory shared.Directory, stdErr io.Writer) (interpreter.Response, error) {
+	outputDir, err := ioutil.TempDir("", "blackduck-offline")
+	if err != nil {
+		return interpreter.Response{}, err
+	}
+	defer os.RemoveAll(outputDir)
+	properties := map[string]string{}
+	for key, value := range directory.Properties {
+		properties[key] = value
+	}
+	// The configured properties can't turn the offline mode off or move the directory, which the BDIO files are collected from.
+	properties["blackduck.offline.mode"] = "true"
+	properties["detect.output.path"] = outputDir
+	directory.Properties = properties
+
+	output, err := r.execute(s, directory, stdErr)
+	if err != nil {
+		return interpreter.Response{}, err
+	}
+	files, digest, err := bdio.Collect(outputDir, filepath.Join(r.path, s.input.Params.BdioDirectory))
+	if err != nil {
+		return interpreter.Response{}, err
+	}
+	detected, statusErr := interpreter.NewResponse(output)
+	response := interpreter.Response{Id: shared.Ref{Ref: "offline-" + digest}}
+	for _, m := range detected.MetaData {
+		if len(m.Value) != 0 {
+			response.MetaData = append(response.MetaData, m)
+		}
+	}
+	response.MetaData = append(response.MetaData,
+		interpreter.MetaData{Name: "offline", Value: "true"},
+		interpreter.MetaData{Name: "files", Value: strings.Join(files, ", ")},
+	)
+	return response, statusErr
+}
+
+// uploadBdio uploads BDIO documents of earlier offline scans without running Detect.
+// It waits until Blackduck mapped a code location for every document to the target version,
+// as the results wouldn't be visible before.
+func (r *Runner) uploadBdio(ctx context.Context, input shared.Request) error {
+	timeout, err := input.Params.GetTimeout()
+	if err != nil {
+		return err
+	}
+	if timeout == 0 {
+		timeout = defaultMappingTimeout
+	}
+	files, err := bdio.Find(filepath.Join(r.path, input.Params.BdioDirectory))
+	if err != nil {
+		return err
+	}
+	digest, err := bdio.Digest(files)
+	if err != nil {
+		return err
+	}
+	// the project or version doesn't need to exist before the first upload
+	known, _ := r.getCodeLocations(input.Source, input.Params.VersionName)
+	response := interpreter.Response{Id: shared.Ref{Ref: "upload-" + digest}}
+	expected := map[string]bool{}
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return process.ErrInterrupted
+		}
+		rel, _ := filepath.Rel(r.path, file)
+		fmt.Fprintf(r.stdErr, "Uploading %v\n", rel)
+		codeLocation, err := r.api.UploadBdio(input.Source, file)
+		if err != nil {
+			return err
+		}
+		expected[codeLocation] = true
+		response.MetaData = append(response.MetaData, interpreter.MetaData{Name: "uploaded", Value: rel})
+	}
+	fmt.Fprintf(r.stdErr, "Waiting for the code locations of %v\n", input.Params.VersionName)
+	mapped, err := r.waitForCodeLocations(ctx, input.Source, input.Params.VersionName, known, expected, timeout)
+	if err != nil {
+		return err
+	}
+	for _, codeLocation := range mapped {
+		response.MetaData = append(response.MetaData, interpreter.MetaData{Name: "codeLocation", Value: codeLocation})
+	}
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	_, err = r.stdOut.Write(b)
+	return err
+}
+
+// waitForCodeLocations polls Blackduck until every expected code location of the version is new or was updated.
+func (r *Runner) waitForCodeLocations(ctx context.Context, source shared.Source, versionName string, known map[string]time.Time, expected map[string]bool, timeout time.Duration) ([]string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		codeLocations, err := r.getCodeLocations(source, versionName)
+		var mapped []string
+		for name := range expected {
+			updated, found := codeLocations[name]
+			if before, ok := known[name]; found && (!ok || updated.After(before)) {
+				mapped = append(mapped, name)
+			}
+		}
+		if len(mapped) == len(expected) {
+			sort.Strings(mapped)
+			return mapped, nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return nil, fmt.Errorf("code locations weren't mapped to version %v within %v: %w", versionName, timeout, err)
+			}
+			return nil, fmt.Errorf("only %v of %v code locations were mapped to version %v within %v", len(mapped), len(expected), versionName, timeout)
+		}
+		if err := r.sleep(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits for the poll interval, unless the context is done before.
+func (r *Runner) sleep(ctx context.Context) error {
+	timer := time.NewTimer(r.pollInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return process.ErrInterrupted
+	case <-timer.C:
+		return nil
+	}
+}
+
+// getCodeLocations returns the last update of every code location, which is mapped to the version.
+func (r *Runner) getCodeLocations(source shared.Source, versionName string) (map[string]time.Time, error) {
+	project, err := r.api.GetProjectByName(source)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := r.api.GetProjectVersions(source, project)
+	if err != nil {
+		return nil, err
+	}
+	for i := range versions {
+		if versions[i].Name != versionName {
+			continue
+		}
+		codeLocations, err := r.api.GetCodeLocations(source, &versions[i])
+		if err != nil {
+			return nil, err
+		}
+		updates := map[string]time.Time{}
+		for _, codeLocation := range codeLocations {
+			updates[codeLocation.Name] = codeLocation.Updated
+		}
+		return updates, nil
+	}
+	return nil, fmt.Errorf("could not find version %v", versionName)
+}
+
+// scanImage inspects an image with the Docker inspector of Detect instead of scanning a directory.
+func (r *Runner) scanImage(s scanner, params shared.Params) (interpreter.Response, error) {
+	properties := map[string]string{"detect.tools": "DOCKER,SIGNATURE_SCAN"}
+	var digest string
+	if len(params.ImageTar) != 0 {
+		tmpDir, err := ioutil.TempDir("", "blackduck-image")
+		if err != nil {
+			return interpreter.Response{}, err
+		}
+		defer os.RemoveAll(tmpDir)
+		img, err := image.Prepare(filepath.Join(r.path, params.ImageTar), tmpDir)
+		if err != nil {
+			return interpreter.Response{}, err
+		}
+		properties["detect.docker.tar"] = img.Tar
+		digest = img.Digest
+	} else {
+		properties["detect.docker.image"] = params.ImageRef
+	}
+	response, err := r.scan(s, shared.Directory{Path: ".", VersionName: params.VersionName, Properties: properties}, r.stdErr)
+	if len(response.MetaData) == 0 {
+		return response, err
+	}
+	if len(params.ImageRef) != 0 {
+		response.MetaData = append(response.MetaData, interpreter.MetaData{Name: "imageRef", Value: params.ImageRef})
+	}
+	if len(params.ImageTar) == 0 {
+		// the image was pulled by the Docker Inspector, so that the daemon knows which digest the reference was resolved to
+		resolved, digestErr := r.imageDigest(params.ImageRef)
+		if digestErr != nil {
+			fmt.Fprintf(r.stdErr, "Could not resolve the digest of %v: %v\n", params.ImageRef, digestErr)
+		}
+		digest = resolved
+	}
+	if len(digest) != 0 {
+		response.MetaData = append(response.MetaData, interpreter.MetaData{Name: "imageDigest", Value: digest})
+	}
+	return response, err
+}
+
+// scanArtifacts uploads build artifacts like jars, zips or firmware to the binary scanner of Detect.
+func (r *Runner) scanArtifacts(s scanner, params shared.Params) (interpreter.Response, error) {
+	maxCount, maxBytes := params.GetArtifactLimits()
+	collection, err := artifact.Collect(r.path, params.Artifacts, artifact.Limits{MaxCount: maxCount, MaxBytes: maxBytes})
+	if err != nil {
+		return interpreter.Response{}, err
+	}
+	tmpDir, err := ioutil.TempDir("", "blackduck-artifacts")
+	if err != nil {
+		return interpreter.Response{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+	target, err := collection.Target(tmpDir)
+	if err != nil {
+		return interpreter.Response{}, err
+	}
+	fmt.Fprintf(r.stdErr, "Uploading %d artifacts (%d bytes) to the binary scanner:\n", len(collection.Artifacts), collection.Bytes)
+	for _, a := range collection.Artifacts {
+		fmt.Fprintf(r.stdErr, "  %v (%d bytes)\n", a.Path, a.Size)
+	}
+	response, err := r.scan(s, shared.Directory{Path: ".", VersionName: params.VersionName, Properties: map[string]string{
+		"detect.tools":                 "BINARY_SCAN",
+		"detect.binary.scan.file.path": target,
+	}}, r.stdErr)
+	if len(response.MetaData) == 0 {
+		return response, err
+	}
+	response.MetaData = append(response.MetaData,
+		interpreter.MetaData{Name: "artifacts", Value: strings.Join(collection.Paths(), ", ")},
+		interpreter.MetaData{Name: "artifactCount", Value: strconv.Itoa(len(collection.Artifacts))},
+		interpreter.MetaData{Name: "artifactBytes", Value: strconv.FormatInt(collection.Bytes, 10)},
+		interpreter.MetaData{Name: "maxArtifactCount", Value: strconv.Itoa(maxCount)},
+		interpreter.MetaData{Name: "maxArtifactBytes", Value: strconv.FormatInt(maxBytes, 10)},
+	)
+	return response, err
+}
+
+type directoryResult struct {
+	directory shared.Directory
+	response  interpreter.Response
+	err       error
+}
+
+// scanDirectories runs Detect on every directory, while only running a limited number of scans at once.
+func (r *Runner) scanDirectories(s scanner, directories []shared.Directory) (interpreter.Response, error) {
+	results := make([]directoryResult, len(directories))
+	limit := make(chan struct{}, s.input.Params.GetConcurrency())
+	var wg sync.WaitGroup
+	for i, directory := range directories {
+		wg.Add(1)
+		go func(i int, directory shared.Directory) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+			stdErr := process.NewPrefixWriter(r.stdErr, "["+directory.Path+"] ")
+			response, err := r.scan(s, directory, stdErr)
+			if err == nil && !s.input.Params.ScansRapidly() && !s.input.Params.Offline {
+				r.addRisk(s.input.Source, &response, stdErr)
+			}
+			_ = stdErr.Flush()
+			results[i] = directoryResult{directory: directory, response: response, err: err}
+		}(i, directory)
+	}
+	wg.Wait()
+	return aggregate(results)
+}
+
+func aggregate(results []directoryResult) (interpreter.Response, error) {
+	var (
+		refs   []string
+		failed []string
+		meta   []interpreter.MetaData
+	)
+	for _, result := range results {
+		status := "SUCCESS"
+		if result.err != nil {
+			status = result.err.Error()
+			failed = append(failed, result.directory.Path+" ("+status+")")
+		}
+		if len(result.response.Id.Ref) != 0 {
+			refs = append(refs, result.response.Id.Ref)
+		}
+		for _, m := range result.response.MetaData {
+			meta = append(meta, interpreter.MetaData{Name: result.directory.Path + "." + m.Name, Value: m.Value})
+		}
+		meta = append(meta, interpreter.MetaData{Name: result.directory.Path + ".status", Value: status})
+	}
+	summary := fmt.Sprintf("%d of %d scans succeeded", len(results)-len(failed), len(results))
+	if len(failed) != 0 {
+		summary += ", failed: " + strings.Join(failed, ", ")
+	}
+	response := interpreter.Response{
+		Id:       shared.Ref{Ref: strings.Join(refs, ",")},
+		MetaData: append([]interpreter.MetaData{{Name: "summary", Value: summary}}, meta...),
+	}
+	if len(failed) != 0 {
+		return response, errors.New(summary)
+	}
+	return response, nil
+}
+
+func (r *Runner) getJava(params shared.Params) (string, error) {
+	if len(params.JavaHome) == 0 {
+		return "java", nil
+	}
+	javaHome := params.JavaHome
+	if !filepath.IsAbs(javaHome) {
+		javaHome = filepath.Join(r.path, javaHome)
+	}
+	java := filepath.Join(javaHome, "bin", "java")
+	if info, err := os.Stat(java); err != nil || info.IsDir() {
+		return "", fmt.Errorf("could not find java in %v", params.JavaHome)
+	}
+	return java, nil
+}
+
+func getArguments(agentJar string, input shared.Request, directory shared.Directory) []string {
+	args := append([]string{}, input.Params.JavaOpts...)
+	args = append(args,
 		"-jar",
 		agentJar,
-		"--blackduck.url=" + input.Source.Url,
-		"--detect.project.name=" + input.Source.Name,
-		"--blackduck.username=" + input.Source.Username,
-		"--blackduck.password=" + input.Source.Password,
-	}
+		"--blackduck.url="+input.Source.Url,
+		"--detect.project.name="+getProjectName(input.Source, directory),
+		"--blackduck.username="+input.Source.Username,
+		"--blackduck.password="+input.Source.Password,
+	)
 	if input.Source.Insecure {
 		args = append(args, "--blackduck.trust.cert=true")
 	}
@@ -102,5 +756,26 @@ func getArguments(agentJar string, input shared.Request) []string {
 	if len(input.Source.ProxyPassword) != 0 {
 		args = append(args, "--blackduck.proxy.password="+input.Source.ProxyPassword)
 	}
+	if len(input.Params.FailOnSeverities) != 0 {
+		args = append(args, "--detect.policy.check.fail.on.severities="+strings.Join(input.Params.FailOnSeverities, ","))
+	}
+	if len(directory.VersionName) != 0 {
+		args = append(args, "--detect.project.version.name="+directory.VersionName)
+	}
+	keys := make([]string, 0, len(directory.Properties))
+	for key := range directory.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--"+key+"="+directory.Properties[key])
+	}
 	return args
 }
+
+func getProjectName(source shared.Source, directory shared.Directory) string {
+	if len(directory.ProjectName) != 0 {
+		return directory.ProjectName
+	}
+	return source.Name
+}
@@ -2,15 +2,21 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/elgohr/concourse-blackduck/shared/sharedfakes"
 	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 )
 
 func TestConstructsRunnerCorrectly(t *testing.T) {
@@ -36,6 +42,18 @@ func TestConstructsRunnerCorrectly(t *testing.T) {
 	if r.agentDir != "/opt/resource" {
 		t.Errorf("Expected the agent to be searched in /opt/resource, but was %v", r.agentDir)
 	}
+	if r.downloadDir != "/opt/detect" {
+		t.Errorf("Expected the agent to fall back to /opt/detect, but was %v", r.downloadDir)
+	}
+	if r.api == nil {
+		t.Error("Didn't set Blackduck Api")
+	}
+	if r.now == nil {
+		t.Error("Didn't set the clock")
+	}
+	if r.imageDigest == nil {
+		t.Error("Didn't set the Docker daemon")
+	}
 }
 
 func TestStartsBlackduckWithUsernamePassword(t *testing.T) {
@@ -233,12 +251,21 @@ func TestReturnsTheVersionAndMetaDataOfTheBlackduckScan(t *testing.T) {
 	stdIn := &bytes.Buffer{}
 	stdOut := &bytes.Buffer{}
 	dir, _ := prepareMockAgentFile(t)
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "presentations"}, nil)
+	fakeBlackduckApi.GetProjectVersionsReturns([]shared.Version{{Name: "0.9.0"}, {Name: "1.0.0"}}, nil)
+	fakeBlackduckApi.GetRiskProfileReturns(shared.RiskProfile{Categories: map[string]map[string]int{
+		"VULNERABILITY": {"CRITICAL": 1},
+		"OPERATIONAL":   {"LOW": 4},
+	}}, nil)
+	fakeBlackduckApi.GetPolicyStatusReturns(shared.PolicyStatus{OverallStatus: "NOT_IN_VIOLATION"}, nil)
 
 	r := Runner{
 		stdIn:    stdIn,
 		stdOut:   stdOut,
 		stdErr:   &bytes.Buffer{},
 		agentDir: dir,
+		api:      fakeBlackduckApi,
 		exec: func(name string, arg ...string) *exec.Cmd {
 			b, err := ioutil.ReadFile("testdata/blackduckResponse.txt")
 			if err != nil {
@@ -270,9 +297,27 @@ func TestReturnsTheVersionAndMetaDataOfTheBlackduckScan(t *testing.T) {
 		"metadata": [
 			{ "name": "name", "value": "presentations" },
 			{ "name": "version", "value": "1.0.0" },
-			{ "name": "url", "value": "https://my.host/api/projects/d6aed8bb-0b9a-46a2-a1ce-60101939eb10/versions/d1530c19-1541-443f-8a5e-ea4e17c856a8/components" }
+			{ "name": "url", "value": "https://my.host/api/projects/d6aed8bb-0b9a-46a2-a1ce-60101939eb10/versions/d1530c19-1541-443f-8a5e-ea4e17c856a8/components" },
+			{ "name": "securityCritical", "value": "1" },
+			{ "name": "securityHigh", "value": "0" },
+			{ "name": "securityMedium", "value": "0" },
+			{ "name": "securityLow", "value": "0" },
+			{ "name": "licenseHigh", "value": "0" },
+			{ "name": "licenseMedium", "value": "0" },
+			{ "name": "licenseLow", "value": "0" },
+			{ "name": "operationalHigh", "value": "0" },
+			{ "name": "operationalMedium", "value": "0" },
+			{ "name": "operationalLow", "value": "4" },
+			{ "name": "policyStatus", "value": "NOT_IN_VIOLATION" },
+			{ "name": "detectVersion", "value": "5.4.99" }
 		]
 	}`, "\n", ""), "	", ""), " ", "")
+	if source := fakeBlackduckApi.GetProjectByNameArgsForCall(0); source.Name != "presentations" {
+		t.Errorf("Expected the risk of the scanned project, but got %v", source.Name)
+	}
+	if _, version := fakeBlackduckApi.GetRiskProfileArgsForCall(0); version.Name != "1.0.0" {
+		t.Errorf("Expected the risk of the scanned version, but got %v", version.Name)
+	}
 	if stdOut.String() != expRes {
 		t.Errorf(`Expected: %v
 				Got:   %v`, expRes, stdOut.String())
@@ -320,7 +365,8 @@ func TestErrorsWhenTheScanFails(t *testing.T) {
 		"metadata": [
 			{ "name": "name", "value": "accountant" },
 			{ "name": "version", "value": "%v" },
-			{ "name": "url", "value": "https://my.Host/api/projects/01883e41-d4c9-420a-b41b-0ddcaadda2b5/versions/509ce50d-b7a2-4303-89bf-bde16e4b7bef/components" }
+			{ "name": "url", "value": "https://my.Host/api/projects/01883e41-d4c9-420a-b41b-0ddcaadda2b5/versions/509ce50d-b7a2-4303-89bf-bde16e4b7bef/components" },
+			{ "name": "detectVersion", "value": "5.4.99" }
 		]
 	}`, "\n", ""), "	", ""), " ", ""), "Default Detect Version")
 	if stdOut.String() != expRes {
@@ -648,6 +694,16 @@ func prepareMockAgentFile(t *testing.T) (string, string) {
 	return dir, mockFileName
 }
 
+func prepareBuildDir(t *testing.T, directories ...string) string {
+	dir := t.TempDir()
+	for _, directory := range directories {
+		if err := os.MkdirAll(filepath.Join(dir, directory), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
 func TestAddsProxySettingsIfConfigured(t *testing.T) {
 	stdIn := &bytes.Buffer{}
 
@@ -716,3 +772,1564 @@ func TestAddsProxySettingsIfConfigured(t *testing.T) {
 		t.Error("Blackduck wasn't started")
 	}
 }
+
+func TestRedactsCredentialsInTheLogOutput(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   stdErr,
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			return exec.Command("echo", strings.Join(arg, " "))
+		},
+	}
+
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "PASSWORD",
+				"name": "project1",
+				"proxy-host": "http://PROXY",
+				"proxy-password": "PROXY_PASSWORD"
+  			},
+			"params": {
+				"directory": "/"
+			}
+		}`)
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	logs := stdErr.String()
+	if strings.Contains(logs, "PASSWORD") {
+		t.Errorf("Expected credentials to be redacted, but got %v", logs)
+	}
+	if !strings.Contains(logs, "--blackduck.password=****") {
+		t.Errorf("Expected the masked password in the logs, but got %v", logs)
+	}
+}
+
+func TestUsesTheConfiguredDetectVersion(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	dir, _ := prepareMockAgentFile(t)
+	for _, jar := range []string{"synopsys-detect-6.9.1.jar", "synopsys-detect-7.1.0.jar"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, jar), []byte(""), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1",
+				"detect_version": "6"
+  			},
+			"params": {
+				"directory": "."
+			}
+		}`)
+
+	var usedJar string
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   &bytes.Buffer{},
+		agentDir: dir,
+		exec: func(command string, arg ...string) *exec.Cmd {
+			usedJar = arg[1]
+			return exec.Command("true")
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	if usedJar != filepath.Join(dir, "synopsys-detect-6.9.1.jar") {
+		t.Errorf("Expected detect 6.9.1 to be used, but was %v", usedJar)
+	}
+	if !strings.Contains(stdOut.String(), `{"name":"detectVersion","value":"6.9.1"}`) {
+		t.Errorf("Expected the detect version in the metadata, but got %v", stdOut.String())
+	}
+}
+
+func TestFallsBackToTheDownloadDirectoryForUnbundledDetectVersions(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	dir, _ := prepareMockAgentFile(t)
+	downloadDir, _ := prepareMockAgentFile(t)
+	if err := os.Rename(filepath.Join(downloadDir, "synopsys-detect-5.4.99.jar"), filepath.Join(downloadDir, "synopsys-detect-4.2.0.jar")); err != nil {
+		t.Fatal(err)
+	}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1",
+				"detect_version": "4.2"
+  			},
+			"params": {
+				"directory": "."
+			}
+		}`)
+
+	var usedJar string
+	r := Runner{
+		stdIn:       stdIn,
+		stdOut:      &bytes.Buffer{},
+		stdErr:      &bytes.Buffer{},
+		agentDir:    dir,
+		downloadDir: downloadDir,
+		exec: func(command string, arg ...string) *exec.Cmd {
+			usedJar = arg[1]
+			return exec.Command("true")
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	if usedJar != filepath.Join(downloadDir, "synopsys-detect-4.2.0.jar") {
+		t.Errorf("Expected the downloaded detect to be used, but was %v", usedJar)
+	}
+}
+
+func TestStartsJavaWithTheConfiguredOptionsAndRuntime(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	buildDir := t.TempDir()
+	javaHome := filepath.Join(buildDir, "jdk")
+	if err := os.MkdirAll(filepath.Join(javaHome, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(javaHome, "bin", "java"), []byte(""), 0755); err != nil {
+		t.Fatal(err)
+	}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": ".",
+				"java_opts": ["-Xmx4g", "-Djava.io.tmpdir=/tmp/scan"],
+				"java_home": "jdk"
+			}
+		}`)
+
+	dir, mockFileName := prepareMockAgentFile(t)
+	command := exec.Command("true")
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		path:     buildDir,
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			if name != filepath.Join(javaHome, "bin", "java") {
+				t.Errorf("Should have started the configured java, but started %v", name)
+			}
+			expectedArgs := []string{"-Xmx4g", "-Djava.io.tmpdir=/tmp/scan", "-jar", dir + "/" + mockFileName}
+			for i, a := range expectedArgs {
+				if arg[i] != a {
+					t.Errorf("Expected argument %v, but got %v", a, arg[i])
+				}
+			}
+			return command
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	expEnv := "JAVA_HOME=" + javaHome
+	if command.Env[len(command.Env)-1] != expEnv {
+		t.Errorf("Expected %v in the environment, but got %v", expEnv, command.Env)
+	}
+}
+
+func TestErrorsWhenJavaOptionsOverrideTheScanner(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": ".",
+				"java_opts": ["-jar", "evil.jar"]
+			}
+		}`)
+
+	var called bool
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			called = true
+			return exec.Command("true")
+		},
+	}
+
+	errMsg := "java option -jar is not allowed"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+	if called {
+		t.Error("Should not have called Blackduck")
+	}
+}
+
+func TestErrorsWhenJavaHomeContainsNoJava(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": ".",
+				"java_home": "/not_here"
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			t.Error("Should not have called Blackduck")
+			return exec.Command("true")
+		},
+	}
+
+	errMsg := "could not find java in /not_here"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+}
+
+func TestErrorsWhenTheScanTimesOut(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": ".",
+				"timeout": "50ms"
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:       stdIn,
+		stdOut:      stdOut,
+		stdErr:      &bytes.Buffer{},
+		agentDir:    dir,
+		gracePeriod: time.Second,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			return exec.Command("sleep", "10")
+		},
+	}
+
+	errMsg := "scan timed out after 50ms"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+	if stdOut.Len() != 0 {
+		t.Errorf("Expected no version to be emitted, but got %v", stdOut.String())
+	}
+}
+
+func TestErrorsWhenTheScanTimesOutBeforeItStarts(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": ".",
+				"timeout": "1ns"
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			t.Error("Should not have started the scan")
+			return exec.Command("true")
+		},
+	}
+
+	errMsg := "scan timed out after 1ns"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+}
+
+func TestForwardsSignalsToTheScanner(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": "."
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	signals := make(chan os.Signal, 1)
+	r := Runner{
+		stdIn:       stdIn,
+		stdOut:      &bytes.Buffer{},
+		stdErr:      &bytes.Buffer{},
+		agentDir:    dir,
+		signals:     signals,
+		gracePeriod: time.Second,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			signals <- syscall.SIGTERM
+			return exec.Command("sleep", "10")
+		},
+	}
+
+	errMsg := "scan was interrupted"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+}
+
+func TestScansSeveralDirectoriesAndAggregatesTheResults(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directories": [
+					{ "path": "service-a", "project_name": "a", "version_name": "1.0.0", "properties": { "detect.tools": "DETECTOR", "detect.excluded.directories": "test" } },
+					{ "path": "service-b" }
+				]
+			}
+		}`)
+
+	dir, mockFileName := prepareMockAgentFile(t)
+	buildDir := prepareBuildDir(t, "service-a", "service-b")
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "presentations"}, nil)
+	fakeBlackduckApi.GetProjectVersionsReturns([]shared.Version{{Name: "1.0.0"}}, nil)
+	fakeBlackduckApi.GetRiskProfileReturns(shared.RiskProfile{Categories: map[string]map[string]int{"VULNERABILITY": {"CRITICAL": 1}}}, nil)
+	fakeBlackduckApi.GetPolicyStatusReturns(shared.PolicyStatus{OverallStatus: "IN_VIOLATION"}, nil)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   stdErr,
+		path:     buildDir,
+		agentDir: dir,
+		api:      fakeBlackduckApi,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			response := "testdata/blackduckError.txt"
+			if arg[3] == "--detect.project.name=a" {
+				response = "testdata/blackduckResponse.txt"
+				expectedArgs := []string{
+					"-jar",
+					dir + "/" + mockFileName,
+					"--blackduck.url=https://BLACKDUCK",
+					"--detect.project.name=a",
+					"--blackduck.username=username",
+					"--blackduck.password=password",
+					"--detect.project.version.name=1.0.0",
+					"--detect.excluded.directories=test",
+					"--detect.tools=DETECTOR",
+				}
+				if !reflect.DeepEqual(arg, expectedArgs) {
+					t.Errorf("Expected arguments %v, but got %v", expectedArgs, arg)
+				}
+			} else if arg[3] != "--detect.project.name=project1" {
+				t.Errorf("Expected the project name of the source, but got %v", arg[3])
+			}
+			b, err := ioutil.ReadFile(response)
+			if err != nil {
+				t.Error(err)
+			}
+			return exec.Command("sh", "-c", `echo "$1"; echo done >&2`, "sh", string(b))
+		},
+	}
+
+	errMsg := "1 of 2 scans succeeded, failed: service-b (FAILURE_DETECTOR)"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+
+	expRes := `{"version":{"ref":"d1530c19-1541-443f-8a5e-ea4e17c856a8,509ce50d-b7a2-4303-89bf-bde16e4b7bef"},"metadata":[` +
+		`{"name":"summary","value":"1 of 2 scans succeeded, failed: service-b (FAILURE_DETECTOR)"},` +
+		`{"name":"service-a.name","value":"presentations"},` +
+		`{"name":"service-a.version","value":"1.0.0"},` +
+		`{"name":"service-a.url","value":"https://my.host/api/projects/d6aed8bb-0b9a-46a2-a1ce-60101939eb10/versions/d1530c19-1541-443f-8a5e-ea4e17c856a8/components"},` +
+		`{"name":"service-a.securityCritical","value":"1"},` +
+		`{"name":"service-a.securityHigh","value":"0"},` +
+		`{"name":"service-a.securityMedium","value":"0"},` +
+		`{"name":"service-a.securityLow","value":"0"},` +
+		`{"name":"service-a.licenseHigh","value":"0"},` +
+		`{"name":"service-a.licenseMedium","value":"0"},` +
+		`{"name":"service-a.licenseLow","value":"0"},` +
+		`{"name":"service-a.operationalHigh","value":"0"},` +
+		`{"name":"service-a.operationalMedium","value":"0"},` +
+		`{"name":"service-a.operationalLow","value":"0"},` +
+		`{"name":"service-a.policyStatus","value":"IN_VIOLATION"},` +
+		`{"name":"service-a.status","value":"SUCCESS"},` +
+		`{"name":"service-b.name","value":"accountant"},` +
+		`{"name":"service-b.version","value":"Default Detect Version"},` +
+		`{"name":"service-b.url","value":"https://my.Host/api/projects/01883e41-d4c9-420a-b41b-0ddcaadda2b5/versions/509ce50d-b7a2-4303-89bf-bde16e4b7bef/components"},` +
+		`{"name":"service-b.status","value":"FAILURE_DETECTOR"},` +
+		`{"name":"detectVersion","value":"5.4.99"}]}`
+	if stdOut.String() != expRes {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+	if !strings.Contains(stdErr.String(), "[service-a] done\n") || !strings.Contains(stdErr.String(), "[service-b] done\n") {
+		t.Errorf("Expected the logs to be prefixed with the directory, but got %v", stdErr.String())
+	}
+	if fakeBlackduckApi.GetRiskProfileCallCount() != 1 {
+		t.Errorf("Expected the risk of the successful scan only, but was fetched %v times", fakeBlackduckApi.GetRiskProfileCallCount())
+	}
+	if source := fakeBlackduckApi.GetProjectByNameArgsForCall(0); source.Name != "presentations" {
+		t.Errorf("Expected the risk of the scanned project, but got %v", source.Name)
+	}
+}
+
+func TestPrintsPercentSignsOfTheAggregatedResults(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {"directories": [{ "path": "my%20service" }]}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   &bytes.Buffer{},
+		path:     prepareBuildDir(t, "my%20service"),
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			return exec.Command("echo", "--- Overall Status: FAILURE_DETECTOR")
+		},
+	}
+
+	if err := r.run(); err == nil {
+		t.Error("Should have errored")
+	}
+	if !strings.Contains(stdOut.String(), `{"name":"my%20service.status","value":"FAILURE_DETECTOR"}`) {
+		t.Errorf("Expected the path to be printed as it is, but got %v", stdOut.String())
+	}
+}
+
+func TestLimitsTheNumberOfConcurrentScans(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"concurrency": 1,
+				"directories": [{ "path": "a" }, { "path": "b" }, { "path": "c" }]
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	buildDir := prepareBuildDir(t, "a", "b", "c")
+	markers := filepath.Join(buildDir, "markers")
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		path:     buildDir,
+		agentDir: dir,
+		api:      &sharedfakes.FakeBlackduckApi{},
+		exec: func(name string, arg ...string) *exec.Cmd {
+			return exec.Command("sh", "-c", `echo start >> "$1"; sleep 0.05; echo end >> "$1"`, "sh", markers)
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	b, err := ioutil.ReadFile(markers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "start\nend\nstart\nend\nstart\nend\n" {
+		t.Errorf("Expected the scans to run one after another, but got %v", string(b))
+	}
+}
+
+func TestScansImagesWithTheDockerInspector(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"image_tar": "image/image.tar",
+				"image_ref": "registry.example.com/app:1.0.0"
+			}
+		}`)
+
+	buildDir := prepareBuildDir(t, "image")
+	if err := ioutil.WriteFile(filepath.Join(buildDir, "image", "image.tar"), []byte(""), 0644); err != nil {
+		t.Fatal(err)
+	}
+	digest := "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	if err := ioutil.WriteFile(filepath.Join(buildDir, "image", "digest"), []byte(digest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dir, _ := prepareMockAgentFile(t)
+	response, err := filepath.Abs("testdata/blackduckResponse.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	command := exec.Command("cat", response)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   &bytes.Buffer{},
+		path:     buildDir,
+		agentDir: dir,
+		api:      &sharedfakes.FakeBlackduckApi{},
+		exec: func(name string, arg ...string) *exec.Cmd {
+			expectedArgs := []string{
+				"--detect.docker.tar=" + filepath.Join(buildDir, "image", "image.tar"),
+				"--detect.tools=DOCKER,SIGNATURE_SCAN",
+			}
+			if !reflect.DeepEqual(arg[6:], expectedArgs) {
+				t.Errorf("Expected arguments %v, but got %v", expectedArgs, arg[6:])
+			}
+			return command
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	if command.Dir != buildDir+"/." {
+		t.Errorf("Expected the build dir to be the working dir, but was %v", command.Dir)
+	}
+	for _, meta := range []string{
+		`{"name":"imageRef","value":"registry.example.com/app:1.0.0"}`,
+		`{"name":"imageDigest","value":"` + digest + `"}`,
+	} {
+		if !strings.Contains(stdOut.String(), meta) {
+			t.Errorf("Expected %v in the metadata, but got %v", meta, stdOut.String())
+		}
+	}
+}
+
+func TestPullsImagesWhichAreOnlyReferenced(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"image_ref": "registry.example.com/app:1.0.0"
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	response, err := filepath.Abs("testdata/blackduckResponse.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   &bytes.Buffer{},
+		path:     t.TempDir(),
+		agentDir: dir,
+		api:      &sharedfakes.FakeBlackduckApi{},
+		exec: func(name string, arg ...string) *exec.Cmd {
+			expectedArgs := []string{
+				"--detect.docker.image=registry.example.com/app:1.0.0",
+				"--detect.tools=DOCKER,SIGNATURE_SCAN",
+			}
+			if !reflect.DeepEqual(arg[6:], expectedArgs) {
+				t.Errorf("Expected arguments %v, but got %v", expectedArgs, arg[6:])
+			}
+			return exec.Command("cat", response)
+		},
+		imageDigest: func(ref string) (string, error) {
+			if ref != "registry.example.com/app:1.0.0" {
+				t.Errorf("Expected the digest of the reference, but got %v", ref)
+			}
+			return digest, nil
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	if !strings.Contains(stdOut.String(), `{"name":"imageDigest","value":"`+digest+`"}`) {
+		t.Errorf("Expected the resolved digest in the metadata, but got %v", stdOut.String())
+	}
+}
+
+func TestOnlyLogsWhenTheDigestOfAReferenceCantBeResolved(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"image_ref": "registry.example.com/app:1.0.0"
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	response, err := filepath.Abs("testdata/blackduckResponse.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   stdErr,
+		path:     t.TempDir(),
+		agentDir: dir,
+		api:      &sharedfakes.FakeBlackduckApi{},
+		exec: func(name string, arg ...string) *exec.Cmd {
+			return exec.Command("cat", response)
+		},
+		imageDigest: func(ref string) (string, error) {
+			return "", errors.New("no docker daemon")
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	if strings.Contains(stdOut.String(), "imageDigest") {
+		t.Errorf("Expected no digest in the metadata, but got %v", stdOut.String())
+	}
+	if !strings.Contains(stdErr.String(), "Could not resolve the digest of registry.example.com/app:1.0.0: no docker daemon") {
+		t.Errorf("Expected the failure to be logged, but got %v", stdErr.String())
+	}
+}
+
+func TestUploadsArtifactsToTheBinaryScanner(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"artifacts": ["vendor/*.jar"],
+				"max_artifact_count": 5
+			}
+		}`)
+
+	buildDir := prepareBuildDir(t, "vendor")
+	if err := ioutil.WriteFile(filepath.Join(buildDir, "vendor", "lib.jar"), []byte("12345"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   &bytes.Buffer{},
+		path:     buildDir,
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			expectedArgs := []string{
+				"--detect.binary.scan.file.path=" + filepath.Join(buildDir, "vendor", "lib.jar"),
+				"--detect.tools=BINARY_SCAN",
+			}
+			if !reflect.DeepEqual(arg[6:], expectedArgs) {
+				t.Errorf("Expected arguments %v, but got %v", expectedArgs, arg[6:])
+			}
+			return exec.Command("true")
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	for _, meta := range []string{
+		`{"name":"artifacts","value":"vendor/lib.jar"}`,
+		`{"name":"artifactCount","value":"1"}`,
+		`{"name":"artifactBytes","value":"5"}`,
+		`{"name":"maxArtifactCount","value":"5"}`,
+		`{"name":"maxArtifactBytes","value":"5368709120"}`,
+	} {
+		if !strings.Contains(stdOut.String(), meta) {
+			t.Errorf("Expected %v in the metadata, but got %v", meta, stdOut.String())
+		}
+	}
+}
+
+func TestRunsRapidScansWithoutPersistingTheResults(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": ".",
+				"scan_mode": "rapid",
+				"fail_on_severities": ["BLOCKER", "CRITICAL"]
+			}
+		}`)
+
+	result, err := filepath.Abs("rapid/testdata/result.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   stdErr,
+		path:     t.TempDir(),
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			var outputDir string
+			for _, a := range arg {
+				if strings.HasPrefix(a, "--detect.output.path=") {
+					outputDir = strings.TrimPrefix(a, "--detect.output.path=")
+				}
+			}
+			expectedArgs := []string{
+				"--detect.policy.check.fail.on.severities=BLOCKER,CRITICAL",
+				"--detect.blackduck.scan.mode=RAPID",
+				"--detect.output.path=" + outputDir,
+			}
+			if !reflect.DeepEqual(arg[6:], expectedArgs) {
+				t.Errorf("Expected arguments %v, but got %v", expectedArgs, arg[6:])
+			}
+			return exec.Command("sh", "-c", `mkdir -p "$1/runs/1/scan" && cp "$2" "$1/runs/1/scan/project1_BlackDuck_DeveloperMode_Result.json" && `+
+				`echo "--- Project name: project1" && echo "--- Overall Status: FAILURE_POLICY_VIOLATION" && exit 3`, "sh", outputDir, result)
+		},
+	}
+
+	errMsg := "FAILURE_POLICY_VIOLATION"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+	if !strings.Contains(stdErr.String(), "Rapid scan found policy violations in 2 of 3 components:") {
+		t.Errorf("Expected a summary of the violations, but got %v", stdErr.String())
+	}
+	expRes := `{"version":{"ref":"rapid-[0-9a-f]{64}"},"metadata":\[` +
+		`{"name":"name","value":"project1"},` +
+		`{"name":"scanMode","value":"RAPID"},` +
+		`{"name":"components","value":"3"},` +
+		`{"name":"violations","value":"2"},` +
+		`{"name":"detectVersion","value":"5.4.99"}\]}`
+	if !regexp.MustCompile("^" + expRes + "$").MatchString(stdOut.String()) {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+}
+
+func TestKeepsTheScanModeAndOutputOfRapidScans(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directories": [{
+					"path": ".",
+					"properties": {"detect.blackduck.scan.mode": "INTELLIGENT", "detect.output.path": "/tmp/elsewhere"}
+				}],
+				"scan_mode": "rapid"
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	var args []string
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		path:     t.TempDir(),
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			args = arg
+			return exec.Command("true")
+		},
+	}
+
+	_ = r.run()
+	rapid := false
+	for _, arg := range args {
+		switch {
+		case arg == "--detect.blackduck.scan.mode=RAPID":
+			rapid = true
+		case arg == "--detect.blackduck.scan.mode=INTELLIGENT", arg == "--detect.output.path=/tmp/elsewhere":
+			t.Errorf("Expected %v to be overridden, but got %v", arg, args)
+		}
+	}
+	if !rapid {
+		t.Errorf("Expected a rapid scan, but got %v", args)
+	}
+}
+
+func TestWritesTheViolationsOfRapidScansAsJunit(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": "source-code",
+				"scan_mode": "rapid",
+				"junit_file": "reports/junit.xml"
+			}
+		}`)
+
+	result, err := filepath.Abs("rapid/testdata/result.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, _ := prepareMockAgentFile(t)
+	buildDir := prepareBuildDir(t, "source-code")
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		path:     buildDir,
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			var outputDir string
+			for _, a := range arg {
+				if strings.HasPrefix(a, "--detect.output.path=") {
+					outputDir = strings.TrimPrefix(a, "--detect.output.path=")
+				}
+			}
+			return exec.Command("sh", "-c", `mkdir -p "$1/runs/1/scan" && cp "$2" "$1/runs/1/scan/project1_BlackDuck_DeveloperMode_Result.json" && `+
+				`echo "--- Project name: project1" && echo "--- Overall Status: SUCCESS"`, "sh", outputDir, result)
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(buildDir, "reports", "junit.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<testsuites name="source-code" tests="6" failures="2" skipped="0">`,
+		`<testsuite name="No Copyleft" tests="3" failures="1" skipped="0">`,
+		`<failure message="Apache Log4j 2.14.1 violates No Critical Vulnerabilities" type="PolicyViolation"></failure>`,
+	} {
+		if !strings.Contains(string(b), expected) {
+			t.Errorf("Expected %v in %v", expected, string(b))
+		}
+	}
+}
+
+func TestWritesThePolicyComplianceOfIntelligentScansAsJunit(t *testing.T) {
+	for _, status := range []string{"SUCCESS", "FAILURE_POLICY_VIOLATION"} {
+		stdIn := &bytes.Buffer{}
+		stdIn.WriteString(`{
+				"source": {
+					"url": "https://BLACKDUCK",
+					"username": "username",
+					"password": "password",
+					"name": "project1"
+				},
+				"params": {
+					"directory": "source-code",
+					"junit_file": "reports/junit.xml"
+				}
+			}`)
+
+		dir, _ := prepareMockAgentFile(t)
+		buildDir := prepareBuildDir(t, "source-code")
+		fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+		fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1"}, nil)
+		fakeBlackduckApi.GetProjectVersionsReturns([]shared.Version{{Name: "0.9.0"}, {Name: "1.0.0"}}, nil)
+		fakeBlackduckApi.GetBomComponentsReturns([]shared.BomComponent{
+			{ComponentName: "log4j", ComponentVersionName: "2.14.1", PolicyStatus: shared.PolicyStatusInViolation},
+			{ComponentName: "guava", ComponentVersionName: "30.0", PolicyStatus: "NOT_IN_VIOLATION"},
+		}, nil)
+		fakeBlackduckApi.GetPolicyRulesReturns([]shared.PolicyRule{{Name: "No Critical Vulnerabilities", Enabled: true}}, nil)
+		fakeBlackduckApi.GetComponentPolicyRulesReturns([]shared.PolicyRule{{Name: "No Critical Vulnerabilities"}}, nil)
+		r := Runner{
+			stdIn:    stdIn,
+			stdOut:   &bytes.Buffer{},
+			stdErr:   &bytes.Buffer{},
+			path:     buildDir,
+			agentDir: dir,
+			api:      fakeBlackduckApi,
+			exec: func(name string, arg ...string) *exec.Cmd {
+				return exec.Command("sh", "-c", `echo "--- Project name: project1" && echo "--- Project version: 1.0.0" && echo "--- Overall Status: $1"`, "sh", status)
+			},
+		}
+
+		err := r.run()
+		if status == "SUCCESS" && err != nil {
+			t.Fatal(err)
+		}
+		if status != "SUCCESS" && (err == nil || err.Error() != status) {
+			t.Errorf("Should have errored with %v, but was %v", status, err)
+		}
+		if _, version := fakeBlackduckApi.GetBomComponentsArgsForCall(0); version.Name != "1.0.0" {
+			t.Errorf("Expected the BOM of the scanned version, but got %v", version.Name)
+		}
+		b, err := ioutil.ReadFile(filepath.Join(buildDir, "reports", "junit.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			`<testsuites name="1.0.0" tests="2" failures="1" skipped="0">`,
+			`<failure message="log4j 2.14.1 violates No Critical Vulnerabilities" type="PolicyViolation"></failure>`,
+		} {
+			if !strings.Contains(string(b), expected) {
+				t.Errorf("Expected %v in %v", expected, string(b))
+			}
+		}
+	}
+}
+
+func TestCollectsTheBdioFilesOfOfflineScans(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directory": ".",
+				"offline": true,
+				"bdio_directory": "output/bdio"
+			}
+		}`)
+
+	buildDir := t.TempDir()
+	dir, _ := prepareMockAgentFile(t)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   stdOut,
+		stdErr:   &bytes.Buffer{},
+		path:     buildDir,
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			var outputDir string
+			for _, a := range arg {
+				if strings.HasPrefix(a, "--detect.output.path=") {
+					outputDir = strings.TrimPrefix(a, "--detect.output.path=")
+				}
+			}
+			expectedArgs := []string{
+				"--blackduck.offline.mode=true",
+				"--detect.output.path=" + outputDir,
+			}
+			if !reflect.DeepEqual(arg[6:], expectedArgs) {
+				t.Errorf("Expected arguments %v, but got %v", expectedArgs, arg[6:])
+			}
+			return exec.Command("sh", "-c", `mkdir -p "$1/runs/1/bdio" && echo "[]" > "$1/runs/1/bdio/project1.jsonld" && `+
+				`echo "--- Project name: project1" && echo "--- Overall Status: SUCCESS"`, "sh", outputDir)
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(buildDir, "output/bdio/runs/1/bdio/project1.jsonld")); err != nil {
+		t.Errorf("Expected the BDIO file to be collected, but got %v", err)
+	}
+	expRes := `{"version":{"ref":"offline-[0-9a-f]{64}"},"metadata":\[` +
+		`{"name":"name","value":"project1"},` +
+		`{"name":"offline","value":"true"},` +
+		`{"name":"files","value":"runs/1/bdio/project1.jsonld"},` +
+		`{"name":"detectVersion","value":"5.4.99"}\]}`
+	if !regexp.MustCompile("^" + expRes + "$").MatchString(stdOut.String()) {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+}
+
+func TestKeepsTheModeAndOutputOfOfflineScans(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"directories": [{
+					"path": ".",
+					"properties": {"blackduck.offline.mode": "false", "detect.output.path": "/tmp/elsewhere"}
+				}],
+				"offline": true,
+				"bdio_directory": "output/bdio"
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	var args []string
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		path:     t.TempDir(),
+		agentDir: dir,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			args = arg
+			return exec.Command("true")
+		},
+	}
+
+	_ = r.run()
+	offline := false
+	for _, arg := range args {
+		switch {
+		case arg == "--blackduck.offline.mode=true":
+			offline = true
+		case arg == "--blackduck.offline.mode=false", arg == "--detect.output.path=/tmp/elsewhere":
+			t.Errorf("Expected %v to be overridden, but got %v", arg, args)
+		}
+	}
+	if !offline {
+		t.Errorf("Expected an offline scan, but got %v", args)
+	}
+}
+
+func TestUploadsBdioFilesWithoutRunningDetect(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"action": "upload_bdio",
+				"bdio_directory": "offline-scan",
+				"version_name": "1.0.0"
+			}
+		}`)
+
+	buildDir := prepareBuildDir(t, "offline-scan/runs/1/bdio")
+	file := filepath.Join(buildDir, "offline-scan/runs/1/bdio/project1.jsonld")
+	if err := ioutil.WriteFile(file, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1"}, nil)
+	fakeBlackduckApi.GetProjectVersionsReturns([]shared.Version{{Name: "0.9.0"}, {Name: "1.0.0"}}, nil)
+	fakeBlackduckApi.UploadBdioReturns("project1 bom", nil)
+	before := time.Now()
+	fakeBlackduckApi.GetCodeLocationsReturnsOnCall(0, []shared.CodeLocation{{Name: "project1 bom", Updated: before}}, nil)
+	fakeBlackduckApi.GetCodeLocationsReturnsOnCall(1, []shared.CodeLocation{{Name: "project1 bom", Updated: before}}, nil)
+	fakeBlackduckApi.GetCodeLocationsReturnsOnCall(2, []shared.CodeLocation{{Name: "project1 bom", Updated: before.Add(time.Minute)}}, nil)
+	r := Runner{
+		stdIn:  stdIn,
+		stdOut: stdOut,
+		stdErr: &bytes.Buffer{},
+		path:   buildDir,
+		api:    fakeBlackduckApi,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			t.Error("Should not have started Detect")
+			return exec.Command("true")
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	if fakeBlackduckApi.UploadBdioCallCount() != 1 {
+		t.Fatalf("Expected one upload, but got %v", fakeBlackduckApi.UploadBdioCallCount())
+	}
+	source, uploaded := fakeBlackduckApi.UploadBdioArgsForCall(0)
+	if source.Name != "project1" || uploaded != file {
+		t.Errorf("Expected %v to be uploaded to project1, but was %v to %v", file, uploaded, source.Name)
+	}
+	if fakeBlackduckApi.GetCodeLocationsCallCount() != 3 {
+		t.Errorf("Expected to wait until the code location was updated, but polled %v times", fakeBlackduckApi.GetCodeLocationsCallCount())
+	}
+	if _, version := fakeBlackduckApi.GetCodeLocationsArgsForCall(0); version.Name != "1.0.0" {
+		t.Errorf("Expected the code locations of 1.0.0, but got %v", version.Name)
+	}
+	expRes := `{"version":{"ref":"upload-[0-9a-f]{64}"},"metadata":\[` +
+		`{"name":"uploaded","value":"offline-scan/runs/1/bdio/project1.jsonld"},` +
+		`{"name":"codeLocation","value":"project1 bom"}\]}`
+	if !regexp.MustCompile("^" + expRes + "$").MatchString(stdOut.String()) {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+}
+
+func TestWaitsForTheCodeLocationsTheBdioFilesAreMergedInto(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"action": "upload_bdio",
+				"bdio_directory": ".",
+				"version_name": "1.0.0"
+			}
+		}`)
+
+	buildDir := t.TempDir()
+	for _, name := range []string{"project1.jsonld", "project1-2.jsonld"} {
+		if err := ioutil.WriteFile(filepath.Join(buildDir, name), []byte("[]"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1"}, nil)
+	fakeBlackduckApi.GetProjectVersionsReturns([]shared.Version{{Name: "1.0.0"}}, nil)
+	fakeBlackduckApi.UploadBdioReturns("project1 bom", nil)
+	before := time.Now()
+	fakeBlackduckApi.GetCodeLocationsReturnsOnCall(0, []shared.CodeLocation{{Name: "other bom", Updated: before}}, nil)
+	fakeBlackduckApi.GetCodeLocationsReturnsOnCall(1, []shared.CodeLocation{{Name: "other bom", Updated: before.Add(time.Minute)}}, nil)
+	fakeBlackduckApi.GetCodeLocationsReturnsOnCall(2, []shared.CodeLocation{
+		{Name: "other bom", Updated: before.Add(time.Minute)},
+		{Name: "project1 bom", Updated: before.Add(time.Minute)},
+	}, nil)
+	r := Runner{
+		stdIn:  stdIn,
+		stdOut: stdOut,
+		stdErr: &bytes.Buffer{},
+		path:   buildDir,
+		api:    fakeBlackduckApi,
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	if fakeBlackduckApi.GetCodeLocationsCallCount() != 3 {
+		t.Errorf("Expected to wait for the uploaded code location, but polled %v times", fakeBlackduckApi.GetCodeLocationsCallCount())
+	}
+	if !strings.HasSuffix(stdOut.String(), `{"name":"codeLocation","value":"project1 bom"}]}`) {
+		t.Errorf("Expected only the uploaded code location, but got %v", stdOut.String())
+	}
+}
+
+func TestErrorsWhenTheBdioUploadFails(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"action": "upload_bdio",
+				"bdio_directory": ".",
+				"version_name": "1.0.0"
+			}
+		}`)
+
+	buildDir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(buildDir, "project1.jsonld"), []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.UploadBdioReturns("", errors.New("something bad"))
+	r := Runner{
+		stdIn:  stdIn,
+		stdOut: stdOut,
+		stdErr: &bytes.Buffer{},
+		path:   buildDir,
+		api:    fakeBlackduckApi,
+	}
+
+	if err := r.run(); err == nil || err.Error() != "something bad" {
+		t.Errorf("Should have errored with something bad, but was %v", err)
+	}
+	if stdOut.Len() != 0 {
+		t.Errorf("Expected no version to be emitted, but got %v", stdOut.String())
+	}
+}
+
+func TestErrorsWhenTheCodeLocationsAreNotMappedInTime(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"action": "upload_bdio",
+				"bdio_directory": ".",
+				"version_name": "1.0.0",
+				"timeout": "10ms"
+			}
+		}`)
+
+	buildDir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(buildDir, "project1.jsonld"), []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(nil, errors.New("no project matching the name"))
+	fakeBlackduckApi.UploadBdioReturns("project1 bom", nil)
+	r := Runner{
+		stdIn:        stdIn,
+		stdOut:       stdOut,
+		stdErr:       &bytes.Buffer{},
+		path:         buildDir,
+		pollInterval: time.Millisecond,
+		api:          fakeBlackduckApi,
+	}
+
+	expErr := "code locations weren't mapped to version 1.0.0 within 10ms: no project matching the name"
+	if err := r.run(); err == nil || err.Error() != expErr {
+		t.Errorf("Should have errored with %v, but was %v", expErr, err)
+	}
+	if fakeBlackduckApi.UploadBdioCallCount() != 1 {
+		t.Errorf("Expected one upload, but got %v", fakeBlackduckApi.UploadBdioCallCount())
+	}
+	if stdOut.Len() != 0 {
+		t.Errorf("Expected no version to be emitted, but got %v", stdOut.String())
+	}
+}
+
+func TestStopsWaitingForTheCodeLocationsOnSignals(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdOut := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1"
+  			},
+			"params": {
+				"action": "upload_bdio",
+				"bdio_directory": ".",
+				"version_name": "1.0.0"
+			}
+		}`)
+
+	buildDir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(buildDir, "project1.jsonld"), []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	signals := make(chan os.Signal, 1)
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(nil, errors.New("no project matching the name"))
+	fakeBlackduckApi.UploadBdioStub = func(source shared.Source, file string) (string, error) {
+		signals <- syscall.SIGTERM
+		return "project1 bom", nil
+	}
+	r := Runner{
+		stdIn:        stdIn,
+		stdOut:       stdOut,
+		stdErr:       &bytes.Buffer{},
+		path:         buildDir,
+		signals:      signals,
+		pollInterval: time.Hour,
+		api:          fakeBlackduckApi,
+	}
+
+	errMsg := "scan was interrupted"
+	if err := r.run(); err == nil || err.Error() != errMsg {
+		t.Errorf("Should have errored with %v, but was %v", errMsg, err)
+	}
+	if stdOut.Len() != 0 {
+		t.Errorf("Expected no version to be emitted, but got %v", stdOut.String())
+	}
+}
+
+func TestCreatesTheProjectAndVersionWithTheirSettingsBeforeScanning(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1",
+				"project": {"description": "description", "tier": 2, "group": "team", "clone_categories": ["VULN_DATA"]},
+				"version": {"phase": "RELEASED", "release_date": "2020-01-31"}
+  			},
+			"params": {
+				"directory": ".",
+				"version_name": "1.0.0"
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(nil, fmt.Errorf("GetProjectByName: %w", shared.ErrProjectNotFound))
+	createdProject := &shared.Project{Name: "project1"}
+	fakeBlackduckApi.CreateProjectReturns(createdProject, nil)
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		agentDir: dir,
+		api:      fakeBlackduckApi,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			if fakeBlackduckApi.CreateVersionCallCount() != 1 {
+				t.Error("Expected the version to be created before scanning")
+			}
+			if !strings.Contains(strings.Join(arg, " "), "--detect.project.version.name=1.0.0") {
+				t.Errorf("Expected the version to be scanned, but got %v", arg)
+			}
+			return exec.Command("echo", "--- Overall Status: SUCCESS")
+		},
+	}
+
+	if err := r.run(); err != nil {
+		t.Error(err)
+	}
+	_, project := fakeBlackduckApi.CreateProjectArgsForCall(0)
+	expProject := shared.Project{Name: "project1", Description: "description", ProjectTier: 2, ProjectGroup: "team", CloneCategories: []string{"VULN_DATA"}}
+	if !reflect.DeepEqual(project, expProject) {
+		t.Errorf("Expected project %v, but got %v", expProject, project)
+	}
+	_, inProject, version := fakeBlackduckApi.CreateVersionArgsForCall(0)
+	if inProject != createdProject {
+		t.Error("Expected the version to be created in the new project")
+	}
+	expVersion := shared.Version{Name: "1.0.0", Phase: "RELEASED", Distribution: "EXTERNAL", ReleasedOn: "2020-01-31T00:00:00.000Z"}
+	if !reflect.DeepEqual(version, expVersion) {
+		t.Errorf("Expected version %v, but got %v", expVersion, version)
+	}
+}
+
+func TestUpdatesExistingVersionsOnlyWhenTheyDiffer(t *testing.T) {
+	for _, phase := range []string{"DEVELOPMENT", "RELEASED"} {
+		stdIn := &bytes.Buffer{}
+		stdIn.WriteString(`{
+				"source": {
+					"url": "https://BLACKDUCK",
+					"username": "username",
+					"password": "password",
+					"name": "project1",
+					"version": {"phase": "RELEASED"}
+				},
+				"params": {
+					"directory": ".",
+					"version_name": "1.0.0"
+				}
+			}`)
+
+		dir, _ := prepareMockAgentFile(t)
+		fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+		fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1"}, nil)
+		fakeBlackduckApi.GetProjectVersionsReturns([]shared.Version{
+			{Name: "0.9.0", Phase: "DEVELOPMENT"},
+			{Name: "1.0.0", Phase: phase, Distribution: "EXTERNAL"},
+		}, nil)
+		r := Runner{
+			stdIn:    stdIn,
+			stdOut:   &bytes.Buffer{},
+			stdErr:   &bytes.Buffer{},
+			agentDir: dir,
+			api:      fakeBlackduckApi,
+			exec: func(name string, arg ...string) *exec.Cmd {
+				return exec.Command("echo", "--- Overall Status: SUCCESS")
+			},
+		}
+
+		if err := r.run(); err != nil {
+			t.Error(err)
+		}
+		if fakeBlackduckApi.CreateProjectCallCount() != 0 || fakeBlackduckApi.CreateVersionCallCount() != 0 {
+			t.Error("Should not have created anything")
+		}
+		if phase == "RELEASED" {
+			if fakeBlackduckApi.UpdateVersionCallCount() != 0 {
+				t.Error("Should not have updated a matching version")
+			}
+			continue
+		}
+		_, version := fakeBlackduckApi.UpdateVersionArgsForCall(0)
+		if version.Name != "1.0.0" || version.Phase != "RELEASED" || version.Distribution != "EXTERNAL" {
+			t.Errorf("Expected 1.0.0 to be released, but got %v", version)
+		}
+	}
+}
+
+func TestUpdatesExistingProjectsOnlyWhenTheyDiffer(t *testing.T) {
+	for _, tier := range []int{1, 2} {
+		stdIn := &bytes.Buffer{}
+		stdIn.WriteString(`{
+				"source": {
+					"url": "https://BLACKDUCK",
+					"username": "username",
+					"password": "password",
+					"name": "project1",
+					"project": {"tier": 2, "group": "team"}
+				},
+				"params": {
+					"directory": "."
+				}
+			}`)
+
+		dir, _ := prepareMockAgentFile(t)
+		fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+		fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{
+			Name:         "project1",
+			ProjectTier:  tier,
+			ProjectGroup: "https://BLACKDUCK/api/project-groups/1",
+		}, nil)
+		fakeBlackduckApi.GetProjectGroupUrlReturns("https://BLACKDUCK/api/project-groups/1", nil)
+		r := Runner{
+			stdIn:    stdIn,
+			stdOut:   &bytes.Buffer{},
+			stdErr:   &bytes.Buffer{},
+			agentDir: dir,
+			api:      fakeBlackduckApi,
+			exec: func(name string, arg ...string) *exec.Cmd {
+				return exec.Command("echo", "--- Overall Status: SUCCESS")
+			},
+		}
+
+		if err := r.run(); err != nil {
+			t.Error(err)
+		}
+		if fakeBlackduckApi.CreateProjectCallCount() != 0 {
+			t.Error("Should not have created the project")
+		}
+		if _, name := fakeBlackduckApi.GetProjectGroupUrlArgsForCall(0); name != "team" {
+			t.Errorf("Expected the group to be looked up, but got %v", name)
+		}
+		if tier == 2 {
+			if fakeBlackduckApi.UpdateProjectCallCount() != 0 {
+				t.Error("Should not have updated a matching project")
+			}
+			continue
+		}
+		_, project := fakeBlackduckApi.UpdateProjectArgsForCall(0)
+		expProject := shared.Project{Name: "project1", ProjectTier: 2, ProjectGroup: "https://BLACKDUCK/api/project-groups/1"}
+		if !reflect.DeepEqual(project, expProject) {
+			t.Errorf("Expected project %v, but got %v", expProject, project)
+		}
+	}
+}
+
+func TestDoesNotScanWhenTheProjectCouldNotBePrepared(t *testing.T) {
+	stdIn := &bytes.Buffer{}
+	stdIn.WriteString(`{
+			"source": {
+    			"url": "https://BLACKDUCK",
+				"username": "username",
+    			"password": "password",
+				"name": "project1",
+				"project": {"tier": 1}
+  			},
+			"params": {
+				"directory": "."
+			}
+		}`)
+
+	dir, _ := prepareMockAgentFile(t)
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(nil, errors.New("something bad"))
+	r := Runner{
+		stdIn:    stdIn,
+		stdOut:   &bytes.Buffer{},
+		stdErr:   &bytes.Buffer{},
+		agentDir: dir,
+		api:      fakeBlackduckApi,
+		exec: func(name string, arg ...string) *exec.Cmd {
+			t.Error("Should not have started Detect")
+			return exec.Command("true")
+		},
+	}
+
+	if err := r.run(); err == nil || err.Error() != "something bad" {
+		t.Errorf("Should have errored with something bad, but was %v", err)
+	}
+	if fakeBlackduckApi.CreateProjectCallCount() != 0 {
+		t.Error("Should not have created a project on errors")
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/out/interpreter"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"strconv"
+)
+
+// setPhase updates the phase and distribution of a version without scanning, e.g. when a release was shipped.
+// The version is found by its name or by the ref, which was emitted by check.
+func (r *Runner) setPhase(input shared.Request) error {
+	project, err := r.api.GetProjectByName(input.Source)
+	if err != nil {
+		return err
+	}
+	versions, err := r.api.GetProjectVersions(input.Source, project)
+	if err != nil {
+		return err
+	}
+	version, err := findVersion(versions, input.Params)
+	if err != nil {
+		return err
+	}
+
+	settings := input.Params.GetVersionSettings()
+	previous := version
+	changed := settings.Apply(&version)
+	if changed {
+		fmt.Fprintf(r.stdErr, "Updating version %v from %v/%v to %v/%v\n",
+			version.Name, previous.Phase, previous.Distribution, version.Phase, version.Distribution)
+		if err := r.api.UpdateVersion(input.Source, version); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(r.stdErr, "Version %v is already %v/%v\n", version.Name, version.Phase, version.Distribution)
+	}
+
+	response := interpreter.Response{
+		Id: shared.Ref{Ref: fmt.Sprintf("%v-%v-%v", version.Name, version.Phase, version.Distribution)},
+		MetaData: []interpreter.MetaData{
+			{Name: "name", Value: input.Source.Name},
+			{Name: "version", Value: version.Name},
+			{Name: "phase", Value: version.Phase},
+			{Name: "distribution", Value: version.Distribution},
+			{Name: "changed", Value: strconv.FormatBool(changed)},
+		},
+	}
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	_, err = r.stdOut.Write(b)
+	return err
+}
+
+func findVersion(versions []shared.Version, params shared.Params) (shared.Version, error) {
+	for _, version := range versions {
+		if len(params.VersionName) != 0 && version.Name == params.VersionName {
+			return version, nil
+		}
+		if len(params.VersionName) == 0 && version.Updated.String() == params.VersionRef {
+			return version, nil
+		}
+	}
+	if len(params.VersionName) != 0 {
+		return shared.Version{}, fmt.Errorf("could not find version %v", params.VersionName)
+	}
+	return shared.Version{}, fmt.Errorf("could not find a version with ref %v", params.VersionRef)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"testing"
+	"time"
+)
+
+var phaseUpdated = time.Date(2019, 4, 20, 9, 12, 48, 0, time.UTC)
+
+var phaseVersions = []shared.Version{
+	{Name: "0.9.0", Phase: "RELEASED", Distribution: "EXTERNAL", Updated: phaseUpdated.Add(-time.Hour)},
+	{Name: "1.0.0", Phase: "DEVELOPMENT", Distribution: "EXTERNAL", Updated: phaseUpdated},
+}
+
+func TestSetsThePhaseOfAVersionByName(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, request("", `{"action": "set_phase", "version_name": "1.0.0", "phase": "RELEASED", "distribution": "SAAS"}`), phaseVersions...)
+	stdOut := &bytes.Buffer{}
+	r.stdOut = stdOut
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	_, version := fakeBlackduckApi.UpdateVersionArgsForCall(0)
+	if version.Name != "1.0.0" || version.Phase != "RELEASED" || version.Distribution != "SAAS" {
+		t.Errorf("Expected 1.0.0 to be RELEASED/SAAS, but got %v", version)
+	}
+	expRes := `{"version":{"ref":"1.0.0-RELEASED-SAAS"},"metadata":[` +
+		`{"name":"name","value":"project1"},` +
+		`{"name":"version","value":"1.0.0"},` +
+		`{"name":"phase","value":"RELEASED"},` +
+		`{"name":"distribution","value":"SAAS"},` +
+		`{"name":"changed","value":"true"}]}`
+	if stdOut.String() != expRes {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+}
+
+func TestSetsThePhaseOfAVersionByTheRefOfCheck(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, request("", `{"action": "set_phase", "version_ref": "`+phaseUpdated.String()+`", "phase": "RELEASED"}`), phaseVersions...)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	_, version := fakeBlackduckApi.UpdateVersionArgsForCall(0)
+	if version.Name != "1.0.0" || version.Phase != "RELEASED" || version.Distribution != "EXTERNAL" {
+		t.Errorf("Expected 1.0.0 to be RELEASED/EXTERNAL, but got %v", version)
+	}
+}
+
+func TestDoesNotUpdateVersionsInTheirPhase(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, request("", `{"action": "set_phase", "version_name": "0.9.0", "phase": "RELEASED"}`), phaseVersions...)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.UpdateVersionCallCount() != 0 {
+		t.Error("Should not have updated the version")
+	}
+}
+
+func TestErrorsWhenTheVersionToSetThePhaseOfIsMissing(t *testing.T) {
+	r, _ := prepareRunner(t, request("", `{"action": "set_phase", "version_ref": "unknown", "phase": "RELEASED"}`), phaseVersions...)
+
+	if err := r.run(); err == nil || err.Error() != "could not find a version with ref unknown" {
+		t.Errorf("Should have errored with could not find a version with ref unknown, but was %v", err)
+	}
+}
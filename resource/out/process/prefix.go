@@ -0,0 +1,55 @@
+package process
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixWriter prefixes every line, so that the output of concurrent processes can be told apart.
+// Only complete lines are forwarded, so that lines of different processes don't get mixed up.
+type PrefixWriter struct {
+	mutex  sync.Mutex
+	out    io.Writer
+	prefix []byte
+	buf    bytes.Buffer
+}
+
+func NewPrefixWriter(out io.Writer, prefix string) *PrefixWriter {
+	return &PrefixWriter{
+		out:    out,
+		prefix: []byte(prefix),
+	}
+}
+
+func (w *PrefixWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buf.Write(p)
+	for {
+		end := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if end < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf.Next(end + 1)); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush writes out a trailing line, which wasn't terminated by a newline yet.
+func (w *PrefixWriter) Flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	line := append(w.buf.Bytes(), '\n')
+	w.buf.Reset()
+	return w.writeLine(line)
+}
+
+func (w *PrefixWriter) writeLine(line []byte) error {
+	_, err := w.out.Write(append(append([]byte{}, w.prefix...), line...))
+	return err
+}
@@ -0,0 +1,28 @@
+package process_test
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/out/process"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPrefixesCompleteLines(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := process.NewPrefixWriter(out, "[a] ")
+	_, err := w.Write([]byte("first\nsec"))
+	require.NoError(t, err)
+	require.Equal(t, "[a] first\n", out.String())
+	_, err = w.Write([]byte("ond\nthird"))
+	require.NoError(t, err)
+	require.Equal(t, "[a] first\n[a] second\n", out.String())
+	require.NoError(t, w.Flush())
+	require.Equal(t, "[a] first\n[a] second\n[a] third\n", out.String())
+}
+
+func TestFlushesNothingWithoutPendingOutput(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := process.NewPrefixWriter(out, "[a] ")
+	require.NoError(t, w.Flush())
+	require.Empty(t, out.String())
+}
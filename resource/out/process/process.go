@@ -0,0 +1,96 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrTimeout     = errors.New("scan timed out")
+	ErrInterrupted = errors.New("scan was interrupted")
+)
+
+type signalKey struct{}
+
+type receivedSignal struct {
+	mutex  sync.Mutex
+	signal os.Signal
+}
+
+// WithInterrupt returns a context, which is cancelled by calling interrupt.
+// The signal passed to interrupt is forwarded to all processes started with the context.
+func WithInterrupt(parent context.Context) (ctx context.Context, interrupt func(os.Signal)) {
+	received := &receivedSignal{}
+	ctx, cancel := context.WithCancel(context.WithValue(parent, signalKey{}, received))
+	return ctx, func(signal os.Signal) {
+		received.mutex.Lock()
+		if received.signal == nil {
+			received.signal = signal
+		}
+		received.mutex.Unlock()
+		cancel()
+	}
+}
+
+// Run starts the command in its own process group and waits for it to finish.
+// When the context is done, the whole group is terminated and killed after the grace period,
+// so that no orphaned processes are left behind.
+func Run(ctx context.Context, cmd *exec.Cmd, grace time.Duration) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+	select {
+	case err := <-done:
+		return err
+	default:
+	}
+
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+	signal := syscall.SIGTERM
+	if received, ok := ctx.Value(signalKey{}).(*receivedSignal); ok && !timedOut {
+		received.mutex.Lock()
+		if s, ok := received.signal.(syscall.Signal); ok {
+			signal = s
+		}
+		received.mutex.Unlock()
+	}
+	signalGroup(cmd.Process.Pid, signal)
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		signalGroup(cmd.Process.Pid, syscall.SIGKILL)
+		<-done
+	}
+	if timedOut {
+		return ErrTimeout
+	}
+	return ErrInterrupted
+}
+
+func signalGroup(pid int, signal syscall.Signal) {
+	if err := syscall.Kill(-pid, signal); err != nil {
+		_ = syscall.Kill(pid, signal)
+	}
+}
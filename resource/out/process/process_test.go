@@ -0,0 +1,52 @@
+package process_test
+
+import (
+	"context"
+	"github.com/elgohr/concourse-blackduck/out/process"
+	"github.com/stretchr/testify/require"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunsTheCommandToCompletion(t *testing.T) {
+	err := process.Run(context.Background(), exec.Command("true"), time.Second)
+	require.NoError(t, err)
+}
+
+func TestReturnsTheErrorOfTheCommand(t *testing.T) {
+	err := process.Run(context.Background(), exec.Command("false"), time.Second)
+	require.EqualError(t, err, "exit status 1")
+}
+
+func TestTerminatesTheCommandOnTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := process.Run(ctx, exec.Command("sleep", "10"), 5*time.Second)
+	require.Equal(t, process.ErrTimeout, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestKillsTheCommandAfterTheGracePeriod(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	cmd := exec.Command("sh", "-c", `trap "" TERM; sleep 10 & wait`)
+	start := time.Now()
+	err := process.Run(ctx, cmd, 100*time.Millisecond)
+	require.Equal(t, process.ErrTimeout, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestForwardsTheReceivedSignal(t *testing.T) {
+	ctx, interrupt := process.WithInterrupt(context.Background())
+	cmd := exec.Command("sh", "-c", `trap "exit 3" INT; sleep 10 & wait`)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		interrupt(syscall.SIGINT)
+	}()
+	err := process.Run(ctx, cmd, 5*time.Second)
+	require.Equal(t, process.ErrInterrupted, err)
+	require.Equal(t, 3, cmd.ProcessState.ExitCode())
+}
@@ -0,0 +1,250 @@
+package projectfile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Name of the file, which declares the project in a repository.
+const Name = "blackduck.yml"
+
+// File declares the settings of a project. Lists, which are declared, replace the current ones on Blackduck,
+// while settings and lists, which aren't declared, are left as they are.
+type File struct {
+	Project      Settings            `yaml:"project"`
+	UserGroups   []string            `yaml:"user_groups"`
+	Tags         []string            `yaml:"tags"`
+	CustomFields map[string][]string `yaml:"custom_fields"`
+}
+
+type Settings struct {
+	Description     string   `yaml:"description"`
+	Tier            int      `yaml:"tier"`
+	CloneCategories []string `yaml:"clone_categories"`
+}
+
+// State is the current state of a project on Blackduck.
+type State struct {
+	Exists       bool
+	Project      shared.Project
+	UserGroups   []string
+	Tags         []string
+	CustomFields map[string][]string
+}
+
+// Load reads the file and returns a digest of its content.
+// Unknown keys are rejected, so that typos don't silently leave settings unmanaged.
+func Load(file string) (File, string, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return File{}, "", err
+	}
+	var f File
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil {
+		return File{}, "", fmt.Errorf("%v is invalid: %w", file, err)
+	}
+	if f.Project.Tier < 0 || f.Project.Tier > 5 {
+		return File{}, "", fmt.Errorf("%v is invalid: tier %v is not between 1 and 5", file, f.Project.Tier)
+	}
+	hash := sha256.Sum256(content)
+	return f, hex.EncodeToString(hash[:]), nil
+}
+
+// Apply returns the project with the declared settings.
+func (f *File) Apply(project shared.Project) shared.Project {
+	if len(f.Project.Description) != 0 {
+		project.Description = f.Project.Description
+	}
+	if f.Project.Tier != 0 {
+		project.ProjectTier = f.Project.Tier
+	}
+	if f.Project.CloneCategories != nil {
+		project.CloneCategories = normalize(f.Project.CloneCategories)
+	}
+	return project
+}
+
+type Action string
+
+const (
+	Create Action = "+"
+	Update Action = "~"
+	Delete Action = "-"
+)
+
+const (
+	KindProject     = "project"
+	KindUserGroup   = "user_group"
+	KindTag         = "tag"
+	KindCustomField = "custom_field"
+)
+
+// Change of a single resource. Updates list the changed attributes.
+type Change struct {
+	Action     Action
+	Kind       string
+	Name       string
+	Attributes []Attribute
+}
+
+type Attribute struct {
+	Name string
+	From string
+	To   string
+}
+
+type Plan struct {
+	Changes []Change
+}
+
+// Diff plans the changes, which make the project on Blackduck match the file.
+func Diff(f File, state State) (Plan, error) {
+	var plan Plan
+	desired := f.Apply(state.Project)
+	if !state.Exists {
+		plan.add(Create, KindProject, state.Project.Name, projectAttributes(shared.Project{}, desired))
+	} else if attributes := projectAttributes(state.Project, desired); len(attributes) != 0 {
+		plan.add(Update, KindProject, state.Project.Name, attributes)
+	}
+	if f.UserGroups != nil {
+		plan.diffList(KindUserGroup, state.UserGroups, f.UserGroups)
+	}
+	if f.Tags != nil {
+		plan.diffList(KindTag, state.Tags, f.Tags)
+	}
+	labels := make([]string, 0, len(f.CustomFields))
+	for label := range f.CustomFields {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		current, ok := state.CustomFields[label]
+		if !ok && state.Exists {
+			return Plan{}, fmt.Errorf("custom field %v doesn't exist", label)
+		}
+		from, to := format(normalize(current)), format(normalize(f.CustomFields[label]))
+		if from != to {
+			plan.add(Update, KindCustomField, label, []Attribute{{Name: "values", From: from, To: to}})
+		}
+	}
+	return plan, nil
+}
+
+func (p *Plan) add(action Action, kind string, name string, attributes []Attribute) {
+	p.Changes = append(p.Changes, Change{Action: action, Kind: kind, Name: name, Attributes: attributes})
+}
+
+func (p *Plan) diffList(kind string, current []string, desired []string) {
+	current, desired = normalize(current), normalize(desired)
+	for _, name := range desired {
+		if !contains(current, name) {
+			p.add(Create, kind, name, nil)
+		}
+	}
+	for _, name := range current {
+		if !contains(desired, name) {
+			p.add(Delete, kind, name, nil)
+		}
+	}
+}
+
+func projectAttributes(current shared.Project, desired shared.Project) []Attribute {
+	var attributes []Attribute
+	compare := func(name string, from string, to string) {
+		if from != to {
+			attributes = append(attributes, Attribute{Name: name, From: from, To: to})
+		}
+	}
+	compare("description", strconv.Quote(current.Description), strconv.Quote(desired.Description))
+	compare("tier", strconv.Itoa(current.ProjectTier), strconv.Itoa(desired.ProjectTier))
+	compare("clone_categories", format(normalize(current.CloneCategories)), format(normalize(desired.CloneCategories)))
+	return attributes
+}
+
+// Counts returns the number of resources, which are added, changed and destroyed by the plan.
+func (p *Plan) Counts() (add int, change int, destroy int) {
+	for _, c := range p.Changes {
+		switch c.Action {
+		case Create:
+			add++
+		case Update:
+			change++
+		case Delete:
+			destroy++
+		}
+	}
+	return
+}
+
+// String renders the plan like terraform does.
+func (p *Plan) String() string {
+	if len(p.Changes) == 0 {
+		return "No changes. The project matches " + Name + ".\n"
+	}
+	var b strings.Builder
+	b.WriteString("Blackduck will perform the following actions:\n\n")
+	for _, c := range p.Changes {
+		fmt.Fprintf(&b, "  %v %v %q", c.Action, c.Kind, c.Name)
+		if len(c.Attributes) == 0 {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(" {\n")
+		width := 0
+		for _, a := range c.Attributes {
+			if len(a.Name) > width {
+				width = len(a.Name)
+			}
+		}
+		for _, a := range c.Attributes {
+			if c.Action == Create {
+				fmt.Fprintf(&b, "      + %-*v = %v\n", width, a.Name, a.To)
+			} else {
+				fmt.Fprintf(&b, "      ~ %-*v = %v -> %v\n", width, a.Name, a.From, a.To)
+			}
+		}
+		b.WriteString("    }\n")
+	}
+	add, change, destroy := p.Counts()
+	fmt.Fprintf(&b, "\nPlan: %v to add, %v to change, %v to destroy.\n", add, change, destroy)
+	return b.String()
+}
+
+// normalize sorts and de-duplicates the values, as their order isn't significant on Blackduck.
+func normalize(values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		if !contains(normalized, value) {
+			normalized = append(normalized, value)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func format(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package projectfile_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/out/projectfile"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+const declaration = `
+project:
+  description: Billing service
+  tier: 2
+  clone_categories: [VULN_DATA, COMPONENT_DATA]
+user_groups: [team-billing]
+tags: [java, billing]
+custom_fields:
+  Owner: [alice]
+`
+
+func TestLoadsTheDeclaration(t *testing.T) {
+	f, digest, err := projectfile.Load(writeFile(t, declaration))
+	require.NoError(t, err)
+	require.Len(t, digest, 64)
+	require.Equal(t, projectfile.File{
+		Project: projectfile.Settings{
+			Description:     "Billing service",
+			Tier:            2,
+			CloneCategories: []string{"VULN_DATA", "COMPONENT_DATA"},
+		},
+		UserGroups:   []string{"team-billing"},
+		Tags:         []string{"java", "billing"},
+		CustomFields: map[string][]string{"Owner": {"alice"}},
+	}, f)
+}
+
+func TestRejectsUnknownKeys(t *testing.T) {
+	file := writeFile(t, "tag: [java]\n")
+	_, _, err := projectfile.Load(file)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), file+" is invalid")
+}
+
+func TestRejectsInvalidTiers(t *testing.T) {
+	file := writeFile(t, "project: {tier: 6}\n")
+	_, _, err := projectfile.Load(file)
+	require.EqualError(t, err, file+" is invalid: tier 6 is not between 1 and 5")
+}
+
+func TestPlansTheCreationOfMissingProjects(t *testing.T) {
+	f, _, err := projectfile.Load(writeFile(t, declaration))
+	require.NoError(t, err)
+	plan, err := projectfile.Diff(f, projectfile.State{Project: shared.Project{Name: "billing"}})
+	require.NoError(t, err)
+	require.Equal(t, `Blackduck will perform the following actions:
+
+  + project "billing" {
+      + description      = "Billing service"
+      + tier             = 2
+      + clone_categories = ["COMPONENT_DATA", "VULN_DATA"]
+    }
+  + user_group "team-billing"
+  + tag "billing"
+  + tag "java"
+  ~ custom_field "Owner" {
+      ~ values = [] -> ["alice"]
+    }
+
+Plan: 4 to add, 1 to change, 0 to destroy.
+`, plan.String())
+}
+
+func TestPlansOnlyTheDifferences(t *testing.T) {
+	f, _, err := projectfile.Load(writeFile(t, declaration))
+	require.NoError(t, err)
+	plan, err := projectfile.Diff(f, projectfile.State{
+		Exists: true,
+		Project: shared.Project{
+			Name:            "billing",
+			Description:     "Billing",
+			ProjectTier:     2,
+			CloneCategories: []string{"COMPONENT_DATA", "VULN_DATA"},
+		},
+		UserGroups:   []string{"team-legacy", "team-billing"},
+		Tags:         []string{"java"},
+		CustomFields: map[string][]string{"Owner": {"alice"}, "Cost Center": {"42"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `Blackduck will perform the following actions:
+
+  ~ project "billing" {
+      ~ description = "Billing" -> "Billing service"
+    }
+  - user_group "team-legacy"
+  + tag "billing"
+
+Plan: 1 to add, 1 to change, 1 to destroy.
+`, plan.String())
+}
+
+func TestLeavesUndeclaredSettingsUntouched(t *testing.T) {
+	f, _, err := projectfile.Load(writeFile(t, "tags: [java]\n"))
+	require.NoError(t, err)
+	plan, err := projectfile.Diff(f, projectfile.State{
+		Exists:     true,
+		Project:    shared.Project{Name: "billing", Description: "Billing", ProjectTier: 3},
+		UserGroups: []string{"team-billing"},
+		Tags:       []string{"java", "java"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, plan.Changes)
+	require.Equal(t, "No changes. The project matches blackduck.yml.\n", plan.String())
+}
+
+func TestErrorsWhenACustomFieldDoesNotExist(t *testing.T) {
+	f, _, err := projectfile.Load(writeFile(t, "custom_fields: {Owner: [alice]}\n"))
+	require.NoError(t, err)
+	_, err = projectfile.Diff(f, projectfile.State{Exists: true, Project: shared.Project{Name: "billing"}})
+	require.EqualError(t, err, "custom field Owner doesn't exist")
+}
+
+func writeFile(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), projectfile.Name)
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+	return file
+}
@@ -0,0 +1,115 @@
+package rapid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const resultPattern = "runs/*/scan/*_BlackDuck_*_Result.json"
+
+var ErrNoResult = errors.New("could not find the result of the rapid scan")
+
+// Result is the rapid scan result file, which Detect writes into its output directory.
+type Result struct {
+	Components []Component
+	Digest     string
+}
+
+type Component struct {
+	Name                 string      `json:"componentName"`
+	Version              string      `json:"versionName"`
+	Identifier           string      `json:"componentIdentifier"`
+	ViolatingPolicyNames []string    `json:"violatingPolicyNames"`
+	Vulnerabilities      []Violation `json:"policyViolationVulnerabilities"`
+	Licenses             []Violation `json:"policyViolationLicenses"`
+}
+
+type Violation struct {
+	Name                 string   `json:"name"`
+	ViolatingPolicyNames []string `json:"violatingPolicyNames"`
+}
+
+func Load(outputDir string) (Result, error) {
+	files, _ := filepath.Glob(filepath.Join(outputDir, resultPattern))
+	if len(files) == 0 {
+		return Result{}, ErrNoResult
+	}
+	sort.Strings(files)
+	b, err := ioutil.ReadFile(files[len(files)-1])
+	if err != nil {
+		return Result{}, err
+	}
+	var components []Component
+	if err := json.Unmarshal(b, &components); err != nil {
+		return Result{}, fmt.Errorf("could not read the result of the rapid scan: %w", err)
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return components[i].Identifier < components[j].Identifier
+	})
+	sum := sha256.Sum256(b)
+	return Result{Components: components, Digest: hex.EncodeToString(sum[:])}, nil
+}
+
+// Violations returns the components, which violate at least one policy.
+func (r Result) Violations() []Component {
+	var violations []Component
+	for _, c := range r.Components {
+		if len(c.Policies()) != 0 {
+			violations = append(violations, c)
+		}
+	}
+	return violations
+}
+
+// Policies returns all policies, which are violated by the component.
+func (c Component) Policies() []string {
+	unique := map[string]bool{}
+	for _, name := range c.ViolatingPolicyNames {
+		unique[name] = true
+	}
+	for _, v := range append(append([]Violation{}, c.Vulnerabilities...), c.Licenses...) {
+		for _, name := range v.ViolatingPolicyNames {
+			unique[name] = true
+		}
+	}
+	policies := make([]string, 0, len(unique))
+	for name := range unique {
+		policies = append(policies, name)
+	}
+	sort.Strings(policies)
+	return policies
+}
+
+func (r Result) Summary() string {
+	violations := r.Violations()
+	if len(violations) == 0 {
+		return fmt.Sprintf("Rapid scan found no policy violations in %d components\n", len(r.Components))
+	}
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "Rapid scan found policy violations in %d of %d components:\n", len(violations), len(r.Components))
+	for _, c := range violations {
+		fmt.Fprintf(&b, "  %v %v (%v)\n", c.Name, c.Version, c.Identifier)
+		fmt.Fprintf(&b, "    policies: %v\n", strings.Join(c.Policies(), ", "))
+		writeViolations(&b, "vulnerabilities", c.Vulnerabilities)
+		writeViolations(&b, "licenses", c.Licenses)
+	}
+	return b.String()
+}
+
+func writeViolations(b *strings.Builder, kind string, violations []Violation) {
+	if len(violations) == 0 {
+		return
+	}
+	names := make([]string, len(violations))
+	for i, v := range violations {
+		names[i] = v.Name
+	}
+	fmt.Fprintf(b, "    %v: %v\n", kind, strings.Join(names, ", "))
+}
@@ -0,0 +1,67 @@
+package rapid_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/out/rapid"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func prepareOutputDir(t *testing.T, content []byte) string {
+	dir := t.TempDir()
+	scanDir := filepath.Join(dir, "runs", "2023-10-19-10-11-12-123", "scan")
+	require.NoError(t, os.MkdirAll(scanDir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(scanDir, "project1_1.0.0_BlackDuck_DeveloperMode_Result.json"), content, 0644))
+	return dir
+}
+
+func loadTestResult(t *testing.T) rapid.Result {
+	b, err := ioutil.ReadFile("testdata/result.json")
+	require.NoError(t, err)
+	result, err := rapid.Load(prepareOutputDir(t, b))
+	require.NoError(t, err)
+	return result
+}
+
+func TestLoadsTheResultOfTheRapidScan(t *testing.T) {
+	result := loadTestResult(t)
+	require.Len(t, result.Components, 3)
+	require.Len(t, result.Digest, 64)
+	require.Equal(t, "maven:com.fasterxml.jackson.core:jackson-databind:2.15.2", result.Components[0].Identifier)
+}
+
+func TestReturnsTheComponentsViolatingPolicies(t *testing.T) {
+	violations := loadTestResult(t).Violations()
+	require.Len(t, violations, 2)
+	require.Equal(t, "MySQL Connector/J", violations[0].Name)
+	require.Equal(t, []string{"No Copyleft"}, violations[0].Policies())
+}
+
+func TestSummarizesTheViolations(t *testing.T) {
+	require.Equal(t, `Rapid scan found policy violations in 2 of 3 components:
+  MySQL Connector/J 8.0.33 (maven:com.mysql:mysql-connector-j:8.0.33)
+    policies: No Copyleft
+    licenses: GPL 2.0
+  Apache Log4j 2.14.1 (maven:org.apache.logging.log4j:log4j-core:2.14.1)
+    policies: No Critical Vulnerabilities
+    vulnerabilities: CVE-2021-44228, CVE-2021-45046
+`, loadTestResult(t).Summary())
+}
+
+func TestSummarizesResultsWithoutViolations(t *testing.T) {
+	result, err := rapid.Load(prepareOutputDir(t, []byte(`[{"componentName":"a"}]`)))
+	require.NoError(t, err)
+	require.Equal(t, "Rapid scan found no policy violations in 1 components\n", result.Summary())
+}
+
+func TestErrorsWhenTheResultIsMissing(t *testing.T) {
+	_, err := rapid.Load(t.TempDir())
+	require.Equal(t, rapid.ErrNoResult, err)
+}
+
+func TestErrorsWhenTheResultIsCorrupted(t *testing.T) {
+	_, err := rapid.Load(prepareOutputDir(t, []byte(`{]`)))
+	require.EqualError(t, err, "could not read the result of the rapid scan: invalid character ']' looking for beginning of object key string")
+}
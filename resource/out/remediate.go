@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/out/interpreter"
+	"github.com/elgohr/concourse-blackduck/out/remediation"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"path/filepath"
+	"strconv"
+)
+
+// remediate applies the remediation decisions of a file to the vulnerable components of a version.
+// The drift between Blackduck and the file is always reported, but only corrected when it's no dry run.
+func (r *Runner) remediate(input shared.Request) error {
+	file, digest, err := remediation.Load(filepath.Join(r.path, input.Params.RemediationFile))
+	if err != nil {
+		return err
+	}
+	project, err := r.api.GetProjectByName(input.Source)
+	if err != nil {
+		return err
+	}
+	versions, err := r.api.GetProjectVersions(input.Source, project)
+	if err != nil {
+		return err
+	}
+	version, err := findVersion(versions, input.Params)
+	if err != nil {
+		return err
+	}
+	components, err := r.api.GetVulnerableComponents(input.Source, &version)
+	if err != nil {
+		return err
+	}
+
+	result := remediation.Compare(file, components, r.now())
+	fmt.Fprint(r.stdErr, result.String())
+	if input.Params.DryRun {
+		fmt.Fprintln(r.stdErr, "Nothing was applied, as this is a dry run.")
+	} else {
+		for _, drift := range result.Drifts {
+			if err := r.api.UpdateRemediation(input.Source, drift.Component, drift.To); err != nil {
+				return fmt.Errorf("could not remediate %v: %w", drift.Decision, err)
+			}
+		}
+	}
+
+	response := interpreter.Response{
+		Id: shared.Ref{Ref: "remediate-" + digest},
+		MetaData: []interpreter.MetaData{
+			{Name: "name", Value: input.Source.Name},
+			{Name: "version", Value: version.Name},
+			{Name: "dryRun", Value: strconv.FormatBool(input.Params.DryRun)},
+			{Name: "drift", Value: strconv.Itoa(len(result.Drifts))},
+			{Name: "expired", Value: strconv.Itoa(len(result.Expired))},
+			{Name: "unmatched", Value: strconv.Itoa(len(result.Unmatched))},
+		},
+	}
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	_, err = r.stdOut.Write(b)
+	return err
+}
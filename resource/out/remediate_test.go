@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const remediations = "remediations:\n" +
+	"- {component: log4j, vulnerability: CVE-2021-44228, status: NOT_AFFECTED, comment: removed}\n" +
+	"- {component: commons-text, vulnerability: CVE-2022-42889, status: PATCHED}\n"
+
+var log4jVulnerability = shared.VulnerableComponent{
+	ComponentName:        "log4j",
+	ComponentVersionName: "2.14.1",
+	Vulnerability:        shared.VulnerabilityWithRemediation{Name: "CVE-2021-44228", RemediationStatus: "NEW"},
+	Meta:                 shared.Meta{Href: "log4j-remediation"},
+}
+
+func remediateRequest(dryRun bool) string {
+	return request("", fmt.Sprintf(`{"action": "remediate", "remediation_file": "source-code/remediations.yml", "version_name": "1.0.0", "dry_run": %v}`, dryRun))
+}
+
+func TestAppliesRemediationDecisions(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, remediateRequest(false), shared.Version{Name: "0.9.0"}, shared.Version{Name: "1.0.0"})
+	writeFile(t, r.path, "source-code/remediations.yml", remediations)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+	stdOut := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	r.stdOut = stdOut
+	r.stdErr = stdErr
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, version := fakeBlackduckApi.GetVulnerableComponentsArgsForCall(0); version.Name != "1.0.0" {
+		t.Errorf("Expected the components of 1.0.0, but got %v", version.Name)
+	}
+	_, component, remediation := fakeBlackduckApi.UpdateRemediationArgsForCall(0)
+	if component.Meta.Href != "log4j-remediation" || remediation != (shared.Remediation{Status: "NOT_AFFECTED", Comment: "removed"}) {
+		t.Errorf("Expected log4j to be NOT_AFFECTED, but got %v for %v", remediation, component.Meta.Href)
+	}
+	if !strings.Contains(stdErr.String(), "CVE-2021-44228 in log4j 2.14.1: NEW -> NOT_AFFECTED") {
+		t.Errorf("Expected the drift to be reported, but got %v", stdErr.String())
+	}
+	expRes := `{"version":{"ref":"remediate-[0-9a-f]{64}"},"metadata":\[` +
+		`{"name":"name","value":"project1"},` +
+		`{"name":"version","value":"1.0.0"},` +
+		`{"name":"dryRun","value":"false"},` +
+		`{"name":"drift","value":"1"},` +
+		`{"name":"expired","value":"0"},` +
+		`{"name":"unmatched","value":"1"}\]}`
+	if !regexp.MustCompile("^" + expRes + "$").MatchString(stdOut.String()) {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+}
+
+func TestOnlyReportsTheDriftOfRemediationsOnDryRuns(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, remediateRequest(true), shared.Version{Name: "1.0.0"})
+	writeFile(t, r.path, "source-code/remediations.yml", remediations)
+	fakeBlackduckApi.GetVulnerableComponentsReturns([]shared.VulnerableComponent{log4jVulnerability}, nil)
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.UpdateRemediationCallCount() != 0 {
+		t.Error("Should not have remediated anything on a dry run")
+	}
+}
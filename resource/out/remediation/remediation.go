@@ -0,0 +1,183 @@
+package remediation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// ExpiredStatus is set, when an expired decision is still in place, so that the vulnerability is triaged again.
+const ExpiredStatus = "NEEDS_REVIEW"
+
+const expiryLayout = "2006-01-02"
+
+type File struct {
+	Decisions []Decision `yaml:"remediations"`
+}
+
+// Decision remediates a vulnerability of a component, in every version of the component unless a version is given.
+type Decision struct {
+	Component     string `yaml:"component"`
+	Version       string `yaml:"version"`
+	Vulnerability string `yaml:"vulnerability"`
+	Status        string `yaml:"status"`
+	Comment       string `yaml:"comment"`
+	Expires       string `yaml:"expires"`
+}
+
+func (d Decision) String() string {
+	component := d.Component
+	if len(d.Version) != 0 {
+		component += " " + d.Version
+	}
+	return d.Vulnerability + " in " + component
+}
+
+// Expired returns true, when the decision isn't valid anymore at the time. It expires at the end of its day.
+func (d Decision) Expired(now time.Time) bool {
+	if len(d.Expires) == 0 {
+		return false
+	}
+	expires, _ := time.Parse(expiryLayout, d.Expires)
+	return !now.Before(expires.AddDate(0, 0, 1))
+}
+
+func (d Decision) matches(component shared.VulnerableComponent) bool {
+	return strings.EqualFold(d.Component, component.ComponentName) &&
+		(len(d.Version) == 0 || d.Version == component.ComponentVersionName) &&
+		strings.EqualFold(d.Vulnerability, component.Vulnerability.Name)
+}
+
+// Load reads the decisions and returns a digest of the file.
+func Load(file string) (File, string, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return File{}, "", err
+	}
+	var f File
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil {
+		return File{}, "", fmt.Errorf("%v is invalid: %w", file, err)
+	}
+	for i, decision := range f.Decisions {
+		if err := decision.validate(); err != nil {
+			return File{}, "", fmt.Errorf("%v is invalid: remediation %v: %w", file, i+1, err)
+		}
+	}
+	hash := sha256.Sum256(content)
+	return f, hex.EncodeToString(hash[:]), nil
+}
+
+func (d Decision) validate() error {
+	if len(d.Component) == 0 || len(d.Vulnerability) == 0 {
+		return fmt.Errorf("component and vulnerability are mandatory")
+	}
+	valid := false
+	for _, status := range shared.RemediationStatuses {
+		valid = valid || status == d.Status
+	}
+	if !valid {
+		return fmt.Errorf("status %v is unknown", d.Status)
+	}
+	if len(d.Expires) != 0 {
+		if _, err := time.Parse(expiryLayout, d.Expires); err != nil {
+			return fmt.Errorf("expires %v is no date like 2006-01-02", d.Expires)
+		}
+	}
+	return nil
+}
+
+// Drift is a vulnerable component, whose remediation on Blackduck disagrees with the file.
+type Drift struct {
+	Component shared.VulnerableComponent
+	Decision  Decision
+	Expired   bool
+	To        shared.Remediation
+}
+
+func (d Drift) String() string {
+	from := d.Component.Vulnerability
+	component := d.Component.ComponentName + " " + d.Component.ComponentVersionName
+	if d.Expired {
+		return fmt.Sprintf("%v in %v: the decision %v expired on %v", from.Name, component, d.Decision.Status, d.Decision.Expires)
+	}
+	if from.RemediationStatus != d.To.Status {
+		return fmt.Sprintf("%v in %v: %v -> %v", from.Name, component, from.RemediationStatus, d.To.Status)
+	}
+	return fmt.Sprintf("%v in %v: comment %q -> %q", from.Name, component, from.RemediationComment, d.To.Comment)
+}
+
+type Result struct {
+	Drifts    []Drift
+	Unmatched []Decision
+	Expired   []Decision
+}
+
+// Compare finds the drift between the decisions and the vulnerable components of a version.
+// Decisions, which match no vulnerable component, are reported as unmatched, as they might be outdated.
+func Compare(f File, components []shared.VulnerableComponent, now time.Time) Result {
+	var result Result
+	for _, decision := range f.Decisions {
+		expired := decision.Expired(now)
+		if expired {
+			result.Expired = append(result.Expired, decision)
+		}
+		matched := false
+		for _, component := range components {
+			if !decision.matches(component) {
+				continue
+			}
+			matched = true
+			current := component.Vulnerability
+			if expired {
+				if current.RemediationStatus == decision.Status {
+					result.Drifts = append(result.Drifts, Drift{
+						Component: component,
+						Decision:  decision,
+						Expired:   true,
+						To:        shared.Remediation{Status: ExpiredStatus, Comment: "Expired: " + decision.Comment},
+					})
+				}
+				continue
+			}
+			if current.RemediationStatus != decision.Status || current.RemediationComment != decision.Comment {
+				result.Drifts = append(result.Drifts, Drift{
+					Component: component,
+					Decision:  decision,
+					To:        shared.Remediation{Status: decision.Status, Comment: decision.Comment},
+				})
+			}
+		}
+		if !matched {
+			result.Unmatched = append(result.Unmatched, decision)
+		}
+	}
+	return result
+}
+
+// String reports the drift, as well as expired and unmatched decisions.
+func (r Result) String() string {
+	var b strings.Builder
+	if len(r.Drifts) == 0 {
+		b.WriteString("No drift. The remediations match the file.\n")
+	} else {
+		fmt.Fprintf(&b, "Drift of %v remediations:\n", len(r.Drifts))
+		for _, drift := range r.Drifts {
+			fmt.Fprintf(&b, "  %v\n", drift)
+		}
+	}
+	for _, decision := range r.Expired {
+		fmt.Fprintf(&b, "Expired: %v (%v on %v)\n", decision, decision.Status, decision.Expires)
+	}
+	for _, decision := range r.Unmatched {
+		fmt.Fprintf(&b, "Unmatched: %v isn't vulnerable in this version\n", decision)
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package remediation_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/out/remediation"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2020, 1, 31, 12, 0, 0, 0, time.UTC)
+
+const decisions = `
+remediations:
+- component: Apache Log4j
+  version: 2.14.1
+  vulnerability: CVE-2021-44228
+  status: NOT_AFFECTED
+  comment: JndiLookup is removed from the classpath
+- component: jackson-databind
+  vulnerability: BDSA-2020-0001
+  status: IGNORED
+  comment: Not deserializing untrusted data
+  expires: 2020-01-30
+- component: commons-text
+  vulnerability: CVE-2022-42889
+  status: PATCHED
+`
+
+func vulnerable(component string, version string, vulnerability string, status string, comment string) shared.VulnerableComponent {
+	return shared.VulnerableComponent{
+		ComponentName:        component,
+		ComponentVersionName: version,
+		Vulnerability: shared.VulnerabilityWithRemediation{
+			Name:               vulnerability,
+			RemediationStatus:  status,
+			RemediationComment: comment,
+		},
+		Meta: shared.Meta{Href: component + "/" + version + "/" + vulnerability},
+	}
+}
+
+func TestLoadsTheDecisions(t *testing.T) {
+	f, digest, err := remediation.Load(writeFile(t, decisions))
+	require.NoError(t, err)
+	require.Len(t, digest, 64)
+	require.Len(t, f.Decisions, 3)
+	require.Equal(t, remediation.Decision{
+		Component:     "Apache Log4j",
+		Version:       "2.14.1",
+		Vulnerability: "CVE-2021-44228",
+		Status:        "NOT_AFFECTED",
+		Comment:       "JndiLookup is removed from the classpath",
+	}, f.Decisions[0])
+}
+
+func TestRejectsInvalidDecisions(t *testing.T) {
+	for content, message := range map[string]string{
+		"remediations: [{component: a, vulnerability: CVE-1, status: FINE}]":                      "remediation 1: status FINE is unknown",
+		"remediations: [{component: a, status: IGNORED}]":                                         "remediation 1: component and vulnerability are mandatory",
+		"remediations: [{component: a, vulnerability: CVE-1, status: IGNORED, expires: 31.1.20}]": "remediation 1: expires 31.1.20 is no date like 2006-01-02",
+	} {
+		file := writeFile(t, content)
+		_, _, err := remediation.Load(file)
+		require.EqualError(t, err, file+" is invalid: "+message)
+	}
+}
+
+func TestDecisionsExpireAtTheEndOfTheirDay(t *testing.T) {
+	d := remediation.Decision{Expires: "2020-01-31"}
+	require.False(t, d.Expired(now))
+	require.True(t, d.Expired(now.AddDate(0, 0, 1)))
+	require.False(t, remediation.Decision{}.Expired(now))
+}
+
+func TestFindsTheDrift(t *testing.T) {
+	f, _, err := remediation.Load(writeFile(t, decisions))
+	require.NoError(t, err)
+	result := remediation.Compare(f, []shared.VulnerableComponent{
+		vulnerable("apache log4j", "2.14.1", "CVE-2021-44228", "NEW", ""),
+		vulnerable("Apache Log4j", "2.15.0", "CVE-2021-44228", "NEW", ""),
+		vulnerable("jackson-databind", "2.9.0", "BDSA-2020-0001", "IGNORED", "Not deserializing untrusted data"),
+		vulnerable("jackson-databind", "2.9.1", "BDSA-2020-0001", "NEW", ""),
+	}, now)
+
+	require.Len(t, result.Drifts, 2)
+	require.Equal(t, shared.Remediation{Status: "NOT_AFFECTED", Comment: "JndiLookup is removed from the classpath"}, result.Drifts[0].To)
+	require.Equal(t, "apache log4j/2.14.1/CVE-2021-44228", result.Drifts[0].Component.Meta.Href)
+	require.True(t, result.Drifts[1].Expired)
+	require.Equal(t, shared.Remediation{Status: "NEEDS_REVIEW", Comment: "Expired: Not deserializing untrusted data"}, result.Drifts[1].To)
+	require.Equal(t, "jackson-databind/2.9.0/BDSA-2020-0001", result.Drifts[1].Component.Meta.Href)
+	require.Equal(t, `Drift of 2 remediations:
+  CVE-2021-44228 in apache log4j 2.14.1: NEW -> NOT_AFFECTED
+  BDSA-2020-0001 in jackson-databind 2.9.0: the decision IGNORED expired on 2020-01-30
+Expired: BDSA-2020-0001 in jackson-databind (IGNORED on 2020-01-30)
+Unmatched: CVE-2022-42889 in commons-text isn't vulnerable in this version
+`, result.String())
+}
+
+func TestReportsChangedComments(t *testing.T) {
+	f := remediation.File{Decisions: []remediation.Decision{{Component: "a", Vulnerability: "CVE-1", Status: "IGNORED", Comment: "new"}}}
+	result := remediation.Compare(f, []shared.VulnerableComponent{vulnerable("a", "1", "CVE-1", "IGNORED", "old")}, now)
+	require.Len(t, result.Drifts, 1)
+	require.Equal(t, `CVE-1 in a 1: comment "old" -> "new"`, result.Drifts[0].String())
+}
+
+func TestReportsNoDriftWhenTheFileMatches(t *testing.T) {
+	f := remediation.File{Decisions: []remediation.Decision{{Component: "a", Vulnerability: "CVE-1", Status: "IGNORED"}}}
+	result := remediation.Compare(f, []shared.VulnerableComponent{vulnerable("a", "1", "CVE-1", "IGNORED", "")}, now)
+	require.Empty(t, result.Drifts)
+	require.Equal(t, "No drift. The remediations match the file.\n", result.String())
+}
+
+func writeFile(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "remediations.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+	return file
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/out/interpreter"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cleanup enforces the retention of the project without scanning. A dry run only lists the versions.
+func (r *Runner) cleanup(input shared.Request) error {
+	if !input.Source.Retention.Configured() {
+		return errors.New("missing retention in source")
+	}
+	var protected []string
+	if len(input.Params.VersionName) != 0 {
+		protected = append(protected, input.Params.VersionName)
+	}
+	removed, err := r.applyRetention(input.Source, input.Params.DryRun, r.stdErr, protected...)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(removed))
+	for i, version := range removed {
+		names[i] = version.Name
+	}
+	kind := "deleted"
+	if input.Source.Retention.Archive {
+		kind = "archived"
+	}
+	response := interpreter.Response{
+		Id: shared.Ref{Ref: "cleanup-" + r.now().UTC().Format(time.RFC3339)},
+		MetaData: []interpreter.MetaData{
+			{Name: "name", Value: input.Source.Name},
+			{Name: "dryRun", Value: strconv.FormatBool(input.Params.DryRun)},
+			{Name: kind, Value: strings.Join(names, ", ")},
+		},
+	}
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	_, err = r.stdOut.Write(b)
+	return err
+}
+
+// enforceRetention removes the old versions of the scanned projects after a successful put.
+// The scanned versions are never removed. As Detect can name them itself, they are taken from the metadata of the response as well.
+func (r *Runner) enforceRetention(input shared.Request, response interpreter.Response) error {
+	scanned := map[string][]string{}
+	var projects []string
+	add := func(project string, versions ...string) {
+		if _, ok := scanned[project]; !ok {
+			projects = append(projects, project)
+			scanned[project] = nil
+		}
+		for _, version := range versions {
+			if len(version) != 0 {
+				scanned[project] = append(scanned[project], version)
+			}
+		}
+	}
+	metaData := map[string]string{}
+	for _, m := range response.MetaData {
+		metaData[m.Name] = m.Value
+	}
+	if len(input.Params.Directories) != 0 {
+		for _, directory := range input.Params.Directories {
+			add(getProjectName(input.Source, directory), directory.VersionName, metaData[directory.Path+".version"])
+		}
+	} else {
+		add(input.Source.Name, input.Params.VersionName, metaData["version"])
+	}
+	for _, project := range projects {
+		source := input.Source
+		source.Name = project
+		if _, err := r.applyRetention(source, false, r.stdErr, scanned[project]...); err != nil {
+			return fmt.Errorf("could not enforce the retention of %v: %w", project, err)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) applyRetention(source shared.Source, dryRun bool, stdErr io.Writer, protected ...string) ([]shared.Version, error) {
+	project, err := r.api.GetProjectByName(source)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := r.api.GetProjectVersions(source, project)
+	if err != nil {
+		return nil, err
+	}
+	removed := source.Retention.Select(versions, r.now(), protected...)
+	verb, dryRunVerb := "Deleting", "Would delete"
+	if source.Retention.Archive {
+		verb, dryRunVerb = "Archiving", "Would archive"
+	}
+	if dryRun {
+		verb = dryRunVerb
+	}
+	if len(removed) == 0 {
+		fmt.Fprintf(stdErr, "No versions of %v are affected by the retention\n", source.Name)
+	}
+	for _, version := range removed {
+		fmt.Fprintf(stdErr, "%v version %v of %v (%v, created %v)\n", verb, version.Name, source.Name, version.Phase, version.Created.Format(time.RFC3339))
+		if dryRun {
+			continue
+		}
+		if source.Retention.Archive {
+			version.Phase = "ARCHIVED"
+			err = r.api.UpdateVersion(source, version)
+		} else {
+			err = r.api.DeleteVersion(source, version)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"strings"
+	"testing"
+	"time"
+)
+
+var retentionNow = time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+var retentionVersions = []shared.Version{
+	{Name: "0.1.0", Phase: "DEVELOPMENT", Created: retentionNow.AddDate(0, 0, -40)},
+	{Name: "0.2.0", Phase: "RELEASED", Created: retentionNow.AddDate(0, 0, -30)},
+	{Name: "0.3.0", Phase: "DEVELOPMENT", Created: retentionNow.AddDate(0, 0, -20)},
+	{Name: "0.4.0", Phase: "DEVELOPMENT", Created: retentionNow.AddDate(0, 0, -1)},
+}
+
+func TestListsTheVersionsOfACleanupOnDryRuns(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, request(`"retention": {"keep_last": 1}`, `{"action": "cleanup", "dry_run": true}`), retentionVersions...)
+	r.now = func() time.Time { return retentionNow }
+	stdOut := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	r.stdOut = stdOut
+	r.stdErr = stdErr
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	expLog := "Would delete version 0.3.0 of project1 (DEVELOPMENT, created 2020-01-11T00:00:00Z)\n" +
+		"Would delete version 0.1.0 of project1 (DEVELOPMENT, created 2019-12-22T00:00:00Z)\n"
+	if stdErr.String() != expLog {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expLog, stdErr.String())
+	}
+	if fakeBlackduckApi.DeleteVersionCallCount() != 0 || fakeBlackduckApi.UpdateVersionCallCount() != 0 {
+		t.Error("Should not have changed anything on a dry run")
+	}
+	expRes := `{"version":{"ref":"cleanup-2020-01-31T00:00:00Z"},"metadata":[` +
+		`{"name":"name","value":"project1"},` +
+		`{"name":"dryRun","value":"true"},` +
+		`{"name":"deleted","value":"0.3.0, 0.1.0"}]}`
+	if stdOut.String() != expRes {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+}
+
+func TestArchivesOldVersionsOnCleanup(t *testing.T) {
+	r, fakeBlackduckApi := prepareRunner(t, request(`"retention": {"keep_newer_than": "30d", "archive": true}`, `{"action": "cleanup"}`), retentionVersions...)
+	r.now = func() time.Time { return retentionNow }
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if fakeBlackduckApi.UpdateVersionCallCount() != 1 || fakeBlackduckApi.DeleteVersionCallCount() != 0 {
+		t.Fatalf("Expected one version to be archived, but got %v updates", fakeBlackduckApi.UpdateVersionCallCount())
+	}
+	if _, version := fakeBlackduckApi.UpdateVersionArgsForCall(0); version.Name != "0.1.0" || version.Phase != "ARCHIVED" {
+		t.Errorf("Expected 0.1.0 to be archived, but got %v", version)
+	}
+}
+
+func TestErrorsOnCleanupWithoutRetention(t *testing.T) {
+	r, _ := prepareRunner(t, request("", `{"action": "cleanup"}`))
+
+	if err := r.run(); err == nil || err.Error() != "missing retention in source" {
+		t.Errorf("Should have errored with missing retention in source, but was %v", err)
+	}
+}
+
+func TestEnforcesTheRetentionOnlyAfterSuccessfulScans(t *testing.T) {
+	for _, status := range []string{"SUCCESS", "FAILURE_POLICY_VIOLATION"} {
+		r, fakeBlackduckApi := prepareScan(t, request(`"retention": {"keep_last": 1}`, `{"directory": ".", "version_name": "0.3.0"}`), "--- Overall Status: "+status, retentionVersions...)
+		r.now = func() time.Time { return retentionNow }
+
+		err := r.run()
+		if status != "SUCCESS" {
+			if err == nil || fakeBlackduckApi.DeleteVersionCallCount() != 0 {
+				t.Errorf("Should not have enforced the retention after a failed scan, but was %v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var deleted []string
+		for i := 0; i < fakeBlackduckApi.DeleteVersionCallCount(); i++ {
+			_, version := fakeBlackduckApi.DeleteVersionArgsForCall(i)
+			deleted = append(deleted, version.Name)
+		}
+		if strings.Join(deleted, ",") != "0.1.0" {
+			t.Errorf("Expected only 0.1.0 to be deleted, while the scanned 0.3.0 is kept, but got %v", deleted)
+		}
+	}
+}
+
+func TestKeepsTheVersionWhichDetectNamed(t *testing.T) {
+	r, fakeBlackduckApi := prepareScan(t, request(`"retention": {"keep_last": 1}`, `{"directory": "."}`), "--- Project name: project1\n--- Project version: 0.1.0\n--- Overall Status: SUCCESS", retentionVersions...)
+	r.now = func() time.Time { return retentionNow }
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	var deleted []string
+	for i := 0; i < fakeBlackduckApi.DeleteVersionCallCount(); i++ {
+		_, version := fakeBlackduckApi.DeleteVersionArgsForCall(i)
+		deleted = append(deleted, version.Name)
+	}
+	if strings.Join(deleted, ",") != "0.3.0" {
+		t.Errorf("Expected only 0.3.0 to be deleted, while the scanned 0.1.0 is kept, but got %v", deleted)
+	}
+}
+
+func TestPrintsPercentSignsOfTheMetadata(t *testing.T) {
+	stdIn := `{
+			"source": {
+				"url": "https://BLACKDUCK",
+				"username": "username",
+				"password": "password",
+				"name": "my%20project",
+				"retention": {"keep_last": 10}
+			},
+			"params": {"action": "cleanup"}
+		}`
+	r, _ := prepareRunner(t, stdIn, retentionVersions...)
+	r.now = func() time.Time { return retentionNow }
+	stdOut := &bytes.Buffer{}
+	r.stdOut = stdOut
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdOut.String(), `{"name":"name","value":"my%20project"}`) {
+		t.Errorf("Expected the name to be printed as it is, but got %v", stdOut.String())
+	}
+}
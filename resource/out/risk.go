@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/out/interpreter"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"io"
+)
+
+// addRisk appends the risk profile and the policy status of the scanned version to the metadata.
+// As the scan already succeeded, it's only logged, when they can't be fetched.
+func (r *Runner) addRisk(source shared.Source, response *interpreter.Response, stdErr io.Writer) {
+	source, versionName := scannedVersion(source, *response)
+	if len(versionName) == 0 {
+		return
+	}
+	metaData, err := r.getRisk(source, versionName)
+	if err != nil {
+		fmt.Fprintf(stdErr, "Could not fetch the risk profile of %v %v: %v\n", source.Name, versionName, err)
+		return
+	}
+	response.MetaData = append(response.MetaData, metaData...)
+}
+
+func (r *Runner) getRisk(source shared.Source, versionName string) ([]interpreter.MetaData, error) {
+	version, err := r.getVersion(source, versionName)
+	if err != nil {
+		return nil, err
+	}
+	risk, err := shared.GetRisk(r.api, source, &version)
+	if err != nil {
+		return nil, err
+	}
+	var metaData []interpreter.MetaData
+	for _, m := range risk {
+		metaData = append(metaData, interpreter.MetaData{Name: m.Name, Value: m.Value})
+	}
+	return metaData, nil
+}
+
+// scannedVersion returns the project and the name of the version, which Detect reported in the metadata of the response.
+func scannedVersion(source shared.Source, response interpreter.Response) (shared.Source, string) {
+	var versionName string
+	for _, m := range response.MetaData {
+		switch m.Name {
+		case "name":
+			if len(m.Value) != 0 {
+				source.Name = m.Value
+			}
+		case "version":
+			versionName = m.Value
+		}
+	}
+	return source, versionName
+}
+
+func (r *Runner) getVersion(source shared.Source, versionName string) (shared.Version, error) {
+	project, err := r.api.GetProjectByName(source)
+	if err != nil {
+		return shared.Version{}, err
+	}
+	versions, err := r.api.GetProjectVersions(source, project)
+	if err != nil {
+		return shared.Version{}, err
+	}
+	return findVersion(versions, shared.Params{VersionName: versionName})
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/elgohr/concourse-blackduck/out/interpreter"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/elgohr/concourse-blackduck/shared/sharedfakes"
+	"strings"
+	"testing"
+)
+
+func TestOnlyLogsWhenTheRiskCantBeFetched(t *testing.T) {
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(&shared.Project{Name: "project1"}, nil)
+	fakeBlackduckApi.GetProjectVersionsReturns([]shared.Version{{Name: "1.0.0"}}, nil)
+	fakeBlackduckApi.GetRiskProfileReturns(shared.RiskProfile{}, errors.New("missing link to the risk profile"))
+	stdErr := &bytes.Buffer{}
+	r := Runner{api: fakeBlackduckApi}
+	response := interpreter.Response{MetaData: []interpreter.MetaData{{Name: "name", Value: "project1"}, {Name: "version", Value: "1.0.0"}}}
+
+	r.addRisk(shared.Source{Name: "project1"}, &response, stdErr)
+
+	if len(response.MetaData) != 2 {
+		t.Errorf("Should not have added metadata, but got %v", response.MetaData)
+	}
+	if !strings.Contains(stdErr.String(), "Could not fetch the risk profile of project1 1.0.0: missing link to the risk profile") {
+		t.Errorf("Expected the failure to be logged, but got %v", stdErr.String())
+	}
+}
+
+func TestDoesntFetchTheRiskWithoutVersion(t *testing.T) {
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	r := Runner{api: fakeBlackduckApi}
+
+	r.addRisk(shared.Source{Name: "project1"}, &interpreter.Response{}, &bytes.Buffer{})
+
+	if fakeBlackduckApi.GetProjectByNameCallCount() != 0 {
+		t.Error("Should not have called Blackduck")
+	}
+}
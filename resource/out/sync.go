@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/out/interpreter"
+	"github.com/elgohr/concourse-blackduck/out/projectfile"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"path/filepath"
+	"strconv"
+)
+
+// syncProject makes the project on Blackduck match the blackduck.yml in the directory.
+// The plan is always printed, but only applied when it's no dry run.
+func (r *Runner) syncProject(input shared.Request) error {
+	file, digest, err := projectfile.Load(filepath.Join(r.path, input.Params.Directory, projectfile.Name))
+	if err != nil {
+		return err
+	}
+	remote, err := r.getProjectState(input.Source)
+	if err != nil {
+		return err
+	}
+	plan, err := projectfile.Diff(file, remote.State)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(r.stdErr, plan.String())
+	if input.Params.DryRun {
+		fmt.Fprintln(r.stdErr, "\nNothing was applied, as this is a dry run.")
+	} else if err := r.applyPlan(input.Source, file, remote, plan); err != nil {
+		return err
+	}
+
+	add, change, destroy := plan.Counts()
+	response := interpreter.Response{
+		Id: shared.Ref{Ref: "sync-" + digest},
+		MetaData: []interpreter.MetaData{
+			{Name: "name", Value: input.Source.Name},
+			{Name: "dryRun", Value: strconv.FormatBool(input.Params.DryRun)},
+			{Name: "add", Value: strconv.Itoa(add)},
+			{Name: "change", Value: strconv.Itoa(change)},
+			{Name: "destroy", Value: strconv.Itoa(destroy)},
+		},
+	}
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	_, err = r.stdOut.Write(b)
+	return err
+}
+
+// projectState keeps the resources of Blackduck, which are needed to apply a plan to the state.
+type projectState struct {
+	projectfile.State
+	project      *shared.Project
+	userGroups   map[string]shared.UserGroup
+	customFields map[string]shared.CustomField
+}
+
+func (r *Runner) getProjectState(source shared.Source) (projectState, error) {
+	project, err := r.api.GetProjectByName(source)
+	if errors.Is(err, shared.ErrProjectNotFound) {
+		return projectState{State: projectfile.State{Project: shared.Project{Name: source.Name}}}, nil
+	}
+	if err != nil {
+		return projectState{}, err
+	}
+	return r.getExistingProjectState(source, project)
+}
+
+func (r *Runner) getExistingProjectState(source shared.Source, project *shared.Project) (projectState, error) {
+	state := projectState{
+		State: projectfile.State{
+			Exists:       true,
+			Project:      *project,
+			CustomFields: map[string][]string{},
+		},
+		project:      project,
+		userGroups:   map[string]shared.UserGroup{},
+		customFields: map[string]shared.CustomField{},
+	}
+	userGroups, err := r.api.GetProjectUserGroups(source, project)
+	if err != nil {
+		return projectState{}, err
+	}
+	for _, group := range userGroups {
+		state.UserGroups = append(state.UserGroups, group.Name)
+		state.userGroups[group.Name] = group
+	}
+	tags, err := r.api.GetProjectTags(source, project)
+	if err != nil {
+		return projectState{}, err
+	}
+	for _, tag := range tags {
+		state.Tags = append(state.Tags, tag.Name)
+	}
+	customFields, err := r.api.GetProjectCustomFields(source, project)
+	if err != nil {
+		return projectState{}, err
+	}
+	for _, field := range customFields {
+		state.CustomFields[field.Label] = field.Values
+		state.customFields[field.Label] = field
+	}
+	return state, nil
+}
+
+func (r *Runner) applyPlan(source shared.Source, file projectfile.File, remote projectState, plan projectfile.Plan) error {
+	project := remote.project
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Kind {
+		case projectfile.KindProject:
+			desired := file.Apply(remote.Project)
+			if change.Action == projectfile.Create {
+				if project, err = r.api.CreateProject(source, desired); err == nil {
+					// the custom fields of a new project are only known after its creation
+					remote, err = r.getExistingProjectState(source, project)
+				}
+			} else {
+				err = r.api.UpdateProject(source, desired)
+			}
+		case projectfile.KindUserGroup:
+			if change.Action == projectfile.Create {
+				err = r.api.AddProjectUserGroup(source, project, change.Name)
+			} else {
+				err = r.api.RemoveProjectUserGroup(source, remote.userGroups[change.Name])
+			}
+		case projectfile.KindTag:
+			if change.Action == projectfile.Create {
+				err = r.api.AddProjectTag(source, project, change.Name)
+			} else {
+				err = r.api.RemoveProjectTag(source, project, change.Name)
+			}
+		case projectfile.KindCustomField:
+			field, ok := remote.customFields[change.Name]
+			if !ok {
+				err = fmt.Errorf("custom field %v doesn't exist", change.Name)
+				break
+			}
+			field.Values = file.CustomFields[change.Name]
+			err = r.api.UpdateCustomField(source, field)
+		}
+		if err != nil {
+			return fmt.Errorf("could not apply %v %v %v: %w", change.Action, change.Kind, change.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/elgohr/concourse-blackduck/shared/sharedfakes"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const syncRequest = `{
+		"source": {
+			"url": "https://BLACKDUCK",
+			"username": "username",
+			"password": "password",
+			"name": "billing"
+		},
+		"params": {
+			"action": "sync_project",
+			"directory": "source-code",
+			"dry_run": %v
+		}
+	}`
+
+func prepareProjectFile(t *testing.T) string {
+	buildDir := prepareBuildDir(t, "source-code")
+	content := "project: {tier: 2}\nuser_groups: [team-billing]\ntags: [java]\ncustom_fields: {Owner: [alice]}\n"
+	if err := ioutil.WriteFile(filepath.Join(buildDir, "source-code", "blackduck.yml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return buildDir
+}
+
+func prepareExistingProject() (*sharedfakes.FakeBlackduckApi, *shared.Project) {
+	project := &shared.Project{Name: "billing", ProjectTier: 3}
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(project, nil)
+	fakeBlackduckApi.GetProjectUserGroupsReturns([]shared.UserGroup{{Name: "team-legacy", Meta: shared.Meta{Href: "legacy-assignment"}}}, nil)
+	fakeBlackduckApi.GetProjectTagsReturns([]shared.Tag{{Name: "java"}}, nil)
+	fakeBlackduckApi.GetProjectCustomFieldsReturns([]shared.CustomField{{Label: "Owner", Values: []string{"bob"}, Meta: shared.Meta{Href: "owner-field"}}}, nil)
+	return fakeBlackduckApi, project
+}
+
+func TestPrintsThePlanWithoutApplyingItOnDryRuns(t *testing.T) {
+	stdIn := bytes.NewBufferString(fmt.Sprintf(syncRequest, true))
+	stdOut := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	fakeBlackduckApi, _ := prepareExistingProject()
+	r := Runner{
+		stdIn:  stdIn,
+		stdOut: stdOut,
+		stdErr: stdErr,
+		path:   prepareProjectFile(t),
+		api:    fakeBlackduckApi,
+	}
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	expPlan := `  ~ project "billing" {
+      ~ tier = 3 -> 2
+    }
+  + user_group "team-billing"
+  - user_group "team-legacy"
+  ~ custom_field "Owner" {
+      ~ values = ["bob"] -> ["alice"]
+    }
+
+Plan: 1 to add, 2 to change, 1 to destroy.
+
+Nothing was applied, as this is a dry run.
+`
+	if !strings.HasSuffix(stdErr.String(), expPlan) {
+		t.Errorf(`Expected plan: %v
+				Got:   %v`, expPlan, stdErr.String())
+	}
+	if fakeBlackduckApi.UpdateProjectCallCount()+fakeBlackduckApi.AddProjectUserGroupCallCount()+
+		fakeBlackduckApi.RemoveProjectUserGroupCallCount()+fakeBlackduckApi.UpdateCustomFieldCallCount() != 0 {
+		t.Error("Should not have changed anything on a dry run")
+	}
+	expRes := `{"version":{"ref":"sync-[0-9a-f]{64}"},"metadata":\[` +
+		`{"name":"name","value":"billing"},` +
+		`{"name":"dryRun","value":"true"},` +
+		`{"name":"add","value":"1"},` +
+		`{"name":"change","value":"2"},` +
+		`{"name":"destroy","value":"1"}\]}`
+	if !regexp.MustCompile("^" + expRes + "$").MatchString(stdOut.String()) {
+		t.Errorf(`Expected: %v
+				Got:   %v`, expRes, stdOut.String())
+	}
+}
+
+func TestAppliesThePlan(t *testing.T) {
+	stdIn := bytes.NewBufferString(fmt.Sprintf(syncRequest, false))
+	fakeBlackduckApi, project := prepareExistingProject()
+	r := Runner{
+		stdIn:  stdIn,
+		stdOut: &bytes.Buffer{},
+		stdErr: &bytes.Buffer{},
+		path:   prepareProjectFile(t),
+		api:    fakeBlackduckApi,
+	}
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, updated := fakeBlackduckApi.UpdateProjectArgsForCall(0); updated.ProjectTier != 2 {
+		t.Errorf("Expected the tier to be updated, but was %v", updated.ProjectTier)
+	}
+	if _, inProject, group := fakeBlackduckApi.AddProjectUserGroupArgsForCall(0); inProject != project || group != "team-billing" {
+		t.Errorf("Expected team-billing to be assigned, but was %v", group)
+	}
+	if _, group := fakeBlackduckApi.RemoveProjectUserGroupArgsForCall(0); group.Meta.Href != "legacy-assignment" {
+		t.Errorf("Expected the assignment of team-legacy to be removed, but was %v", group)
+	}
+	if fakeBlackduckApi.AddProjectTagCallCount() != 0 || fakeBlackduckApi.RemoveProjectTagCallCount() != 0 {
+		t.Error("Should not have changed matching tags")
+	}
+	expField := shared.CustomField{Label: "Owner", Values: []string{"alice"}, Meta: shared.Meta{Href: "owner-field"}}
+	if _, field := fakeBlackduckApi.UpdateCustomFieldArgsForCall(0); !reflect.DeepEqual(field, expField) {
+		t.Errorf("Expected %v, but got %v", expField, field)
+	}
+}
+
+func TestCreatesMissingProjectsBeforeApplyingTheRest(t *testing.T) {
+	stdIn := bytes.NewBufferString(fmt.Sprintf(syncRequest, false))
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetProjectByNameReturns(nil, fmt.Errorf("GetProjectByName: %w", shared.ErrProjectNotFound))
+	created := &shared.Project{Name: "billing", ProjectTier: 2}
+	fakeBlackduckApi.CreateProjectReturns(created, nil)
+	fakeBlackduckApi.GetProjectCustomFieldsReturns([]shared.CustomField{{Label: "Owner", Meta: shared.Meta{Href: "owner-field"}}}, nil)
+	r := Runner{
+		stdIn:  stdIn,
+		stdOut: &bytes.Buffer{},
+		stdErr: &bytes.Buffer{},
+		path:   prepareProjectFile(t),
+		api:    fakeBlackduckApi,
+	}
+
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, project := fakeBlackduckApi.CreateProjectArgsForCall(0); project.Name != "billing" || project.ProjectTier != 2 {
+		t.Errorf("Expected billing to be created with tier 2, but was %v", project)
+	}
+	if _, inProject, _ := fakeBlackduckApi.AddProjectTagArgsForCall(0); inProject != created {
+		t.Error("Expected the tag to be added to the created project")
+	}
+	if _, field := fakeBlackduckApi.UpdateCustomFieldArgsForCall(0); field.Meta.Href != "owner-field" {
+		t.Errorf("Expected the custom field of the created project to be updated, but was %v", field)
+	}
+}
+
+func TestErrorsWhenTheProjectFileIsMissing(t *testing.T) {
+	stdIn := bytes.NewBufferString(fmt.Sprintf(syncRequest, true))
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	r := Runner{
+		stdIn:  stdIn,
+		stdOut: &bytes.Buffer{},
+		stdErr: &bytes.Buffer{},
+		path:   prepareBuildDir(t, "source-code"),
+		api:    fakeBlackduckApi,
+	}
+
+	if err := r.run(); err == nil || !strings.Contains(err.Error(), "blackduck.yml") {
+		t.Errorf("Should have errored about the missing blackduck.yml, but was %v", err)
+	}
+	if fakeBlackduckApi.GetProjectByNameCallCount() != 0 {
+		t.Error("Should not have called Blackduck")
+	}
+}
@@ -1,44 +1,104 @@
 package shared
 
 import (
+	"archive/zip"
+	"bytes"
 	"crypto/tls"
 	"encoding/json"
+	"fmt"
 	"github.com/pkg/errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	ProjectCacheName = "./project.cache"
 	tokenPrefix      = "AUTHORIZATION_BEARER="
+	bdioContentType  = "application/ld+json"
+	bdio2ContentType = "application/vnd.blackducksoftware.intelligent-persistence-scan-1-ld-2+json"
+	bdio2Extension   = ".bdio"
+	bdio2Header      = "bdio-header.jsonld"
+	bdio2Name        = "https://blackducksoftware.github.io/bdio#hasName"
+	listLimit        = 1000
+	jsonContentType  = "application/json"
+	// uploadTimeout limits uploads, which take longer than other requests for large BDIO documents.
+	uploadTimeout = time.Hour
 )
 
+var ErrProjectNotFound = errors.New("no project matching the name")
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 //counterfeiter:generate . BlackduckApi
 type BlackduckApi interface {
 	GetProjectByName(source Source) (*Project, error)
 	GetProjectVersions(source Source, project *Project) ([]Version, error)
+	UploadBdio(source Source, file string) (string, error)
+	GetCodeLocations(source Source, version *Version) ([]CodeLocation, error)
+	CreateProject(source Source, project Project) (*Project, error)
+	CreateVersion(source Source, project *Project, version Version) (*Version, error)
+	UpdateVersion(source Source, version Version) error
+	DeleteVersion(source Source, version Version) error
+	GetComponentCount(source Source, version *Version) (int, error)
+	GetVulnerableComponents(source Source, version *Version) ([]VulnerableComponent, error)
+	UpdateRemediation(source Source, component VulnerableComponent, remediation Remediation) error
+	GetBomComponents(source Source, version *Version) ([]BomComponent, error)
+	UpdateBomComponent(source Source, component BomComponent) error
+	GetComponentOrigins(source Source, component BomComponent) ([]Origin, error)
+	GetLicenseUrl(source Source, name string) (string, error)
+	GetMatchedFiles(source Source, component BomComponent) ([]MatchedFile, error)
+	GetPolicyRules(source Source) ([]PolicyRule, error)
+	GetComponentPolicyRules(source Source, component BomComponent) ([]PolicyRule, error)
+	CreateNoticesReport(source Source, version *Version, format string) (Report, error)
+	GetReport(source Source, report Report) (Report, error)
+	GetReportContent(source Source, report Report) (string, error)
+	GetLicenseText(source Source, license BomLicense) (string, error)
+	GetCopyrights(source Source, origin BomOrigin) ([]Copyright, error)
+	GetRiskProfile(source Source, version *Version) (RiskProfile, error)
+	GetPolicyStatus(source Source, version *Version) (PolicyStatus, error)
+	GetUpgradeGuidance(source Source, component BomComponent) (UpgradeGuidance, error)
+	GetComponentVersionVulnerabilities(source Source, componentVersion string) ([]ComponentVulnerability, error)
+	UpdateProject(source Source, project Project) error
+	GetProjectGroupUrl(source Source, name string) (string, error)
+	GetProjectUserGroups(source Source, project *Project) ([]UserGroup, error)
+	AddProjectUserGroup(source Source, project *Project, name string) error
+	RemoveProjectUserGroup(source Source, group UserGroup) error
+	GetProjectTags(source Source, project *Project) ([]Tag, error)
+	AddProjectTag(source Source, project *Project, name string) error
+	RemoveProjectTag(source Source, project *Project, name string) error
+	GetProjectCustomFields(source Source, project *Project) ([]CustomField, error)
+	UpdateCustomField(source Source, field CustomField) error
 }
 
+// Blackduck authenticates once per user and reuses the token for all requests.
 type Blackduck struct {
-	client http.Client
+	client       http.Client
+	uploadClient http.Client
+	mutex        sync.Mutex
+	tokens       map[string]string
 }
 
 func NewBlackduck() Blackduck {
 	return Blackduck{
-		client: http.Client{Timeout: 30 * time.Second},
+		client:       http.Client{Timeout: 30 * time.Second},
+		uploadClient: http.Client{Timeout: uploadTimeout},
+		tokens:       map[string]string{},
 	}
 }
 
 func (b *Blackduck) GetProjectByName(source Source) (*Project, error) {
 	if cache, cached := projectIsCached(); cached {
 		var cachedProject Project
-		if err := json.Unmarshal(cache, &cachedProject); err == nil {
+		if err := json.Unmarshal(cache, &cachedProject); err == nil && cachedProject.Name == source.Name {
 			return &cachedProject, nil
 		}
 	}
@@ -47,7 +107,7 @@ func (b *Blackduck) GetProjectByName(source Source) (*Project, error) {
 		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 
-	token, err := authenticate(source)
+	token, err := b.token(source)
 	if err != nil {
 		return nil, errors.Wrap(err, "GetProjectByName")
 	}
@@ -70,7 +130,7 @@ func (b *Blackduck) GetProjectByName(source Source) (*Project, error) {
 	} else {
 		return nil, errors.Wrap(errors.Wrap(err, "Decode"),"GetProjectByName")
 	}
-	return nil, errors.Wrap(errors.New("no project matching the name"), "GetProjectByName")
+	return nil, errors.Wrap(ErrProjectNotFound, "GetProjectByName")
 }
 
 func (b *Blackduck) GetProjectVersions(source Source, project *Project) ([]Version, error) {
@@ -80,23 +140,808 @@ func (b *Blackduck) GetProjectVersions(source Source, project *Project) ([]Versi
 		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 
-	token, err := authenticate(source)
+	token, err := b.token(source)
 	if err != nil {
 		return nil, errors.Wrap(err, "GetProjectVersions")
 	}
 
-	req, _ := http.NewRequest(http.MethodGet, versionsLink, nil)
-	res, err := b.client.Do(authenticatedRequest(*req, token))
+	var versionList VersionList
+	for {
+		req, _ := http.NewRequest(http.MethodGet, withOffset(versionsLink, len(versionList.Versions)), nil)
+		res, err := b.client.Do(authenticatedRequest(*req, token))
+		if err != nil {
+			return nil, errors.Wrap(errors.Wrap(err, "GetProjectVersions"), "Versions")
+		}
+		var page VersionList
+		err = json.NewDecoder(res.Body).Decode(&page)
+		res.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(errors.Wrap(err, "Decode"), "GetProjectVersions")
+		}
+		versionList.Versions = append(versionList.Versions, page.Versions...)
+		if len(page.Versions) == 0 || len(versionList.Versions) >= page.TotalCount {
+			break
+		}
+	}
+	return sortVersionsChronologically(versionList), nil
+}
+
+// UploadBdio uploads a BDIO document, as it was generated by an offline scan, and returns the name of its code location.
+// BDIO2 archives (.bdio) are uploaded in chunks, while BDIO (.jsonld) documents are uploaded at once.
+// Several documents can name the same code location, which Blackduck merges them into.
+func (b *Blackduck) UploadBdio(source Source, file string) (string, error) {
+	if strings.HasSuffix(file, bdio2Extension) {
+		name, err := b.uploadBdio2(source, file)
+		return name, errors.Wrap(err, "UploadBdio")
+	}
+	name, err := bdioName(file)
 	if err != nil {
-		return nil, errors.Wrap(errors.Wrap(err, "GetProjectVersions"),"Versions")
+		return "", errors.Wrap(err, "UploadBdio")
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return "", errors.Wrap(err, "UploadBdio")
+	}
+	defer f.Close()
+	res, err := b.upload(source, http.MethodPost, source.GetApiUrl("scan/data"), bdioContentType, f, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "UploadBdio")
+	}
+	return name, res.Body.Close()
+}
+
+// bdioName returns the code location, which the bill of materials of a BDIO document is named after.
+func bdioName(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	var nodes []struct {
+		Type string `json:"@type"`
+		Name string `json:"spdx:name"`
+	}
+	if err := json.NewDecoder(f).Decode(&nodes); err != nil {
+		return "", errors.Wrap(err, "Decode")
+	}
+	for _, node := range nodes {
+		if node.Type == "BillOfMaterials" && len(node.Name) != 0 {
+			return node.Name, nil
+		}
+	}
+	return "", errors.New("missing the name of the code location in " + filepath.Base(file))
+}
+
+// bdio2HeaderName returns the code location, which the header of a BDIO2 archive is named after.
+// The name is either a plain value or an expanded JSON-LD value.
+func bdio2HeaderName(header []byte) (string, error) {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(header, &properties); err != nil {
+		return "", errors.Wrap(err, "Decode")
+	}
+	var name string
+	if err := json.Unmarshal(properties[bdio2Name], &name); err != nil {
+		var values []struct {
+			Value string `json:"@value"`
+		}
+		if err := json.Unmarshal(properties[bdio2Name], &values); err == nil && len(values) != 0 {
+			name = values[0].Value
+		}
+	}
+	if len(name) == 0 {
+		return "", errors.New("missing the name of the code location in " + bdio2Header)
+	}
+	return name, nil
+}
+
+// uploadBdio2 starts the upload with the header of the archive, appends every entry and finishes it.
+func (b *Blackduck) uploadBdio2(source Source, file string) (string, error) {
+	archive, err := zip.OpenReader(file)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+	var (
+		header  *zip.File
+		entries []*zip.File
+	)
+	for _, f := range archive.File {
+		if f.Name == bdio2Header {
+			header = f
+		} else if strings.HasSuffix(f.Name, ".jsonld") {
+			entries = append(entries, f)
+		}
+	}
+	if header == nil {
+		return "", errors.New("missing " + bdio2Header)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	content, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	headerContent, err := ioutil.ReadAll(content)
+	content.Close()
+	if err != nil {
+		return "", err
+	}
+	name, err := bdio2HeaderName(headerContent)
+	if err != nil {
+		return "", err
+	}
+	res, err := b.do(source, http.MethodPost, source.GetApiUrl("intelligent-persistence-scans"), bdio2ContentType, bytes.NewReader(headerContent), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "start")
+	}
+	res.Body.Close()
+	uploadUrl := res.Header.Get("Location")
+	if len(uploadUrl) == 0 {
+		return "", errors.New("start: missing upload location")
+	}
+
+	count := strconv.Itoa(len(entries))
+	for _, entry := range entries {
+		content, err := entry.Open()
+		if err != nil {
+			return "", err
+		}
+		res, err := b.upload(source, http.MethodPut, uploadUrl, bdio2ContentType, content, map[string]string{
+			"X-BD-MODE":           "append",
+			"X-BD-DOCUMENT-COUNT": count,
+		})
+		content.Close()
+		if err != nil {
+			return "", errors.Wrap(err, "append "+entry.Name)
+		}
+		res.Body.Close()
+	}
+
+	res, err = b.do(source, http.MethodPut, uploadUrl, bdio2ContentType, nil, map[string]string{
+		"X-BD-MODE":           "finish",
+		"X-BD-DOCUMENT-COUNT": count,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "finish")
+	}
+	return name, res.Body.Close()
+}
+
+// GetCodeLocations returns the code locations, which are mapped to the version.
+func (b *Blackduck) GetCodeLocations(source Source, version *Version) ([]CodeLocation, error) {
+	link := version.Meta.GetLinkFor("codelocations")
+	if len(link) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the code locations"), "GetCodeLocations")
+	}
+	var codeLocationList CodeLocationList
+	if err := b.get(source, withLimit(link), &codeLocationList); err != nil {
+		return nil, errors.Wrap(err, "GetCodeLocations")
+	}
+	return codeLocationList.CodeLocations, nil
+}
+
+type projectRequest struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description,omitempty"`
+	ProjectTier     int      `json:"projectTier,omitempty"`
+	ProjectGroup    string   `json:"projectGroup,omitempty"`
+	CloneCategories []string `json:"cloneCategories,omitempty"`
+}
+
+type versionRequest struct {
+	Name         string `json:"versionName"`
+	Phase        string `json:"phase"`
+	Distribution string `json:"distribution"`
+	Nickname     string `json:"nickname,omitempty"`
+	ReleasedOn   string `json:"releasedOn,omitempty"`
+	CloneFrom    string `json:"cloneFromReleaseUrl,omitempty"`
+}
+
+type namedList struct {
+	Items []struct {
+		Name string `json:"name"`
+		Meta Meta   `json:"_meta"`
+	} `json:"items"`
+}
+
+type bomComponentRequest struct {
+	Component        string              `json:"component"`
+	ComponentVersion string              `json:"componentVersion,omitempty"`
+	Ignored          bool                `json:"ignored"`
+	Usages           []string            `json:"usages,omitempty"`
+	Licenses         []bomLicenseRequest `json:"licenses,omitempty"`
+	Origins          []bomOriginRequest  `json:"origins,omitempty"`
+}
+
+type bomLicenseRequest struct {
+	License string `json:"license"`
+}
+
+type bomOriginRequest struct {
+	Origin string `json:"origin"`
+}
+
+type noticesReportRequest struct {
+	ReportFormat string   `json:"reportFormat"`
+	ReportType   string   `json:"reportType"`
+	Categories   []string `json:"categories"`
+}
+
+type userGroupRequest struct {
+	Group string `json:"group"`
+}
+
+type customFieldRequest struct {
+	Values []string `json:"values"`
+}
+
+// CreateProject creates the project and returns it as it was stored by Blackduck.
+// The project group can be given by its name.
+func (b *Blackduck) CreateProject(source Source, project Project) (*Project, error) {
+	group, err := b.projectGroupUrl(source, project.ProjectGroup)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateProject")
+	}
+	var created Project
+	err = b.create(source, source.GetApiUrl("projects"), projectRequest{
+		Name:            project.Name,
+		Description:     project.Description,
+		ProjectTier:     project.ProjectTier,
+		ProjectGroup:    group,
+		CloneCategories: project.CloneCategories,
+	}, &created)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateProject")
+	}
+	return &created, nil
+}
+
+// CreateVersion creates the version in the project and returns it as it was stored by Blackduck.
+func (b *Blackduck) CreateVersion(source Source, project *Project, version Version) (*Version, error) {
+	versionsLink := project.Meta.GetLinkFor("versions")
+	if len(versionsLink) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the versions"), "CreateVersion")
+	}
+	var created Version
+	if err := b.create(source, versionsLink, newVersionRequest(version), &created); err != nil {
+		return nil, errors.Wrap(err, "CreateVersion")
+	}
+	return &created, nil
+}
+
+// UpdateVersion replaces the settings of an existing version.
+func (b *Blackduck) UpdateVersion(source Source, version Version) error {
+	if len(version.Meta.Href) == 0 {
+		return errors.Wrap(errors.New("missing link to the version"), "UpdateVersion")
+	}
+	return errors.Wrap(b.send(source, http.MethodPut, version.Meta.Href, newVersionRequest(version)), "UpdateVersion")
+}
+
+func (b *Blackduck) DeleteVersion(source Source, version Version) error {
+	if len(version.Meta.Href) == 0 {
+		return errors.Wrap(errors.New("missing link to the version"), "DeleteVersion")
+	}
+	return errors.Wrap(b.send(source, http.MethodDelete, version.Meta.Href, nil), "DeleteVersion")
+}
+
+// GetComponentCount returns the number of components in the BOM of the version.
+func (b *Blackduck) GetComponentCount(source Source, version *Version) (int, error) {
+	link := version.Meta.GetLinkFor("components")
+	if len(link) == 0 {
+		return 0, errors.Wrap(errors.New("missing link to the components"), "GetComponentCount")
+	}
+	var page struct {
+		TotalCount int `json:"totalCount"`
+	}
+	if err := b.get(source, withQuery(link, "limit", "1"), &page); err != nil {
+		return 0, errors.Wrap(err, "GetComponentCount")
+	}
+	return page.TotalCount, nil
+}
+
+// GetVulnerableComponents returns every vulnerability of the components in the BOM of the version.
+func (b *Blackduck) GetVulnerableComponents(source Source, version *Version) ([]VulnerableComponent, error) {
+	link := version.Meta.GetLinkFor("vulnerable-components")
+	if len(link) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the vulnerable components"), "GetVulnerableComponents")
+	}
+	var vulnerableComponentList VulnerableComponentList
+	if err := b.get(source, withLimit(link), &vulnerableComponentList); err != nil {
+		return nil, errors.Wrap(err, "GetVulnerableComponents")
+	}
+	return vulnerableComponentList.VulnerableComponents, nil
+}
+
+// UpdateRemediation sets the remediation status and comment of the vulnerability of a component.
+func (b *Blackduck) UpdateRemediation(source Source, component VulnerableComponent, remediation Remediation) error {
+	if len(component.Meta.Href) == 0 {
+		return errors.Wrap(errors.New("missing link to the remediation"), "UpdateRemediation")
+	}
+	return errors.Wrap(b.send(source, http.MethodPut, component.Meta.Href, remediation), "UpdateRemediation")
+}
+
+// GetBomComponents returns the components in the BOM of the version, including ignored ones.
+func (b *Blackduck) GetBomComponents(source Source, version *Version) ([]BomComponent, error) {
+	link := version.Meta.GetLinkFor("components")
+	if len(link) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the components"), "GetBomComponents")
+	}
+	var bomComponentList BomComponentList
+	if err := b.get(source, withLimit(link), &bomComponentList); err != nil {
+		return nil, errors.Wrap(err, "GetBomComponents")
+	}
+	return bomComponentList.BomComponents, nil
+}
+
+// UpdateBomComponent replaces the adjustments of the component in the BOM: whether it's ignored, its usages, licenses and origins.
+func (b *Blackduck) UpdateBomComponent(source Source, component BomComponent) error {
+	if len(component.Meta.Href) == 0 {
+		return errors.Wrap(errors.New("missing link to the component"), "UpdateBomComponent")
+	}
+	request := bomComponentRequest{
+		Component:        component.Component,
+		ComponentVersion: component.ComponentVersion,
+		Ignored:          component.Ignored,
+		Usages:           component.Usages,
+	}
+	for _, license := range component.Licenses {
+		request.Licenses = append(request.Licenses, bomLicenseRequest{License: license.License})
+	}
+	for _, origin := range component.Origins {
+		request.Origins = append(request.Origins, bomOriginRequest{Origin: origin.Origin})
+	}
+	return errors.Wrap(b.send(source, http.MethodPut, component.Meta.Href, request), "UpdateBomComponent")
+}
+
+// GetComponentOrigins returns the known origins of the component version.
+func (b *Blackduck) GetComponentOrigins(source Source, component BomComponent) ([]Origin, error) {
+	if len(component.ComponentVersion) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the component version"), "GetComponentOrigins")
+	}
+	var originList OriginList
+	if err := b.get(source, withLimit(component.ComponentVersion+"/origins"), &originList); err != nil {
+		return nil, errors.Wrap(err, "GetComponentOrigins")
+	}
+	return originList.Origins, nil
+}
+
+// GetMatchedFiles returns the files, which the component was matched in, e.g. the manifest declaring it.
+func (b *Blackduck) GetMatchedFiles(source Source, component BomComponent) ([]MatchedFile, error) {
+	link := component.Meta.GetLinkFor("matched-files")
+	if len(link) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the matched files"), "GetMatchedFiles")
+	}
+	var matchedFileList MatchedFileList
+	if err := b.get(source, withLimit(link), &matchedFileList); err != nil {
+		return nil, errors.Wrap(err, "GetMatchedFiles")
+	}
+	return matchedFileList.MatchedFiles, nil
+}
+
+// GetPolicyRules returns every policy rule of Blackduck, including disabled ones.
+func (b *Blackduck) GetPolicyRules(source Source) ([]PolicyRule, error) {
+	var policyRuleList PolicyRuleList
+	if err := b.get(source, withLimit(source.GetApiUrl("policy-rules")), &policyRuleList); err != nil {
+		return nil, errors.Wrap(err, "GetPolicyRules")
+	}
+	return policyRuleList.PolicyRules, nil
+}
+
+// GetComponentPolicyRules returns the policy rules, which the component in the BOM violates.
+func (b *Blackduck) GetComponentPolicyRules(source Source, component BomComponent) ([]PolicyRule, error) {
+	link := component.Meta.GetLinkFor("policy-rules")
+	if len(link) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the policy rules"), "GetComponentPolicyRules")
+	}
+	var policyRuleList PolicyRuleList
+	if err := b.get(source, withLimit(link), &policyRuleList); err != nil {
+		return nil, errors.Wrap(err, "GetComponentPolicyRules")
+	}
+	return policyRuleList.PolicyRules, nil
+}
+
+// CreateNoticesReport starts generating the notices report of the version, including the copyrights of the components.
+func (b *Blackduck) CreateNoticesReport(source Source, version *Version, format string) (Report, error) {
+	link := version.Meta.GetLinkFor("licenseReports")
+	if len(link) == 0 {
+		return Report{}, errors.Wrap(errors.New("missing link to the license reports"), "CreateNoticesReport")
+	}
+	request := noticesReportRequest{
+		ReportFormat: format,
+		ReportType:   "VERSION_LICENSE",
+		Categories:   []string{"COPYRIGHT_TEXT"},
+	}
+	var report Report
+	return report, errors.Wrap(b.create(source, link, request, &report), "CreateNoticesReport")
+}
+
+// GetReport returns the current status of the report.
+func (b *Blackduck) GetReport(source Source, report Report) (Report, error) {
+	var current Report
+	return current, errors.Wrap(b.get(source, report.Meta.Href, &current), "GetReport")
+}
+
+// GetReportContent returns the content of a completed report.
+func (b *Blackduck) GetReportContent(source Source, report Report) (string, error) {
+	link := report.Meta.GetLinkFor("content")
+	if len(link) == 0 {
+		return "", errors.Wrap(errors.New("missing link to the content"), "GetReportContent")
+	}
+	var content ReportContent
+	if err := b.get(source, link, &content); err != nil {
+		return "", errors.Wrap(err, "GetReportContent")
+	}
+	var text strings.Builder
+	for _, file := range content.Files {
+		text.WriteString(file.FileContent)
+	}
+	return text.String(), nil
+}
+
+// GetLicenseText returns the full text of the license.
+func (b *Blackduck) GetLicenseText(source Source, license BomLicense) (string, error) {
+	if len(license.License) == 0 {
+		return "", errors.Wrap(errors.New("missing link to the license"), "GetLicenseText")
+	}
+	res, err := b.do(source, http.MethodGet, license.License+"/text", "", nil, map[string]string{"Accept": "text/plain"})
+	if err != nil {
+		return "", errors.Wrap(err, "GetLicenseText")
 	}
 	defer res.Body.Close()
+	text, err := ioutil.ReadAll(res.Body)
+	return string(text), errors.Wrap(err, "GetLicenseText")
+}
 
-	var versionList VersionList
-	if err := json.NewDecoder(res.Body).Decode(&versionList); err != nil {
-		return nil, errors.Wrap(errors.Wrap(err, "Decode"),"GetProjectVersions")
+// GetCopyrights returns the copyrights, which Blackduck found in the origin of a component.
+func (b *Blackduck) GetCopyrights(source Source, origin BomOrigin) ([]Copyright, error) {
+	if len(origin.Origin) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the origin"), "GetCopyrights")
 	}
-	return sortVersionsChronologically(versionList), nil
+	var copyrightList CopyrightList
+	if err := b.get(source, withLimit(origin.Origin+"/copyrights"), &copyrightList); err != nil {
+		return nil, errors.Wrap(err, "GetCopyrights")
+	}
+	return copyrightList.Copyrights, nil
+}
+
+// GetRiskProfile returns the number of components per risk category and severity of the version.
+func (b *Blackduck) GetRiskProfile(source Source, version *Version) (RiskProfile, error) {
+	link := version.Meta.GetLinkFor("riskProfile")
+	if len(link) == 0 {
+		return RiskProfile{}, errors.Wrap(errors.New("missing link to the risk profile"), "GetRiskProfile")
+	}
+	var profile RiskProfile
+	return profile, errors.Wrap(b.get(source, link, &profile), "GetRiskProfile")
+}
+
+// GetPolicyStatus returns whether the version violates any policy.
+func (b *Blackduck) GetPolicyStatus(source Source, version *Version) (PolicyStatus, error) {
+	link := version.Meta.GetLinkFor("policy-status")
+	if len(link) == 0 {
+		return PolicyStatus{}, errors.Wrap(errors.New("missing link to the policy status"), "GetPolicyStatus")
+	}
+	var status PolicyStatus
+	return status, errors.Wrap(b.get(source, link, &status), "GetPolicyStatus")
+}
+
+// GetUpgradeGuidance returns the versions, which Blackduck recommends to upgrade the component in the BOM to.
+func (b *Blackduck) GetUpgradeGuidance(source Source, component BomComponent) (UpgradeGuidance, error) {
+	if len(component.ComponentVersion) == 0 {
+		return UpgradeGuidance{}, errors.Wrap(errors.New("missing link to the component version"), "GetUpgradeGuidance")
+	}
+	var guidance UpgradeGuidance
+	return guidance, errors.Wrap(b.get(source, component.ComponentVersion+"/upgrade-guidance", &guidance), "GetUpgradeGuidance")
+}
+
+// GetComponentVersionVulnerabilities returns the known vulnerabilities of the component version with the href.
+func (b *Blackduck) GetComponentVersionVulnerabilities(source Source, componentVersion string) ([]ComponentVulnerability, error) {
+	var vulnerabilityList ComponentVulnerabilityList
+	if err := b.get(source, withLimit(componentVersion+"/vulnerabilities"), &vulnerabilityList); err != nil {
+		return nil, errors.Wrap(err, "GetComponentVersionVulnerabilities")
+	}
+	return vulnerabilityList.Vulnerabilities, nil
+}
+
+// GetLicenseUrl returns the href of the license with the name.
+func (b *Blackduck) GetLicenseUrl(source Source, name string) (string, error) {
+	href, err := b.findByName(source, "licenses", "license", name)
+	return href, errors.Wrap(err, "GetLicenseUrl")
+}
+
+// UpdateProject replaces the description, tier, group and clone categories of an existing project.
+func (b *Blackduck) UpdateProject(source Source, project Project) error {
+	if len(project.Meta.Href) == 0 {
+		return errors.Wrap(errors.New("missing link to the project"), "UpdateProject")
+	}
+	group, err := b.projectGroupUrl(source, project.ProjectGroup)
+	if err != nil {
+		return errors.Wrap(err, "UpdateProject")
+	}
+	return errors.Wrap(b.send(source, http.MethodPut, project.Meta.Href, projectRequest{
+		Name:            project.Name,
+		Description:     project.Description,
+		ProjectTier:     project.ProjectTier,
+		ProjectGroup:    group,
+		CloneCategories: project.CloneCategories,
+	}), "UpdateProject")
+}
+
+// GetProjectGroupUrl returns the href of the project group with the name.
+func (b *Blackduck) GetProjectGroupUrl(source Source, name string) (string, error) {
+	href, err := b.projectGroupUrl(source, name)
+	return href, errors.Wrap(err, "GetProjectGroupUrl")
+}
+
+// projectGroupUrl looks up the href of a project group, which is configured by its name.
+func (b *Blackduck) projectGroupUrl(source Source, group string) (string, error) {
+	if len(group) == 0 || strings.HasPrefix(group, "http") {
+		return group, nil
+	}
+	return b.findByName(source, "project-groups", "project group", group)
+}
+
+// GetProjectUserGroups returns the user groups, which are assigned to the project.
+func (b *Blackduck) GetProjectUserGroups(source Source, project *Project) ([]UserGroup, error) {
+	link := project.Meta.GetLinkFor("usergroups")
+	if len(link) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the user groups"), "GetProjectUserGroups")
+	}
+	var userGroupList UserGroupList
+	if err := b.get(source, withLimit(link), &userGroupList); err != nil {
+		return nil, errors.Wrap(err, "GetProjectUserGroups")
+	}
+	return userGroupList.UserGroups, nil
+}
+
+// AddProjectUserGroup assigns the user group with the name to the project.
+func (b *Blackduck) AddProjectUserGroup(source Source, project *Project, name string) error {
+	link := project.Meta.GetLinkFor("usergroups")
+	if len(link) == 0 {
+		return errors.Wrap(errors.New("missing link to the user groups"), "AddProjectUserGroup")
+	}
+	group, err := b.findByName(source, "usergroups", "user group", name)
+	if err != nil {
+		return errors.Wrap(err, "AddProjectUserGroup")
+	}
+	return errors.Wrap(b.send(source, http.MethodPost, link, userGroupRequest{Group: group}), "AddProjectUserGroup")
+}
+
+// RemoveProjectUserGroup removes the assignment of the user group from its project.
+func (b *Blackduck) RemoveProjectUserGroup(source Source, group UserGroup) error {
+	if len(group.Meta.Href) == 0 {
+		return errors.Wrap(errors.New("missing link to the user group"), "RemoveProjectUserGroup")
+	}
+	return errors.Wrap(b.send(source, http.MethodDelete, group.Meta.Href, nil), "RemoveProjectUserGroup")
+}
+
+func (b *Blackduck) GetProjectTags(source Source, project *Project) ([]Tag, error) {
+	link := project.Meta.GetLinkFor("project-tags")
+	if len(link) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the tags"), "GetProjectTags")
+	}
+	var tagList TagList
+	if err := b.get(source, withLimit(link), &tagList); err != nil {
+		return nil, errors.Wrap(err, "GetProjectTags")
+	}
+	return tagList.Tags, nil
+}
+
+func (b *Blackduck) AddProjectTag(source Source, project *Project, name string) error {
+	link := project.Meta.GetLinkFor("project-tags")
+	if len(link) == 0 {
+		return errors.Wrap(errors.New("missing link to the tags"), "AddProjectTag")
+	}
+	return errors.Wrap(b.send(source, http.MethodPost, link, Tag{Name: name}), "AddProjectTag")
+}
+
+func (b *Blackduck) RemoveProjectTag(source Source, project *Project, name string) error {
+	link := project.Meta.GetLinkFor("project-tags")
+	if len(link) == 0 {
+		return errors.Wrap(errors.New("missing link to the tags"), "RemoveProjectTag")
+	}
+	return errors.Wrap(b.send(source, http.MethodDelete, link+"/"+url.PathEscape(name), nil), "RemoveProjectTag")
+}
+
+func (b *Blackduck) GetProjectCustomFields(source Source, project *Project) ([]CustomField, error) {
+	link := project.Meta.GetLinkFor("custom-fields")
+	if len(link) == 0 {
+		return nil, errors.Wrap(errors.New("missing link to the custom fields"), "GetProjectCustomFields")
+	}
+	var customFieldList CustomFieldList
+	if err := b.get(source, withLimit(link), &customFieldList); err != nil {
+		return nil, errors.Wrap(err, "GetProjectCustomFields")
+	}
+	return customFieldList.CustomFields, nil
+}
+
+// UpdateCustomField replaces the values of the custom field.
+func (b *Blackduck) UpdateCustomField(source Source, field CustomField) error {
+	if len(field.Meta.Href) == 0 {
+		return errors.Wrap(errors.New("missing link to the custom field"), "UpdateCustomField")
+	}
+	return errors.Wrap(b.send(source, http.MethodPut, field.Meta.Href, customFieldRequest{Values: field.Values}), "UpdateCustomField")
+}
+
+func newVersionRequest(version Version) versionRequest {
+	return versionRequest{
+		Name:         version.Name,
+		Phase:        version.Phase,
+		Distribution: version.Distribution,
+		Nickname:     version.Nickname,
+		ReleasedOn:   version.ReleasedOn,
+		CloneFrom:    version.CloneFrom,
+	}
+}
+
+// findByName returns the href of the named item below the api endpoint, e.g. of a project or user group.
+func (b *Blackduck) findByName(source Source, endpoint string, kind string, name string) (string, error) {
+	var list namedList
+	if err := b.get(source, source.GetApiUrl(endpoint)+"?q=name:"+url.QueryEscape(name), &list); err != nil {
+		return "", err
+	}
+	for _, item := range list.Items {
+		if item.Name == name {
+			return item.Meta.Href, nil
+		}
+	}
+	return "", fmt.Errorf("no %v matching %v", kind, name)
+}
+
+// get decodes the response of an authenticated GET request.
+func (b *Blackduck) get(source Source, target string, result interface{}) error {
+	res, err := b.do(source, http.MethodGet, target, "", nil, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return errors.Wrap(json.NewDecoder(res.Body).Decode(result), "Decode")
+}
+
+// send encodes the request as json, if there is one.
+func (b *Blackduck) send(source Source, method string, target string, request interface{}) error {
+	var body io.Reader
+	contentType := ""
+	if request != nil {
+		encoded, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body, contentType = bytes.NewReader(encoded), jsonContentType
+	}
+	res, err := b.do(source, method, target, contentType, body, nil)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// create posts the request and reads the created resource from the location, which Blackduck responds with.
+func (b *Blackduck) create(source Source, target string, request interface{}, created interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	res, err := b.do(source, http.MethodPost, target, jsonContentType, bytes.NewReader(body), nil)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	location := res.Header.Get("Location")
+	if len(location) == 0 {
+		return errors.New("missing location of " + target)
+	}
+	return b.get(source, location, created)
+}
+
+// do sends an authenticated request and errors on any unsuccessful response.
+func (b *Blackduck) do(source Source, method string, target string, contentType string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	return b.request(source, &b.client, method, target, contentType, body, headers)
+}
+
+// upload sends the request like do, but with the longer timeout of uploads.
+func (b *Blackduck) upload(source Source, method string, target string, contentType string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	return b.request(source, &b.uploadClient, method, target, contentType, body, headers)
+}
+
+// request authenticates again, when the token expired. The request is only repeated, when its body can be read again.
+func (b *Blackduck) request(source Source, client *http.Client, method string, target string, contentType string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	if source.Insecure {
+		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	try := func() (*http.Response, error) {
+		token, err := b.token(source)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(method, target, body)
+		if err != nil {
+			return nil, err
+		}
+		if len(contentType) != 0 {
+			req.Header.Set("Content-Type", contentType)
+		}
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		return client.Do(authenticatedRequest(*req, token))
+	}
+	res, err := try()
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		b.forgetToken(source)
+		seeker, seekable := body.(io.Seeker)
+		if body == nil || seekable {
+			res.Body.Close()
+			if seekable {
+				if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+					return nil, err
+				}
+			}
+			if res, err = try(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if res.StatusCode >= 300 {
+		defer res.Body.Close()
+		message, _ := ioutil.ReadAll(io.LimitReader(res.Body, 512))
+		return nil, fmt.Errorf("%v %v: %v %v", method, target, res.Status, strings.TrimSpace(string(message)))
+	}
+	return res, nil
+}
+
+// withLimit makes sure, that lists aren't cut off by the default page size of Blackduck.
+func withLimit(link string) string {
+	return withQuery(link, "limit", strconv.Itoa(listLimit))
+}
+
+// withOffset pages through lists, which are longer than the limit.
+func withOffset(link string, offset int) string {
+	return withQuery(withLimit(link), "offset", strconv.Itoa(offset))
+}
+
+func withQuery(link string, key string, value string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// token returns the token of the user, which is only authenticated on the first request.
+func (b *Blackduck) token(source Source) (string, error) {
+	key := tokenKey(source)
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if token, found := b.tokens[key]; found {
+		return token, nil
+	}
+	token, err := authenticate(source)
+	if err != nil {
+		return "", err
+	}
+	if b.tokens == nil {
+		b.tokens = map[string]string{}
+	}
+	b.tokens[key] = token
+	return token, nil
+}
+
+func (b *Blackduck) forgetToken(source Source) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.tokens, tokenKey(source))
+}
+
+func tokenKey(source Source) string {
+	return source.Url + "\x00" + source.Username + "\x00" + source.Password
 }
 
 func authenticate(source Source) (token string, err error) {
@@ -1,12 +1,14 @@
 package shared
 
 import (
+	"archive/zip"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -109,6 +111,35 @@ func TestGetProjectCachesTheResult(t *testing.T) {
 	clean(t)
 }
 
+func TestGetProjectIgnoresTheCacheOfOtherProjects(t *testing.T) {
+	var calledProjects int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			calledProjects++
+			b, err := ioutil.ReadFile("testdata/projects.json")
+			require.NoError(t, err)
+			_, err = w.Write(b)
+			require.NoError(t, err)
+		}
+	}))
+	defer ts.Close()
+	writeProjectToCache(Project{Name: "other"})
+
+	r := NewBlackduck()
+	project, err := r.GetProjectByName(Source{
+		Url:  ts.URL,
+		Name: "project1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "project1", project.Name)
+	require.Equal(t, 1, calledProjects)
+
+	clean(t)
+}
+
 func TestGetProjectErrorsWhenResponseIsCorrupted(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -209,3 +240,777 @@ func TestQueriesForTheLatestVersionsInChronologicalOrder(t *testing.T) {
 	require.Equal(t, "2019-04-20 09:12:48.511 +0000 UTC", refs[1].Updated.String())
 	require.True(t, calledVersions)
 }
+
+func TestPagesThroughAllVersions(t *testing.T) {
+	var offsets []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		require.Equal(t, "1000", r.URL.Query().Get("limit"))
+		if offset == "0" {
+			_, _ = w.Write([]byte(`{"totalCount":2,"items":[{"versionName":"2","settingUpdatedAt":"2019-04-20T09:12:48.511Z"}]}`))
+		} else {
+			_, _ = w.Write([]byte(`{"totalCount":2,"items":[{"versionName":"1","settingUpdatedAt":"2019-04-18T09:12:48.511Z"}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	r := NewBlackduck()
+	versions, err := r.GetProjectVersions(Source{Url: ts.URL}, &Project{
+		Meta: Meta{Links: []Link{{Rel: "versions", Href: ts.URL + "/api/projects/1/versions"}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"0", "1"}, offsets)
+	require.Len(t, versions, 2)
+	require.Equal(t, "1", versions[0].Name)
+	require.Equal(t, "2", versions[1].Name)
+}
+
+func TestUploadsBdioDocuments(t *testing.T) {
+	var uploaded bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		uploaded = true
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/scan/data", r.RequestURI)
+		require.Equal(t, "AUTHORIZATION_BEARER=TOKEN", r.Header.Get("Cookie"))
+		require.Equal(t, "application/ld+json", r.Header.Get("Content-Type"))
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, `[{"@id":"uuid:1","@type":"BillOfMaterials","spdx:name":"project1 bom"}]`, string(b))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+	file := filepath.Join(t.TempDir(), "scan.jsonld")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`[{"@id":"uuid:1","@type":"BillOfMaterials","spdx:name":"project1 bom"}]`), 0644))
+
+	r := NewBlackduck()
+	name, err := r.UploadBdio(Source{Url: ts.URL, Name: "project1"}, file)
+	require.NoError(t, err)
+	require.Equal(t, "project1 bom", name)
+	require.True(t, uploaded)
+}
+
+func TestErrorsWhenTheBdioUploadIsRejected(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessage":"invalid bdio"}`))
+	}))
+	defer ts.Close()
+	file := filepath.Join(t.TempDir(), "scan.jsonld")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`[{"@id":"uuid:1","@type":"BillOfMaterials","spdx:name":"project1 bom"}]`), 0644))
+
+	r := NewBlackduck()
+	_, err := r.UploadBdio(Source{Url: ts.URL, Name: "project1"}, file)
+	require.EqualError(t, err, "UploadBdio: POST "+ts.URL+`/api/scan/data: 400 Bad Request {"errorMessage":"invalid bdio"}`)
+}
+
+func TestUploadsBdio2ArchivesInChunks(t *testing.T) {
+	var requests []string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		require.Equal(t, "application/vnd.blackducksoftware.intelligent-persistence-scan-1-ld-2+json", r.Header.Get("Content-Type"))
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, strings.Join([]string{r.Method, r.RequestURI, r.Header.Get("X-BD-MODE"), r.Header.Get("X-BD-DOCUMENT-COUNT"), string(b)}, " "))
+		if r.Method == http.MethodPost {
+			w.Header().Set("Location", ts.URL+"/api/intelligent-persistence-scans/1")
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+	file := filepath.Join(t.TempDir(), "scan.bdio")
+	header := `{"@id":"uuid:1","https://blackducksoftware.github.io/bdio#hasName":[{"@value":"project1 bom"}]}`
+	writeArchive(t, file, map[string]string{
+		"bdio-entry-01.jsonld": "entry1",
+		"bdio-header.jsonld":   header,
+		"bdio-entry-00.jsonld": "entry0",
+	})
+
+	r := NewBlackduck()
+	name, err := r.UploadBdio(Source{Url: ts.URL, Name: "project1"}, file)
+	require.NoError(t, err)
+	require.Equal(t, "project1 bom", name)
+	require.Equal(t, []string{
+		"POST /api/intelligent-persistence-scans   " + header,
+		"PUT /api/intelligent-persistence-scans/1 append 2 entry0",
+		"PUT /api/intelligent-persistence-scans/1 append 2 entry1",
+		"PUT /api/intelligent-persistence-scans/1 finish 2 ",
+	}, requests)
+}
+
+func TestErrorsWhenTheBdio2ArchiveHasNoHeader(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "scan.bdio")
+	writeArchive(t, file, map[string]string{"bdio-entry-00.jsonld": "entry0"})
+
+	r := NewBlackduck()
+	_, err := r.UploadBdio(Source{Url: "http://localhost", Name: "project1"}, file)
+	require.EqualError(t, err, "UploadBdio: missing bdio-header.jsonld")
+}
+
+func TestErrorsWhenTheBdioDocumentsDontNameTheirCodeLocation(t *testing.T) {
+	dir := t.TempDir()
+	document := filepath.Join(dir, "scan.jsonld")
+	require.NoError(t, ioutil.WriteFile(document, []byte(`[{"@id":"uuid:1"}]`), 0644))
+	archive := filepath.Join(dir, "scan.bdio")
+	writeArchive(t, archive, map[string]string{"bdio-header.jsonld": `{"@id":"uuid:1"}`})
+
+	r := NewBlackduck()
+	_, err := r.UploadBdio(Source{Url: "http://localhost", Name: "project1"}, document)
+	require.EqualError(t, err, "UploadBdio: missing the name of the code location in scan.jsonld")
+	_, err = r.UploadBdio(Source{Url: "http://localhost", Name: "project1"}, archive)
+	require.EqualError(t, err, "UploadBdio: missing the name of the code location in bdio-header.jsonld")
+}
+
+func TestGetsTheCodeLocationsOfAVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		require.Equal(t, "/api/projects/1/versions/2/codelocations?limit=1000", r.RequestURI)
+		_, _ = w.Write([]byte(`{"totalCount":1,"items":[{"name":"project1/1.0.0 bom","updatedAt":"2019-04-20T09:12:48.511Z"}]}`))
+	}))
+	defer ts.Close()
+	version := Version{Meta: Meta{Links: []Link{{Rel: "codelocations", Href: ts.URL + "/api/projects/1/versions/2/codelocations"}}}}
+
+	r := NewBlackduck()
+	codeLocations, err := r.GetCodeLocations(Source{Url: ts.URL, Name: "project1"}, &version)
+	require.NoError(t, err)
+	require.Len(t, codeLocations, 1)
+	require.Equal(t, "project1/1.0.0 bom", codeLocations[0].Name)
+	require.Equal(t, "2019-04-20 09:12:48.511 +0000 UTC", codeLocations[0].Updated.String())
+}
+
+func writeArchive(t *testing.T, file string, entries map[string]string) {
+	f, err := os.Create(file)
+	require.NoError(t, err)
+	defer f.Close()
+	archive := zip.NewWriter(f)
+	for name, content := range entries {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+}
+
+func TestCreatesProjectsInTheirGroup(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check"):
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.RequestURI == "/api/project-groups?q=name:team":
+			_, _ = w.Write([]byte(`{"items":[{"name":"team-a","_meta":{"href":"` + ts.URL + `/api/project-groups/2"}},` +
+				`{"name":"team","_meta":{"href":"` + ts.URL + `/api/project-groups/1"}}]}`))
+		case r.Method == http.MethodPost && r.RequestURI == "/api/projects":
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			b, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"project1","description":"description","projectTier":2,`+
+				`"projectGroup":"`+ts.URL+`/api/project-groups/1","cloneCategories":["VULN_DATA"]}`, string(b))
+			w.Header().Set("Location", ts.URL+"/api/projects/1")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.RequestURI == "/api/projects/1":
+			_, _ = w.Write([]byte(`{"name":"project1","projectTier":2,"_meta":{"href":"` + ts.URL + `/api/projects/1"}}`))
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	r := NewBlackduck()
+	project, err := r.CreateProject(Source{Url: ts.URL, Name: "project1"}, Project{
+		Name:            "project1",
+		Description:     "description",
+		ProjectTier:     2,
+		ProjectGroup:    "team",
+		CloneCategories: []string{"VULN_DATA"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "project1", project.Name)
+	require.Equal(t, ts.URL+"/api/projects/1", project.Meta.Href)
+}
+
+func TestErrorsWhenTheProjectGroupDoesNotExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	defer ts.Close()
+
+	r := NewBlackduck()
+	_, err := r.CreateProject(Source{Url: ts.URL, Name: "project1"}, Project{Name: "project1", ProjectGroup: "team"})
+	require.EqualError(t, err, "CreateProject: no project group matching team")
+}
+
+func TestUpdatesTheProjectWithItsGroup(t *testing.T) {
+	var updated bool
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check"):
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.RequestURI == "/api/project-groups?q=name:team":
+			_, _ = w.Write([]byte(`{"items":[{"name":"team","_meta":{"href":"` + ts.URL + `/api/project-groups/1"}}]}`))
+		case r.Method == http.MethodPut && r.RequestURI == "/api/projects/1":
+			updated = true
+			b, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"project1","projectTier":2,"projectGroup":"`+ts.URL+`/api/project-groups/1"}`, string(b))
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	r := NewBlackduck()
+	require.NoError(t, r.UpdateProject(Source{Url: ts.URL, Name: "project1"}, Project{
+		Name:         "project1",
+		ProjectTier:  2,
+		ProjectGroup: "team",
+		Meta:         Meta{Href: ts.URL + "/api/projects/1"},
+	}))
+	require.True(t, updated)
+}
+
+func TestCreatesVersionsInTheProject(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check"):
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.RequestURI == "/api/projects/1/versions":
+			b, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"versionName":"1.0.0","phase":"RELEASED","distribution":"SAAS","nickname":"nick",`+
+				`"releasedOn":"2020-01-31T00:00:00.000Z"}`, string(b))
+			w.Header().Set("Location", ts.URL+"/api/projects/1/versions/2")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.RequestURI == "/api/projects/1/versions/2":
+			_, _ = w.Write([]byte(`{"versionName":"1.0.0","phase":"RELEASED","_meta":{"href":"` + ts.URL + `/api/projects/1/versions/2"}}`))
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	project := Project{Name: "project1", Meta: Meta{Links: []Link{{Rel: "versions", Href: ts.URL + "/api/projects/1/versions"}}}}
+
+	r := NewBlackduck()
+	version, err := r.CreateVersion(Source{Url: ts.URL, Name: "project1"}, &project, Version{
+		Name:         "1.0.0",
+		Phase:        "RELEASED",
+		Distribution: "SAAS",
+		Nickname:     "nick",
+		ReleasedOn:   "2020-01-31T00:00:00.000Z",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", version.Name)
+	require.Equal(t, ts.URL+"/api/projects/1/versions/2", version.Meta.Href)
+}
+
+func TestUpdatesVersions(t *testing.T) {
+	var updated bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		updated = true
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/api/projects/1/versions/2", r.RequestURI)
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"versionName":"1.0.0","phase":"ARCHIVED","distribution":"EXTERNAL"}`, string(b))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	r := NewBlackduck()
+	require.NoError(t, r.UpdateVersion(Source{Url: ts.URL, Name: "project1"}, Version{
+		Name:         "1.0.0",
+		Phase:        "ARCHIVED",
+		Distribution: "EXTERNAL",
+		Meta:         Meta{Href: ts.URL + "/api/projects/1/versions/2"},
+	}))
+	require.True(t, updated)
+}
+
+func TestAssignsUserGroupsByName(t *testing.T) {
+	var assigned bool
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check"):
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.RequestURI == "/api/usergroups?q=name:team-billing":
+			_, _ = w.Write([]byte(`{"items":[{"name":"team-billing","_meta":{"href":"` + ts.URL + `/api/usergroups/1"}}]}`))
+		case r.Method == http.MethodPost && r.RequestURI == "/api/projects/1/usergroups":
+			assigned = true
+			b, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"group":"`+ts.URL+`/api/usergroups/1"}`, string(b))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	project := Project{Name: "project1", Meta: Meta{Links: []Link{{Rel: "usergroups", Href: ts.URL + "/api/projects/1/usergroups"}}}}
+
+	r := NewBlackduck()
+	require.NoError(t, r.AddProjectUserGroup(Source{Url: ts.URL, Name: "project1"}, &project, "team-billing"))
+	require.True(t, assigned)
+}
+
+func TestManagesTheTagsOfProjects(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.RequestURI+" "+string(b)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"items":[{"name":"java"}]}`))
+		}
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	project := Project{Name: "project1", Meta: Meta{Links: []Link{{Rel: "project-tags", Href: ts.URL + "/api/projects/1/tags"}}}}
+
+	r := NewBlackduck()
+	tags, err := r.GetProjectTags(source, &project)
+	require.NoError(t, err)
+	require.Equal(t, []Tag{{Name: "java"}}, tags)
+	require.NoError(t, r.AddProjectTag(source, &project, "billing"))
+	require.NoError(t, r.RemoveProjectTag(source, &project, "old stuff"))
+	require.Equal(t, []string{
+		"GET /api/projects/1/tags?limit=1000",
+		`POST /api/projects/1/tags {"name":"billing"}`,
+		"DELETE /api/projects/1/tags/old%20stuff",
+	}, requests)
+}
+
+func TestUpdatesTheValuesOfCustomFields(t *testing.T) {
+	var updated bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		updated = true
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/api/projects/1/custom-fields/3", r.RequestURI)
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"values":["alice"]}`, string(b))
+	}))
+	defer ts.Close()
+
+	r := NewBlackduck()
+	require.NoError(t, r.UpdateCustomField(Source{Url: ts.URL, Name: "project1"}, CustomField{
+		Label:  "Owner",
+		Values: []string{"alice"},
+		Meta:   Meta{Href: ts.URL + "/api/projects/1/custom-fields/3"},
+	}))
+	require.True(t, updated)
+}
+
+func TestCountsTheComponentsOfAVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		require.Equal(t, "/api/projects/1/versions/2/components?limit=1", r.RequestURI)
+		_, _ = w.Write([]byte(`{"totalCount":42,"items":[{"componentName":"log4j"}]}`))
+	}))
+	defer ts.Close()
+	version := Version{Meta: Meta{Links: []Link{{Rel: "components", Href: ts.URL + "/api/projects/1/versions/2/components"}}}}
+
+	r := NewBlackduck()
+	count, err := r.GetComponentCount(Source{Url: ts.URL, Name: "project1"}, &version)
+	require.NoError(t, err)
+	require.Equal(t, 42, count)
+}
+
+func TestCountsTheComponentsOfAVersionWithAFilteredLink(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		require.Equal(t, "1", r.URL.Query().Get("limit"))
+		require.Equal(t, "bomMatchType:file_dependency_direct", r.URL.Query().Get("filter"))
+		_, _ = w.Write([]byte(`{"totalCount":42,"items":[{"componentName":"log4j"}]}`))
+	}))
+	defer ts.Close()
+	version := Version{Meta: Meta{Links: []Link{{Rel: "components", Href: ts.URL + "/api/projects/1/versions/2/components?filter=bomMatchType%3Afile_dependency_direct"}}}}
+
+	r := NewBlackduck()
+	count, err := r.GetComponentCount(Source{Url: ts.URL, Name: "project1"}, &version)
+	require.NoError(t, err)
+	require.Equal(t, 42, count)
+}
+
+func TestRemediatesVulnerableComponents(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.RequestURI+" "+string(b)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"items":[{"componentName":"log4j","componentVersionName":"2.14.1",` +
+				`"vulnerabilityWithRemediation":{"vulnerabilityName":"CVE-2021-44228","severity":"CRITICAL","remediationStatus":"NEW"},` +
+				`"_meta":{"href":"` + "http://" + r.Host + `/api/remediation"}}]}`))
+		}
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	version := Version{Meta: Meta{Links: []Link{{Rel: "vulnerable-components", Href: ts.URL + "/api/projects/1/versions/2/vulnerable-bom-components"}}}}
+
+	r := NewBlackduck()
+	components, err := r.GetVulnerableComponents(source, &version)
+	require.NoError(t, err)
+	require.Len(t, components, 1)
+	require.Equal(t, VulnerabilityWithRemediation{Name: "CVE-2021-44228", Severity: "CRITICAL", RemediationStatus: "NEW"}, components[0].Vulnerability)
+	require.NoError(t, r.UpdateRemediation(source, components[0], Remediation{Status: "NOT_AFFECTED", Comment: "removed"}))
+	require.Equal(t, []string{
+		"GET /api/projects/1/versions/2/vulnerable-bom-components?limit=1000",
+		`PUT /api/remediation {"remediationStatus":"NOT_AFFECTED","comment":"removed"}`,
+	}, requests)
+}
+
+func TestAdjustsBomComponents(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.RequestURI+" "+string(b)))
+		switch {
+		case r.Method != http.MethodGet:
+		case strings.HasPrefix(r.URL.Path, "/api/licenses"):
+			_, _ = w.Write([]byte(`{"items":[{"name":"Apache License 2.0","_meta":{"href":"http://` + r.Host + `/api/licenses/apache"}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/origins"):
+			_, _ = w.Write([]byte(`{"items":[{"originName":"maven","originId":"org.apache.commons:commons-text:1.9",` +
+				`"_meta":{"href":"http://` + r.Host + `/api/components/1/versions/2/origins/3"}}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"items":[{"componentName":"commons-text","componentVersionName":"1.9",` +
+				`"component":"http://` + r.Host + `/api/components/1","componentVersion":"http://` + r.Host + `/api/components/1/versions/2",` +
+				`"usages":["DYNAMICALLY_LINKED"],"licenses":[{"licenseDisplay":"Unknown License","license":"http://` + r.Host + `/api/licenses/unknown"}],` +
+				`"_meta":{"href":"http://` + r.Host + `/api/bom/commons-text"}}]}`))
+		}
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	version := Version{Meta: Meta{Links: []Link{{Rel: "components", Href: ts.URL + "/api/projects/1/versions/2/components"}}}}
+
+	r := NewBlackduck()
+	components, err := r.GetBomComponents(source, &version)
+	require.NoError(t, err)
+	require.Len(t, components, 1)
+	require.Equal(t, []BomLicense{{LicenseDisplay: "Unknown License", License: ts.URL + "/api/licenses/unknown"}}, components[0].Licenses)
+	origins, err := r.GetComponentOrigins(source, components[0])
+	require.NoError(t, err)
+	require.Equal(t, []Origin{{OriginName: "maven", OriginId: "org.apache.commons:commons-text:1.9", Meta: Meta{Href: ts.URL + "/api/components/1/versions/2/origins/3"}}}, origins)
+	license, err := r.GetLicenseUrl(source, "Apache License 2.0")
+	require.NoError(t, err)
+	require.Equal(t, ts.URL+"/api/licenses/apache", license)
+
+	component := components[0]
+	component.Ignored = true
+	component.Licenses = []BomLicense{{License: license}}
+	component.Origins = []BomOrigin{{Origin: origins[0].Meta.Href}}
+	require.NoError(t, r.UpdateBomComponent(source, component))
+	require.Equal(t, []string{
+		"GET /api/projects/1/versions/2/components?limit=1000",
+		"GET /api/components/1/versions/2/origins?limit=1000",
+		"GET /api/licenses?q=name:Apache+License+2.0",
+		`PUT /api/bom/commons-text {"component":"` + ts.URL + `/api/components/1","componentVersion":"` + ts.URL + `/api/components/1/versions/2",` +
+			`"ignored":true,"usages":["DYNAMICALLY_LINKED"],"licenses":[{"license":"` + ts.URL + `/api/licenses/apache"}],` +
+			`"origins":[{"origin":"` + ts.URL + `/api/components/1/versions/2/origins/3"}]}`,
+	}, requests)
+}
+
+func TestGetsTheMatchedFilesOfComponents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		require.Equal(t, "/api/bom/log4j/matched-files?limit=1000", r.RequestURI)
+		_, _ = w.Write([]byte(`{"items":[{"filePath":{"path":"/pom.xml","fileName":"pom.xml","compositePathContext":"/pom.xml"}}]}`))
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	component := BomComponent{Meta: Meta{Links: []Link{{Rel: "matched-files", Href: ts.URL + "/api/bom/log4j/matched-files"}}}}
+
+	r := NewBlackduck()
+	files, err := r.GetMatchedFiles(source, component)
+	require.NoError(t, err)
+	require.Equal(t, []MatchedFile{{FilePath: FilePath{Path: "/pom.xml", FileName: "pom.xml", CompositePathContext: "/pom.xml"}}}, files)
+	_, err = r.GetMatchedFiles(source, BomComponent{})
+	require.EqualError(t, err, "GetMatchedFiles: missing link to the matched files")
+}
+
+func TestGetsPolicyRules(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		requests = append(requests, r.RequestURI)
+		_, _ = w.Write([]byte(`{"items":[{"name":"No Copyleft","severity":"MAJOR","enabled":true,"policyApprovalStatus":"IN_VIOLATION"}]}`))
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	component := BomComponent{Meta: Meta{Links: []Link{{Rel: "policy-rules", Href: ts.URL + "/api/bom/mysql/policy-rules"}}}}
+
+	r := NewBlackduck()
+	rules, err := r.GetPolicyRules(source)
+	require.NoError(t, err)
+	require.Equal(t, []PolicyRule{{Name: "No Copyleft", Severity: "MAJOR", Enabled: true, PolicyApprovalStatus: "IN_VIOLATION"}}, rules)
+	_, err = r.GetComponentPolicyRules(source, component)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/api/policy-rules?limit=1000", "/api/bom/mysql/policy-rules?limit=1000"}, requests)
+	_, err = r.GetComponentPolicyRules(source, BomComponent{})
+	require.EqualError(t, err, "GetComponentPolicyRules: missing link to the policy rules")
+}
+
+func TestGetsTheNoticesReport(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.RequestURI+" "+string(b)))
+		switch r.URL.Path {
+		case "/api/versions/2/license-reports":
+			w.Header().Set("Location", "http://"+r.Host+"/api/reports/3")
+			w.WriteHeader(http.StatusCreated)
+		case "/api/reports/3":
+			_, _ = w.Write([]byte(`{"reportFormat":"TEXT","status":"COMPLETED","_meta":{"href":"http://` + r.Host + `/api/reports/3",` +
+				`"links":[{"rel":"content","href":"http://` + r.Host + `/api/reports/3/contents"}]}}`))
+		case "/api/reports/3/contents":
+			_, _ = w.Write([]byte(`{"reportContent":[{"fileName":"notices.txt","fileContent":"notices"}]}`))
+		case "/api/licenses/apache/text":
+			require.Equal(t, "text/plain", r.Header.Get("Accept"))
+			_, _ = w.Write([]byte("Apache text"))
+		case "/api/origins/1/copyrights":
+			_, _ = w.Write([]byte(`{"items":[{"updatedCopyright":"Copyright Apache","active":true}]}`))
+		}
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	version := Version{Meta: Meta{Links: []Link{{Rel: "licenseReports", Href: ts.URL + "/api/versions/2/license-reports"}}}}
+
+	r := NewBlackduck()
+	report, err := r.CreateNoticesReport(source, &version, ReportFormatText)
+	require.NoError(t, err)
+	require.Equal(t, ReportStatusCompleted, report.Status)
+	report, err = r.GetReport(source, report)
+	require.NoError(t, err)
+	content, err := r.GetReportContent(source, report)
+	require.NoError(t, err)
+	require.Equal(t, "notices", content)
+	text, err := r.GetLicenseText(source, BomLicense{License: ts.URL + "/api/licenses/apache"})
+	require.NoError(t, err)
+	require.Equal(t, "Apache text", text)
+	copyrights, err := r.GetCopyrights(source, BomOrigin{Origin: ts.URL + "/api/origins/1"})
+	require.NoError(t, err)
+	require.Equal(t, []Copyright{{Text: "Copyright Apache", Active: true}}, copyrights)
+	require.Equal(t, []string{
+		`POST /api/versions/2/license-reports {"reportFormat":"TEXT","reportType":"VERSION_LICENSE","categories":["COPYRIGHT_TEXT"]}`,
+		"GET /api/reports/3",
+		"GET /api/reports/3",
+		"GET /api/reports/3/contents",
+		"GET /api/licenses/apache/text",
+		"GET /api/origins/1/copyrights?limit=1000",
+	}, requests)
+}
+
+func TestGetsTheRiskProfileAndPolicyStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch r.RequestURI {
+		case "/api/versions/2/risk-profile":
+			_, _ = w.Write([]byte(`{"categories":{"VULNERABILITY":{"CRITICAL":1,"HIGH":0},"LICENSE":{"HIGH":2}}}`))
+		case "/api/versions/2/policy-status":
+			_, _ = w.Write([]byte(`{"overallStatus":"IN_VIOLATION","componentVersionStatusCounts":[{"name":"IN_VIOLATION","value":3}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	version := Version{Meta: Meta{Links: []Link{
+		{Rel: "riskProfile", Href: ts.URL + "/api/versions/2/risk-profile"},
+		{Rel: "policy-status", Href: ts.URL + "/api/versions/2/policy-status"},
+	}}}
+
+	r := NewBlackduck()
+	profile, err := r.GetRiskProfile(source, &version)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]int{"VULNERABILITY": {"CRITICAL": 1, "HIGH": 0}, "LICENSE": {"HIGH": 2}}, profile.Categories)
+	status, err := r.GetPolicyStatus(source, &version)
+	require.NoError(t, err)
+	require.Equal(t, PolicyStatus{OverallStatus: "IN_VIOLATION", ComponentVersionStatusCounts: []StatusCount{{Name: "IN_VIOLATION", Value: 3}}}, status)
+	_, err = r.GetRiskProfile(source, &Version{})
+	require.EqualError(t, err, "GetRiskProfile: missing link to the risk profile")
+}
+
+func TestGetsTheUpgradeGuidanceOfComponents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch r.RequestURI {
+		case "/api/components/1/versions/2/upgrade-guidance":
+			_, _ = w.Write([]byte(`{"componentName":"log4j","versionName":"2.14.1","shortTerm":{"version":"http://blackduck/api/components/1/versions/3","versionName":"2.15.0","vulnerabilityRisk":{"critical":1}}}`))
+		case "/api/components/1/versions/3/vulnerabilities?limit=1000":
+			_, _ = w.Write([]byte(`{"items":[{"name":"CVE-2021-45046","severity":"CRITICAL"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	component := BomComponent{ComponentVersion: ts.URL + "/api/components/1/versions/2"}
+
+	r := NewBlackduck()
+	guidance, err := r.GetUpgradeGuidance(source, component)
+	require.NoError(t, err)
+	require.Equal(t, UpgradeGuidance{ShortTerm: &UpgradeVersion{Version: "http://blackduck/api/components/1/versions/3", VersionName: "2.15.0"}}, guidance)
+	vulnerabilities, err := r.GetComponentVersionVulnerabilities(source, ts.URL+"/api/components/1/versions/3")
+	require.NoError(t, err)
+	require.Equal(t, []ComponentVulnerability{{Name: "CVE-2021-45046", Severity: "CRITICAL"}}, vulnerabilities)
+	_, err = r.GetUpgradeGuidance(source, BomComponent{})
+	require.EqualError(t, err, "GetUpgradeGuidance: missing link to the component version")
+}
+
+func TestAuthenticatesOnlyOnce(t *testing.T) {
+	logins := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			logins++
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+	component := BomComponent{Meta: Meta{Links: []Link{{Rel: "matched-files", Href: ts.URL + "/api/bom/log4j/matched-files"}}}}
+
+	r := NewBlackduck()
+	for i := 0; i < 3; i++ {
+		_, err := r.GetMatchedFiles(source, component)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, logins)
+}
+
+func TestAuthenticatesAgainWhenTheTokenExpired(t *testing.T) {
+	var (
+		logins   int
+		requests []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.RequestURI, "/j_spring_security_check") {
+			logins++
+			w.Header().Set("Set-Cookie", validCookie)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.RequestURI+" "+string(body))
+		if len(requests) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	source := Source{Url: ts.URL, Name: "project1"}
+
+	r := NewBlackduck()
+	require.NoError(t, r.UpdateVersion(source, Version{Name: "1.0.0", Meta: Meta{Href: ts.URL + "/api/versions/1"}}))
+	require.Equal(t, 2, logins)
+	require.Len(t, requests, 2)
+	require.Equal(t, requests[0], requests[1])
+}
+
+func TestUploadsWithALongerTimeout(t *testing.T) {
+	r := NewBlackduck()
+	require.Equal(t, uploadTimeout, r.uploadClient.Timeout)
+	require.Greater(t, int64(r.uploadClient.Timeout), int64(r.client.Timeout))
+}
@@ -0,0 +1,74 @@
+package shared
+
+// Usages of components, which Blackduck accepts.
+var Usages = []string{
+	"SOURCE_CODE", "STATICALLY_LINKED", "DYNAMICALLY_LINKED", "SEPARATE_WORK", "IMPLEMENTATION_OF_STANDARD",
+	"DEV_TOOL_EXCLUDED", "MERELY_AGGREGATED", "PREREQUISITE", "UNSPECIFIED",
+}
+
+const (
+	PolicyStatusInViolation = "IN_VIOLATION"
+	PolicyStatusOverridden  = "IN_VIOLATION_OVERRIDDEN"
+)
+
+type BomComponentList struct {
+	BomComponents []BomComponent `json:"items"`
+}
+
+// BomComponent is a component version in the BOM of a project version. Its href updates the component in the BOM.
+type BomComponent struct {
+	ComponentName        string       `json:"componentName"`
+	ComponentVersionName string       `json:"componentVersionName"`
+	Component            string       `json:"component"`
+	ComponentVersion     string       `json:"componentVersion"`
+	Ignored              bool         `json:"ignored"`
+	Usages               []string     `json:"usages"`
+	Licenses             []BomLicense `json:"licenses"`
+	Origins              []BomOrigin  `json:"origins"`
+	MatchTypes           []string     `json:"matchTypes"`
+	PolicyStatus         string       `json:"policyStatus"`
+	Meta                 Meta         `json:"_meta"`
+}
+
+// InViolation tells whether the component violates a policy, which isn't overridden.
+func (c BomComponent) InViolation() bool {
+	return c.PolicyStatus == PolicyStatusInViolation
+}
+
+type BomLicense struct {
+	LicenseDisplay string `json:"licenseDisplay"`
+	License        string `json:"license"`
+}
+
+type BomOrigin struct {
+	Name              string `json:"name"`
+	ExternalNamespace string `json:"externalNamespace"`
+	ExternalId        string `json:"externalId"`
+	Origin            string `json:"origin"`
+}
+
+type OriginList struct {
+	Origins []Origin `json:"items"`
+}
+
+// Origin of a component version, e.g. maven:org.apache.commons:commons-text:1.9.
+type Origin struct {
+	OriginName string `json:"originName"`
+	OriginId   string `json:"originId"`
+	Meta       Meta   `json:"_meta"`
+}
+
+type MatchedFileList struct {
+	MatchedFiles []MatchedFile `json:"items"`
+}
+
+type MatchedFile struct {
+	FilePath FilePath `json:"filePath"`
+}
+
+// FilePath of a match relative to the scanned directory. CompositePathContext contains the archives, which the file is nested in.
+type FilePath struct {
+	Path                 string `json:"path"`
+	FileName             string `json:"fileName"`
+	CompositePathContext string `json:"compositePathContext"`
+}
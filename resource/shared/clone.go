@@ -0,0 +1,96 @@
+package shared
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	CloneFromPrevious       = "previous"
+	CloneFromLatestReleased = "latest_released"
+
+	// CloneCategoryComponents makes clones copy the BOM, which allows verifying them.
+	CloneCategoryComponents = "COMPONENT_DATA"
+)
+
+var semverPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// SelectCloneSource returns the version, which a new version with the name is cloned from.
+// It's nil, when there is no earlier version to clone from.
+func (v *VersionSettings) SelectCloneSource(versions []Version, name string) (*Version, error) {
+	if len(v.CloneFrom) == 0 {
+		return nil, nil
+	}
+	selected, err := SelectVersion(versions, v.CloneFrom, name)
+	if err == nil && selected == nil && v.CloneFrom != CloneFromPrevious && v.CloneFrom != CloneFromLatestReleased {
+		return nil, fmt.Errorf("could not find version %v to clone from", v.CloneFrom)
+	}
+	return selected, err
+}
+
+// SelectVersion returns the version, which the selector refers to relative to the version with the name:
+// the previous semantic version, the latest released version or the version named by the selector.
+// It's nil, when there is no such version.
+func SelectVersion(versions []Version, selector string, name string) (*Version, error) {
+	var selected *Version
+	switch selector {
+	case CloneFromPrevious:
+		target, ok := parseSemver(name)
+		if !ok {
+			return nil, fmt.Errorf("version %v is no semantic version to find its previous version", name)
+		}
+		var previous semver
+		for i := range versions {
+			version, ok := parseSemver(versions[i].Name)
+			if ok && version.less(target) && (selected == nil || previous.less(version)) {
+				selected, previous = &versions[i], version
+			}
+		}
+	case CloneFromLatestReleased:
+		for i := range versions {
+			if versions[i].Phase == "RELEASED" && (selected == nil || versions[i].Created.After(selected.Created)) {
+				selected = &versions[i]
+			}
+		}
+	default:
+		for i := range versions {
+			if versions[i].Name == selector {
+				return &versions[i], nil
+			}
+		}
+	}
+	return selected, nil
+}
+
+type semver struct {
+	numbers    [3]int
+	prerelease string
+}
+
+func parseSemver(name string) (semver, bool) {
+	match := semverPattern.FindStringSubmatch(name)
+	if match == nil {
+		return semver{}, false
+	}
+	var v semver
+	for i := range v.numbers {
+		v.numbers[i], _ = strconv.Atoi(match[i+1])
+	}
+	v.prerelease = match[4]
+	return v, true
+}
+
+// less compares the versions by semver precedence, while pre-releases are compared lexically.
+func (v semver) less(o semver) bool {
+	for i := range v.numbers {
+		if v.numbers[i] != o.numbers[i] {
+			return v.numbers[i] < o.numbers[i]
+		}
+	}
+	if len(v.prerelease) == 0 || len(o.prerelease) == 0 {
+		return len(v.prerelease) != 0 && len(o.prerelease) == 0
+	}
+	return strings.Compare(v.prerelease, o.prerelease) < 0
+}
@@ -0,0 +1,74 @@
+package shared_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var cloneVersions = []shared.Version{
+	{Name: "1.2.0", Phase: "RELEASED", Created: now.AddDate(0, 0, -3)},
+	{Name: "1.10.0-rc.1", Phase: "DEVELOPMENT", Created: now.AddDate(0, 0, -1)},
+	{Name: "1.9.1", Phase: "RELEASED", Created: now.AddDate(0, 0, -2)},
+	{Name: "nightly", Phase: "DEVELOPMENT", Created: now},
+	{Name: "2.0.0", Phase: "PLANNING", Created: now},
+}
+
+func TestClonesNothingWithoutCloneFrom(t *testing.T) {
+	from, err := (&shared.VersionSettings{}).SelectCloneSource(cloneVersions, "1.10.0")
+	require.NoError(t, err)
+	require.Nil(t, from)
+}
+
+func TestClonesThePreviousSemanticVersion(t *testing.T) {
+	settings := shared.VersionSettings{CloneFrom: "previous"}
+	for name, previous := range map[string]string{
+		"1.10.0":       "1.10.0-rc.1",
+		"v1.10.0-rc.2": "1.10.0-rc.1",
+		"1.10.0-rc.1":  "1.9.1",
+		"1.9.1":        "1.2.0",
+	} {
+		from, err := settings.SelectCloneSource(cloneVersions, name)
+		require.NoError(t, err)
+		require.Equal(t, previous, from.Name, name)
+	}
+	from, err := settings.SelectCloneSource(cloneVersions, "1.0.0")
+	require.NoError(t, err)
+	require.Nil(t, from)
+}
+
+func TestErrorsWhenThePreviousOfNoSemanticVersionIsCloned(t *testing.T) {
+	_, err := (&shared.VersionSettings{CloneFrom: "previous"}).SelectCloneSource(cloneVersions, "nightly-2")
+	require.EqualError(t, err, "version nightly-2 is no semantic version to find its previous version")
+}
+
+func TestClonesTheLatestReleasedVersion(t *testing.T) {
+	from, err := (&shared.VersionSettings{CloneFrom: "latest_released"}).SelectCloneSource(cloneVersions, "3.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "1.9.1", from.Name)
+	from, err = (&shared.VersionSettings{CloneFrom: "latest_released"}).SelectCloneSource(nil, "3.0.0")
+	require.NoError(t, err)
+	require.Nil(t, from)
+}
+
+func TestClonesAnExplicitVersion(t *testing.T) {
+	settings := shared.VersionSettings{CloneFrom: "nightly"}
+	from, err := settings.SelectCloneSource(cloneVersions, "3.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "nightly", from.Name)
+	settings.CloneFrom = "0.1.0"
+	_, err = settings.SelectCloneSource(cloneVersions, "3.0.0")
+	require.EqualError(t, err, "could not find version 0.1.0 to clone from")
+}
+
+func TestSelectsVersionsToCompareTo(t *testing.T) {
+	previous, err := shared.SelectVersion(cloneVersions, "previous", "1.9.1")
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", previous.Name)
+	named, err := shared.SelectVersion(cloneVersions, "nightly", "1.9.1")
+	require.NoError(t, err)
+	require.Equal(t, "nightly", named.Name)
+	missing, err := shared.SelectVersion(cloneVersions, "1.0.0", "1.9.1")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+}
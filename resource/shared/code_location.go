@@ -0,0 +1,13 @@
+package shared
+
+import "time"
+
+type CodeLocationList struct {
+	CodeLocations []CodeLocation `json:"items"`
+}
+
+type CodeLocation struct {
+	Name    string    `json:"name"`
+	Updated time.Time `json:"updatedAt"`
+	Meta    Meta      `json:"_meta"`
+}
@@ -0,0 +1,37 @@
+package junit
+
+import "github.com/elgohr/concourse-blackduck/shared"
+
+// FromBom reports the enabled policy rules as test suites of the components in the BOM.
+// Only the policy rules of components, which are in violation, are fetched.
+func FromBom(api shared.BlackduckApi, source shared.Source, name string, bom []shared.BomComponent) (TestSuites, error) {
+	rules, err := api.GetPolicyRules(source)
+	if err != nil {
+		return TestSuites{}, err
+	}
+	var names []string
+	for _, rule := range rules {
+		if rule.Enabled {
+			names = append(names, rule.Name)
+		}
+	}
+	var components []Component
+	for _, component := range bom {
+		c := Component{Name: component.ComponentName, Version: component.ComponentVersionName, Ignored: component.Ignored}
+		if !component.Ignored && (component.InViolation() || component.PolicyStatus == shared.PolicyStatusOverridden) {
+			violated, err := api.GetComponentPolicyRules(source, component)
+			if err != nil {
+				return TestSuites{}, err
+			}
+			for _, rule := range violated {
+				if rule.PolicyApprovalStatus == shared.PolicyStatusOverridden {
+					c.Overridden = append(c.Overridden, rule.Name)
+				} else {
+					c.Violated = append(c.Violated, rule.Name)
+				}
+			}
+		}
+		components = append(components, c)
+	}
+	return New(name, names, components), nil
+}
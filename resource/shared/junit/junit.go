@@ -0,0 +1,124 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+)
+
+// Component is reported as a test case in the suite of every policy rule.
+type Component struct {
+	Name       string
+	Version    string
+	Ignored    bool
+	Violated   []string
+	Overridden []string
+}
+
+func (c Component) String() string {
+	if len(c.Version) == 0 {
+		return c.Name
+	}
+	return c.Name + " " + c.Version
+}
+
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+type TestSuite struct {
+	Name     string     `xml:"name,attr"`
+	Tests    int        `xml:"tests,attr"`
+	Failures int        `xml:"failures,attr"`
+	Skipped  int        `xml:"skipped,attr"`
+	Cases    []TestCase `xml:"testcase"`
+}
+
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Failure   *Failure `xml:"failure"`
+	Skipped   *Skipped `xml:"skipped"`
+}
+
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+type Skipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// New reports a suite per policy rule with a test case per component.
+// Components violating the rule fail, while ignored components and overridden violations are skipped.
+// Rules, which are violated but not given, are reported as well.
+func New(name string, rules []string, components []Component) TestSuites {
+	unique := map[string]bool{}
+	for _, rule := range rules {
+		unique[rule] = true
+	}
+	for _, component := range components {
+		for _, rule := range append(append([]string{}, component.Violated...), component.Overridden...) {
+			unique[rule] = true
+		}
+	}
+	var names []string
+	for rule := range unique {
+		names = append(names, rule)
+	}
+	sort.Strings(names)
+	sorted := append([]Component{}, components...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+
+	suites := TestSuites{Name: name, Suites: []TestSuite{}}
+	for _, rule := range names {
+		suite := TestSuite{Name: rule, Cases: []TestCase{}}
+		for _, component := range sorted {
+			testCase := TestCase{Name: component.String(), ClassName: rule}
+			switch {
+			case component.Ignored:
+				testCase.Skipped = &Skipped{Message: "the component is ignored"}
+				suite.Skipped++
+			case contains(component.Violated, rule):
+				testCase.Failure = &Failure{Message: fmt.Sprintf("%v violates %v", component, rule), Type: "PolicyViolation"}
+				suite.Failures++
+			case contains(component.Overridden, rule):
+				testCase.Skipped = &Skipped{Message: "the violation is overridden"}
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Tests = len(suite.Cases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+	return suites
+}
+
+// Marshal encodes the test suites as JUnit XML document.
+func (t TestSuites) Marshal() ([]byte, error) {
+	b, err := xml.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(b, '\n')...), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package junit_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/shared/junit"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReportsASuitePerPolicyRule(t *testing.T) {
+	suites := junit.New("1.0.0", []string{"No Copyleft", "No Critical Vulnerabilities"}, []junit.Component{
+		{Name: "log4j", Version: "2.14.1", Violated: []string{"No Critical Vulnerabilities"}},
+		{Name: "mysql", Version: "8.0.33", Overridden: []string{"No Copyleft"}, Violated: []string{"No Unknown Licenses"}},
+		{Name: "junit", Version: "4.13", Ignored: true},
+	})
+
+	require.Equal(t, 9, suites.Tests)
+	require.Equal(t, 2, suites.Failures)
+	require.Equal(t, 4, suites.Skipped)
+	b, err := suites.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="1.0.0" tests="9" failures="2" skipped="4">
+  <testsuite name="No Copyleft" tests="3" failures="0" skipped="2">
+    <testcase name="junit 4.13" classname="No Copyleft">
+      <skipped message="the component is ignored"></skipped>
+    </testcase>
+    <testcase name="log4j 2.14.1" classname="No Copyleft"></testcase>
+    <testcase name="mysql 8.0.33" classname="No Copyleft">
+      <skipped message="the violation is overridden"></skipped>
+    </testcase>
+  </testsuite>
+  <testsuite name="No Critical Vulnerabilities" tests="3" failures="1" skipped="1">
+    <testcase name="junit 4.13" classname="No Critical Vulnerabilities">
+      <skipped message="the component is ignored"></skipped>
+    </testcase>
+    <testcase name="log4j 2.14.1" classname="No Critical Vulnerabilities">
+      <failure message="log4j 2.14.1 violates No Critical Vulnerabilities" type="PolicyViolation"></failure>
+    </testcase>
+    <testcase name="mysql 8.0.33" classname="No Critical Vulnerabilities"></testcase>
+  </testsuite>
+  <testsuite name="No Unknown Licenses" tests="3" failures="1" skipped="1">
+    <testcase name="junit 4.13" classname="No Unknown Licenses">
+      <skipped message="the component is ignored"></skipped>
+    </testcase>
+    <testcase name="log4j 2.14.1" classname="No Unknown Licenses"></testcase>
+    <testcase name="mysql 8.0.33" classname="No Unknown Licenses">
+      <failure message="mysql 8.0.33 violates No Unknown Licenses" type="PolicyViolation"></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, string(b))
+}
+
+func TestReportsNoSuitesWithoutRules(t *testing.T) {
+	b, err := junit.New("1.0.0", nil, []junit.Component{{Name: "log4j"}}).Marshal()
+	require.NoError(t, err)
+	require.Contains(t, string(b), `<testsuites name="1.0.0" tests="0" failures="0" skipped="0"></testsuites>`)
+}
@@ -1,9 +1,208 @@
 package shared
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	allowedJavaOptionPrefixes = []string{"-X", "-D", "-ea", "-da", "-esa", "-dsa", "-verbose", "-server"}
+	forbiddenJavaOptions      = []string{"-XX:Flags=", "-XX:VMOptionsFile="}
+)
+
+const (
+	defaultConcurrency      = 2
+	defaultMaxArtifactCount = 100
+	defaultMaxArtifactBytes = 5 << 30
+
+	ScanModeIntelligent = "intelligent"
+	ScanModeRapid       = "rapid"
+
+	ActionScan        = ""
+	ActionUploadBdio  = "upload_bdio"
+	ActionSyncProject = "sync_project"
+	ActionCleanup     = "cleanup"
+	ActionSetPhase    = "set_phase"
+	ActionRemediate   = "remediate"
+	ActionAdjust      = "adjust"
+)
+
 type Params struct {
-	Directory string `json:"directory"`
+	Action           string      `json:"action"`
+	Directory        string      `json:"directory"`
+	Directories      []Directory `json:"directories"`
+	Concurrency      int         `json:"concurrency"`
+	ImageTar         string      `json:"image_tar"`
+	ImageRef         string      `json:"image_ref"`
+	Artifacts        []string    `json:"artifacts"`
+	MaxArtifactCount int         `json:"max_artifact_count"`
+	MaxArtifactBytes int64       `json:"max_artifact_bytes"`
+	ScanMode         string      `json:"scan_mode"`
+	FailOnSeverities []string    `json:"fail_on_severities"`
+	Offline          bool        `json:"offline"`
+	BdioDirectory    string      `json:"bdio_directory"`
+	JunitFile        string      `json:"junit_file"`
+	VersionName      string      `json:"version_name"`
+	VersionRef       string      `json:"version_ref"`
+	Phase            string      `json:"phase"`
+	Distribution     string      `json:"distribution"`
+	RemediationFile  string      `json:"remediation_file"`
+	AdjustmentFile   string      `json:"adjustment_file"`
+	DryRun           bool        `json:"dry_run"`
+	CompareTo        string      `json:"compare_to"`
+	Formats          []string    `json:"formats"`
+	SummaryTemplate  string      `json:"summary_template"`
+	SummaryHtml      string      `json:"summary_html_template"`
+	CsvColumns       []string    `json:"csv_columns"`
+	NoticesSource    string      `json:"notices_source"`
+	NoticesTemplate  string      `json:"notices_template"`
+	NoticesHtml      string      `json:"notices_html_template"`
+	JavaOpts         []string    `json:"java_opts"`
+	JavaHome         string      `json:"java_home"`
+	Timeout          string      `json:"timeout"`
+}
+
+// Directory is scanned as its own project version, when several directories are scanned in one put.
+type Directory struct {
+	Path        string            `json:"path"`
+	ProjectName string            `json:"project_name"`
+	VersionName string            `json:"version_name"`
+	Properties  map[string]string `json:"properties"`
 }
 
 func (p *Params) Valid() bool {
-	return len(p.Directory) != 0
+	switch p.Action {
+	case ActionScan:
+		return p.validScan()
+	case ActionUploadBdio:
+		return len(p.BdioDirectory) != 0 && len(p.VersionName) != 0
+	case ActionSyncProject:
+		return len(p.Directory) != 0
+	case ActionCleanup:
+		return true
+	case ActionSetPhase:
+		settings := p.GetVersionSettings()
+		return (len(p.VersionName) != 0 || len(p.VersionRef) != 0) &&
+			(len(p.Phase) != 0 || len(p.Distribution) != 0) &&
+			settings.Valid()
+	case ActionRemediate:
+		return len(p.RemediationFile) != 0 && (len(p.VersionName) != 0 || len(p.VersionRef) != 0)
+	case ActionAdjust:
+		return len(p.AdjustmentFile) != 0 && (len(p.VersionName) != 0 || len(p.VersionRef) != 0)
+	}
+	return false
+}
+
+// validScan makes sure, that exactly one target is scanned: a directory, several directories, an image or artifacts.
+func (p *Params) validScan() bool {
+	targets := 0
+	for _, configured := range []bool{len(p.Directory) != 0, len(p.Directories) != 0, p.ScansImage(), len(p.Artifacts) != 0} {
+		if configured {
+			targets++
+		}
+	}
+	if len(p.Directories) != 0 && !p.validDirectories() {
+		return false
+	}
+	if len(p.ScanMode) != 0 && p.ScanMode != ScanModeIntelligent && p.ScanMode != ScanModeRapid {
+		return false
+	}
+	if p.Offline && (len(p.BdioDirectory) == 0 || p.ScansRapidly()) {
+		return false
+	}
+	if len(p.JunitFile) != 0 && (p.Offline || len(p.Directories) != 0) {
+		return false
+	}
+	return targets == 1
+}
+
+// GetVersionSettings returns the settings, which set_phase applies to the version.
+func (p *Params) GetVersionSettings() VersionSettings {
+	return VersionSettings{Phase: p.Phase, Distribution: p.Distribution}
+}
+
+// ScansRapidly returns true, when the scan shouldn't persist anything on Blackduck.
+func (p *Params) ScansRapidly() bool {
+	return p.ScanMode == ScanModeRapid
+}
+
+func (p *Params) ScansImage() bool {
+	return len(p.ImageTar) != 0 || len(p.ImageRef) != 0
+}
+
+func (p *Params) validDirectories() bool {
+	for _, directory := range p.Directories {
+		if len(directory.Path) == 0 {
+			return false
+		}
+		for key := range directory.Properties {
+			if len(key) == 0 || strings.HasPrefix(key, "-") || strings.ContainsAny(key, "= \t\n") {
+				return false
+			}
+		}
+	}
+	return p.Concurrency >= 0
+}
+
+// GetArtifactLimits returns the maximum number and total size of artifacts, which are uploaded by one put.
+func (p *Params) GetArtifactLimits() (maxCount int, maxBytes int64) {
+	maxCount, maxBytes = p.MaxArtifactCount, p.MaxArtifactBytes
+	if maxCount <= 0 {
+		maxCount = defaultMaxArtifactCount
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxArtifactBytes
+	}
+	return
+}
+
+func (p *Params) GetConcurrency() int {
+	if p.Concurrency == 0 {
+		return defaultConcurrency
+	}
+	return p.Concurrency
+}
+
+// ValidateJava makes sure, that the java options can only tune the JVM,
+// but can't replace the scanner which is started with -jar.
+func (p *Params) ValidateJava() error {
+	for _, option := range p.JavaOpts {
+		if !isAllowedJavaOption(option) {
+			return fmt.Errorf("java option %v is not allowed", option)
+		}
+	}
+	if strings.ContainsAny(p.JavaHome, "\x00\n") {
+		return fmt.Errorf("java home %v is invalid", p.JavaHome)
+	}
+	return nil
+}
+
+func isAllowedJavaOption(option string) bool {
+	if option != strings.TrimSpace(option) {
+		return false
+	}
+	for _, forbidden := range forbiddenJavaOptions {
+		if strings.HasPrefix(option, forbidden) {
+			return false
+		}
+	}
+	for _, prefix := range allowedJavaOptionPrefixes {
+		if strings.HasPrefix(option, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTimeout returns the configured timeout of the scan, while 0 means no timeout.
+func (p *Params) GetTimeout() (time.Duration, error) {
+	if len(p.Timeout) == 0 {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(p.Timeout)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("timeout %v is invalid", p.Timeout)
+	}
+	return timeout, nil
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/elgohr/concourse-blackduck/shared"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestIsValidWhenDirectoryIsFilled(t *testing.T) {
@@ -17,3 +18,226 @@ func TestIsInvalidWhenDirectoryIsNotFilled(t *testing.T) {
 	p := shared.Params{}
 	require.False(t, p.Valid())
 }
+
+func TestAcceptsJavaOptionsWhichTuneTheJvm(t *testing.T) {
+	p := shared.Params{
+		Directory: "directory",
+		JavaOpts:  []string{"-Xmx4g", "-XX:+UseG1GC", "-Djava.io.tmpdir=/tmp/scan", "-ea"},
+		JavaHome:  "/usr/lib/jvm/java-17",
+	}
+	require.NoError(t, p.ValidateJava())
+}
+
+func TestRejectsJavaOptionsWhichCouldReplaceTheScanner(t *testing.T) {
+	for _, option := range []string{
+		"-jar",
+		"-cp",
+		"-classpath",
+		"--class-path=evil.jar",
+		"-javaagent:evil.jar",
+		"@argfile",
+		"-XX:Flags=.hotspotrc",
+		"-XX:VMOptionsFile=options",
+		" -Xmx4g",
+		"Main",
+	} {
+		p := shared.Params{
+			Directory: "directory",
+			JavaOpts:  []string{"-Xmx4g", option},
+		}
+		require.EqualError(t, p.ValidateJava(), "java option "+option+" is not allowed")
+	}
+}
+
+func TestRejectsJavaHomeWithControlCharacters(t *testing.T) {
+	p := shared.Params{
+		Directory: "directory",
+		JavaHome:  "/usr/lib/jvm\n-jar",
+	}
+	require.Error(t, p.ValidateJava())
+}
+
+func TestParsesTheTimeout(t *testing.T) {
+	p := shared.Params{Timeout: "1h30m"}
+	timeout, err := p.GetTimeout()
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Minute, timeout)
+}
+
+func TestHasNoTimeoutByDefault(t *testing.T) {
+	p := shared.Params{}
+	timeout, err := p.GetTimeout()
+	require.NoError(t, err)
+	require.Zero(t, timeout)
+}
+
+func TestErrorsWhenTheTimeoutIsInvalid(t *testing.T) {
+	for _, timeout := range []string{"1 hour", "-5m"} {
+		p := shared.Params{Timeout: timeout}
+		_, err := p.GetTimeout()
+		require.EqualError(t, err, "timeout "+timeout+" is invalid")
+	}
+}
+
+func TestIsValidWhenDirectoriesAreFilled(t *testing.T) {
+	p := shared.Params{
+		Directories: []shared.Directory{
+			{Path: "service-a", ProjectName: "a", VersionName: "1.0.0"},
+			{Path: "service-b", Properties: map[string]string{"detect.tools": "DETECTOR"}},
+		},
+	}
+	require.True(t, p.Valid())
+}
+
+func TestIsInvalidWhenDirectoryAndDirectoriesAreFilled(t *testing.T) {
+	p := shared.Params{
+		Directory:   "directory",
+		Directories: []shared.Directory{{Path: "service-a"}},
+	}
+	require.False(t, p.Valid())
+}
+
+func TestIsInvalidWhenADirectoryHasNoPath(t *testing.T) {
+	p := shared.Params{
+		Directories: []shared.Directory{{Path: "service-a"}, {ProjectName: "b"}},
+	}
+	require.False(t, p.Valid())
+}
+
+func TestIsInvalidWhenADirectoryHasAnInvalidProperty(t *testing.T) {
+	for _, key := range []string{"", "-jar", "detect.tools=ALL", "detect tools"} {
+		p := shared.Params{
+			Directories: []shared.Directory{{Path: "service-a", Properties: map[string]string{key: "value"}}},
+		}
+		require.False(t, p.Valid(), key)
+	}
+}
+
+func TestDefaultsTheConcurrency(t *testing.T) {
+	p := shared.Params{}
+	require.Equal(t, 2, p.GetConcurrency())
+	p.Concurrency = 5
+	require.Equal(t, 5, p.GetConcurrency())
+}
+
+func TestIsValidWhenAnImageIsFilled(t *testing.T) {
+	for _, p := range []shared.Params{
+		{ImageTar: "image/image.tar"},
+		{ImageRef: "registry.example.com/app:1.0.0"},
+		{ImageTar: "image/image.tar", ImageRef: "registry.example.com/app:1.0.0"},
+	} {
+		require.True(t, p.Valid())
+		require.True(t, p.ScansImage())
+	}
+}
+
+func TestIsInvalidWhenAnImageAndADirectoryAreFilled(t *testing.T) {
+	p := shared.Params{
+		Directory: "directory",
+		ImageTar:  "image/image.tar",
+	}
+	require.False(t, p.Valid())
+}
+
+func TestIsValidWhenArtifactsAreFilled(t *testing.T) {
+	p := shared.Params{Artifacts: []string{"vendor/*.jar"}}
+	require.True(t, p.Valid())
+	p.Directory = "directory"
+	require.False(t, p.Valid())
+}
+
+func TestDefaultsTheArtifactLimits(t *testing.T) {
+	p := shared.Params{}
+	maxCount, maxBytes := p.GetArtifactLimits()
+	require.Equal(t, 100, maxCount)
+	require.Equal(t, int64(5<<30), maxBytes)
+	p = shared.Params{MaxArtifactCount: 3, MaxArtifactBytes: 1024}
+	maxCount, maxBytes = p.GetArtifactLimits()
+	require.Equal(t, 3, maxCount)
+	require.Equal(t, int64(1024), maxBytes)
+}
+
+func TestValidatesTheScanMode(t *testing.T) {
+	p := shared.Params{Directory: "directory", ScanMode: "rapid"}
+	require.True(t, p.Valid())
+	require.True(t, p.ScansRapidly())
+	p.ScanMode = "intelligent"
+	require.True(t, p.Valid())
+	require.False(t, p.ScansRapidly())
+	p.ScanMode = "fast"
+	require.False(t, p.Valid())
+}
+
+func TestIsValidWhenOfflineScansHaveABdioDirectory(t *testing.T) {
+	p := shared.Params{Directory: "directory", Offline: true}
+	require.False(t, p.Valid())
+	p.BdioDirectory = "bdio"
+	require.True(t, p.Valid())
+	p.ScanMode = "rapid"
+	require.False(t, p.Valid())
+}
+
+func TestIsValidWhenUploadsHaveABdioDirectoryAndVersion(t *testing.T) {
+	p := shared.Params{Action: "upload_bdio"}
+	require.False(t, p.Valid())
+	p.BdioDirectory = "bdio"
+	require.False(t, p.Valid())
+	p.VersionName = "1.0.0"
+	require.True(t, p.Valid())
+}
+
+func TestIsValidWhenProjectSyncsHaveADirectory(t *testing.T) {
+	p := shared.Params{Action: "sync_project"}
+	require.False(t, p.Valid())
+	p.Directory = "source-code"
+	require.True(t, p.Valid())
+}
+
+func TestIsValidWhenPhasesAreSetForAVersion(t *testing.T) {
+	p := shared.Params{Action: "set_phase", Phase: "RELEASED"}
+	require.False(t, p.Valid())
+	p.VersionName = "1.0.0"
+	require.True(t, p.Valid())
+	p.VersionName, p.VersionRef = "", "2019-04-20 09:12:48.511 +0000 UTC"
+	require.True(t, p.Valid())
+	p.Phase = "SHIPPED"
+	require.False(t, p.Valid())
+	p.Phase, p.Distribution = "", "SAAS"
+	require.True(t, p.Valid())
+	p.Distribution = ""
+	require.False(t, p.Valid())
+}
+
+func TestIsValidWhenRemediationsHaveAFileAndVersion(t *testing.T) {
+	p := shared.Params{Action: "remediate", VersionName: "1.0.0"}
+	require.False(t, p.Valid())
+	p.RemediationFile = "source-code/remediations.yml"
+	require.True(t, p.Valid())
+	p.VersionName = ""
+	require.False(t, p.Valid())
+}
+
+func TestIsValidWhenAdjustmentsHaveAFileAndVersion(t *testing.T) {
+	p := shared.Params{Action: "adjust", VersionRef: "2019-04-20 09:12:48.511 +0000 UTC"}
+	require.False(t, p.Valid())
+	p.AdjustmentFile = "source-code/adjustments.yml"
+	require.True(t, p.Valid())
+	p.VersionRef = ""
+	require.False(t, p.Valid())
+}
+
+func TestIsValidWhenJunitIsWrittenForASingleOnlineScan(t *testing.T) {
+	p := shared.Params{Directory: "source-code", JunitFile: "reports/junit.xml"}
+	require.True(t, p.Valid())
+	p.ScanMode = "rapid"
+	require.True(t, p.Valid())
+	p.ScanMode, p.Offline, p.BdioDirectory = "", true, "bdio"
+	require.False(t, p.Valid())
+	p.Offline, p.Directory, p.Directories = false, "", []shared.Directory{{Path: "source-code"}}
+	require.False(t, p.Valid())
+}
+
+func TestIsInvalidWhenTheActionIsUnknown(t *testing.T) {
+	p := shared.Params{Action: "unknown", Directory: "directory"}
+	require.False(t, p.Valid())
+}
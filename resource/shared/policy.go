@@ -0,0 +1,15 @@
+package shared
+
+type PolicyRuleList struct {
+	PolicyRules []PolicyRule `json:"items"`
+}
+
+// PolicyRule of Blackduck. Listed for a component, PolicyApprovalStatus tells whether its violation is overridden.
+type PolicyRule struct {
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	Severity             string `json:"severity"`
+	Enabled              bool   `json:"enabled"`
+	PolicyApprovalStatus string `json:"policyApprovalStatus"`
+	Meta                 Meta   `json:"_meta"`
+}
@@ -5,11 +5,16 @@ type ProjectList struct {
 }
 
 type Project struct {
-	Name string `json:"name"`
-	Meta Meta   `json:"_meta"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	ProjectTier     int      `json:"projectTier"`
+	ProjectGroup    string   `json:"projectGroup"`
+	CloneCategories []string `json:"cloneCategories"`
+	Meta            Meta     `json:"_meta"`
 }
 
 type Meta struct {
+	Href  string `json:"href"`
 	Links []Link `json:"links"`
 }
 
@@ -26,3 +31,31 @@ type Link struct {
 	Rel  string `json:"rel"`
 	Href string `json:"href"`
 }
+
+type UserGroupList struct {
+	UserGroups []UserGroup `json:"items"`
+}
+
+// UserGroup is assigned to a project, while its href is the assignment.
+type UserGroup struct {
+	Name string `json:"name"`
+	Meta Meta   `json:"_meta"`
+}
+
+type TagList struct {
+	Tags []Tag `json:"items"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+type CustomFieldList struct {
+	CustomFields []CustomField `json:"items"`
+}
+
+type CustomField struct {
+	Label  string   `json:"label"`
+	Values []string `json:"values"`
+	Meta   Meta     `json:"_meta"`
+}
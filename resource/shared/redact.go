@@ -0,0 +1,79 @@
+package shared
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const Mask = "****"
+
+var bearerPattern = regexp.MustCompile(tokenPrefix + `[^;\s"']+`)
+
+// RedactingWriter masks the credentials of a Source before they reach the underlying writer.
+// Output is forwarded line by line, so secrets split across several writes are masked as well.
+type RedactingWriter struct {
+	mutex   sync.Mutex
+	out     io.Writer
+	buf     bytes.Buffer
+	secrets []string
+}
+
+func NewRedactingWriter(out io.Writer, source Source) *RedactingWriter {
+	var secrets []string
+	for _, secret := range []string{source.Password, source.Token, source.ProxyPassword} {
+		if len(secret) == 0 {
+			continue
+		}
+		secrets = append(secrets, secret)
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			secrets = append(secrets, escaped)
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	return &RedactingWriter{
+		out:     out,
+		secrets: secrets,
+	}
+}
+
+func (w *RedactingWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buf.Write(p)
+	end := bytes.LastIndexByte(w.buf.Bytes(), '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+	lines := w.buf.Next(end + 1)
+	if _, err := io.WriteString(w.out, w.Redact(string(lines))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes out a trailing line, which wasn't terminated by a newline yet.
+func (w *RedactingWriter) Flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := io.WriteString(w.out, w.Redact(w.buf.String()))
+	w.buf.Reset()
+	return err
+}
+
+func (w *RedactingWriter) Redact(text string) string {
+	text = bearerPattern.ReplaceAllString(text, tokenPrefix+Mask)
+	for _, secret := range w.secrets {
+		text = strings.ReplaceAll(text, secret, Mask)
+	}
+	return text
+}
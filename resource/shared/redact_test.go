@@ -0,0 +1,59 @@
+package shared_test
+
+import (
+	"bytes"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRedactsAllCredentialsOfTheSource(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := shared.NewRedactingWriter(out, shared.Source{
+		Username:      "USER",
+		Password:      "PASSWORD",
+		Token:         "TOKEN",
+		ProxyPassword: "PROXY_PASSWORD",
+	})
+	_, err := w.Write([]byte("--blackduck.username=USER --blackduck.password=PASSWORD\n" +
+		"api.token=TOKEN proxy=PROXY_PASSWORD\n" +
+		"Cookie: AUTHORIZATION_BEARER=eyJhbGciOi.J9; Path=/\n"))
+	require.NoError(t, err)
+	require.Equal(t, "--blackduck.username=USER --blackduck.password=****\n"+
+		"api.token=**** proxy=****\n"+
+		"Cookie: AUTHORIZATION_BEARER=****; Path=/\n", out.String())
+}
+
+func TestRedactsSecretsWhichAreSplitAcrossWrites(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := shared.NewRedactingWriter(out, shared.Source{Password: "PASSWORD"})
+	_, err := w.Write([]byte("password=PASS"))
+	require.NoError(t, err)
+	require.Empty(t, out.String())
+	_, err = w.Write([]byte("WORD\nnext"))
+	require.NoError(t, err)
+	require.Equal(t, "password=****\n", out.String())
+	require.NoError(t, w.Flush())
+	require.Equal(t, "password=****\nnext", out.String())
+}
+
+func TestRedactsUrlEncodedSecrets(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := shared.NewRedactingWriter(out, shared.Source{Password: "p@ss word"})
+	_, err := w.Write([]byte("j_password=p%40ss+word&other=p@ss word\n"))
+	require.NoError(t, err)
+	require.Equal(t, "j_password=****&other=****\n", out.String())
+}
+
+func TestRedactsLongerSecretsFirst(t *testing.T) {
+	w := shared.NewRedactingWriter(&bytes.Buffer{}, shared.Source{
+		Password: "secret",
+		Token:    "secret-token",
+	})
+	require.Equal(t, "**** ****", w.Redact("secret-token secret"))
+}
+
+func TestDoesNotRedactWhenNoCredentialsAreConfigured(t *testing.T) {
+	w := shared.NewRedactingWriter(&bytes.Buffer{}, shared.Source{})
+	require.Equal(t, "nothing to hide", w.Redact("nothing to hide"))
+}
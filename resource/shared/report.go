@@ -0,0 +1,35 @@
+package shared
+
+const (
+	ReportStatusCompleted = "COMPLETED"
+	ReportStatusFailed    = "FAILED"
+
+	ReportFormatText = "TEXT"
+	ReportFormatHtml = "HTML"
+)
+
+// Report is generated by Blackduck in the background. Its content can be fetched, once it's completed.
+type Report struct {
+	ReportFormat string `json:"reportFormat"`
+	Status       string `json:"status"`
+	Meta         Meta   `json:"_meta"`
+}
+
+type ReportContent struct {
+	Files []ReportFile `json:"reportContent"`
+}
+
+type ReportFile struct {
+	FileName    string `json:"fileName"`
+	FileContent string `json:"fileContent"`
+}
+
+type CopyrightList struct {
+	Copyrights []Copyright `json:"items"`
+}
+
+// Copyright of a component origin. Text is the copyright as it was edited in Blackduck.
+type Copyright struct {
+	Text   string `json:"updatedCopyright"`
+	Active bool   `json:"active"`
+}
@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// protectedPhases are never deleted or archived by the retention.
+var protectedPhases = []string{"RELEASED", "ARCHIVED"}
+
+// Retention removes old versions of a project, which are neither one of the last versions nor newer than a duration.
+// Versions, which match any of the rules, are kept.
+type Retention struct {
+	KeepLast      int    `json:"keep_last"`
+	KeepNewerThan string `json:"keep_newer_than"`
+	Archive       bool   `json:"archive"`
+}
+
+func (r *Retention) Configured() bool {
+	return r.KeepLast != 0 || len(r.KeepNewerThan) != 0
+}
+
+func (r *Retention) Valid() bool {
+	_, err := r.GetKeepNewerThan()
+	return r.KeepLast >= 0 && err == nil
+}
+
+// GetKeepNewerThan parses the duration, which also accepts days like 30d. Durations, which aren't positive, are invalid.
+func (r *Retention) GetKeepNewerThan() (time.Duration, error) {
+	if len(r.KeepNewerThan) == 0 {
+		return 0, nil
+	}
+	if days := strings.TrimSuffix(r.KeepNewerThan, "d"); days != r.KeepNewerThan {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if d, err := time.ParseDuration(r.KeepNewerThan); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("keep_newer_than %v is invalid", r.KeepNewerThan)
+}
+
+// Select returns the versions, which should be removed. The protected versions (e.g. the one just scanned) are always kept.
+func (r *Retention) Select(versions []Version, now time.Time, protected ...string) []Version {
+	if !r.Configured() {
+		return nil
+	}
+	keepNewerThan, _ := r.GetKeepNewerThan()
+	newestFirst := make([]Version, len(versions))
+	copy(newestFirst, versions)
+	sort.SliceStable(newestFirst, func(i, j int) bool {
+		return newestFirst[i].Created.After(newestFirst[j].Created)
+	})
+	var removed []Version
+	for i, version := range newestFirst {
+		switch {
+		case i < r.KeepLast:
+		case keepNewerThan != 0 && now.Sub(version.Created) < keepNewerThan:
+		case contains(protectedPhases, version.Phase):
+		case contains(protected, version.Name):
+		default:
+			removed = append(removed, version)
+		}
+	}
+	return removed
+}
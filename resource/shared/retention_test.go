@@ -0,0 +1,77 @@
+package shared_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+func versionsCreatedDaysAgo(phases map[string]string, days map[string]int) []shared.Version {
+	var versions []shared.Version
+	for name, phase := range phases {
+		versions = append(versions, shared.Version{Name: name, Phase: phase, Created: now.AddDate(0, 0, -days[name])})
+	}
+	return versions
+}
+
+func names(versions []shared.Version) []string {
+	var n []string
+	for _, v := range versions {
+		n = append(n, v.Name)
+	}
+	return n
+}
+
+func TestIsValidWithAValidDuration(t *testing.T) {
+	for duration, valid := range map[string]bool{"": true, "720h": true, "30d": true, "-1h": false, "-1d": false, "0s": false, "0d": false, "month": false} {
+		r := shared.Retention{KeepNewerThan: duration}
+		require.Equal(t, valid, r.Valid(), duration)
+	}
+	require.False(t, (&shared.Retention{KeepLast: -1}).Valid())
+}
+
+func TestParsesDays(t *testing.T) {
+	d, err := (&shared.Retention{KeepNewerThan: "30d"}).GetKeepNewerThan()
+	require.NoError(t, err)
+	require.Equal(t, 720*time.Hour, d)
+}
+
+func TestSelectsNothingWithoutRules(t *testing.T) {
+	versions := versionsCreatedDaysAgo(map[string]string{"1": "DEVELOPMENT"}, map[string]int{"1": 100})
+	require.Empty(t, (&shared.Retention{Archive: true}).Select(versions, now))
+}
+
+func TestKeepsTheLastVersions(t *testing.T) {
+	versions := versionsCreatedDaysAgo(
+		map[string]string{"1": "DEVELOPMENT", "2": "DEVELOPMENT", "3": "DEVELOPMENT", "4": "DEVELOPMENT"},
+		map[string]int{"1": 4, "2": 3, "3": 2, "4": 1},
+	)
+	require.Equal(t, []string{"2", "1"}, names((&shared.Retention{KeepLast: 2}).Select(versions, now)))
+}
+
+func TestKeepsVersionsNewerThanTheDuration(t *testing.T) {
+	versions := versionsCreatedDaysAgo(
+		map[string]string{"1": "DEVELOPMENT", "2": "DEVELOPMENT", "3": "DEVELOPMENT"},
+		map[string]int{"1": 40, "2": 20, "3": 1},
+	)
+	require.Equal(t, []string{"1"}, names((&shared.Retention{KeepNewerThan: "30d"}).Select(versions, now)))
+}
+
+func TestKeepsVersionsMatchingAnyRule(t *testing.T) {
+	versions := versionsCreatedDaysAgo(
+		map[string]string{"1": "DEVELOPMENT", "2": "DEVELOPMENT", "3": "DEVELOPMENT"},
+		map[string]int{"1": 40, "2": 20, "3": 10},
+	)
+	require.Equal(t, []string{"1"}, names((&shared.Retention{KeepLast: 1, KeepNewerThan: "30d"}).Select(versions, now)))
+}
+
+func TestNeverTouchesReleasedArchivedOrProtectedVersions(t *testing.T) {
+	versions := versionsCreatedDaysAgo(
+		map[string]string{"1": "RELEASED", "2": "ARCHIVED", "3": "DEVELOPMENT", "4": "PLANNING", "5": "DEVELOPMENT"},
+		map[string]int{"1": 50, "2": 40, "3": 30, "4": 20, "5": 10},
+	)
+	require.Equal(t, []string{"4"}, names((&shared.Retention{KeepNewerThan: "1d"}).Select(versions, now, "5", "3")))
+}
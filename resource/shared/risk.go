@@ -0,0 +1,76 @@
+package shared
+
+import (
+	"strconv"
+	"strings"
+)
+
+// riskCategories of the risk profile, which are reported, with their name in the metadata and their severities.
+var riskCategories = []struct {
+	category   string
+	name       string
+	severities []string
+}{
+	{category: "VULNERABILITY", name: "security", severities: []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}},
+	{category: "LICENSE", name: "license", severities: []string{"HIGH", "MEDIUM", "LOW"}},
+	{category: "OPERATIONAL", name: "operational", severities: []string{"HIGH", "MEDIUM", "LOW"}},
+}
+
+// RiskProfile counts the components of a version per risk category and severity.
+type RiskProfile struct {
+	Categories map[string]map[string]int `json:"categories"`
+}
+
+// RiskCount is the number of components with a risk of a category and severity, e.g. securityCritical.
+type RiskCount struct {
+	Name  string
+	Value int
+}
+
+// Counts returns the security, license and operational risks per severity in a stable order.
+func (p RiskProfile) Counts() []RiskCount {
+	var counts []RiskCount
+	for _, c := range riskCategories {
+		for _, severity := range c.severities {
+			counts = append(counts, RiskCount{
+				Name:  c.name + severity[:1] + strings.ToLower(severity[1:]),
+				Value: p.Categories[c.category][severity],
+			})
+		}
+	}
+	return counts
+}
+
+// PolicyStatus of a version. OverallStatus is IN_VIOLATION, when any component violates a policy.
+type PolicyStatus struct {
+	OverallStatus                string        `json:"overallStatus"`
+	ComponentVersionStatusCounts []StatusCount `json:"componentVersionStatusCounts"`
+}
+
+type StatusCount struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+// RiskMetadata is the risk of a version, as get and put report it in their metadata.
+type RiskMetadata struct {
+	Name  string
+	Value string
+}
+
+// GetRisk returns the risk counts of the version, followed by its policyStatus.
+func GetRisk(api BlackduckApi, source Source, version *Version) ([]RiskMetadata, error) {
+	profile, err := api.GetRiskProfile(source, version)
+	if err != nil {
+		return nil, err
+	}
+	status, err := api.GetPolicyStatus(source, version)
+	if err != nil {
+		return nil, err
+	}
+	var metadata []RiskMetadata
+	for _, count := range profile.Counts() {
+		metadata = append(metadata, RiskMetadata{Name: count.Name, Value: strconv.Itoa(count.Value)})
+	}
+	return append(metadata, RiskMetadata{Name: "policyStatus", Value: status.OverallStatus}), nil
+}
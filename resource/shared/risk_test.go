@@ -0,0 +1,64 @@
+package shared_test
+
+import (
+	"errors"
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/elgohr/concourse-blackduck/shared/sharedfakes"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCountsTheRiskPerCategoryAndSeverity(t *testing.T) {
+	profile := shared.RiskProfile{Categories: map[string]map[string]int{
+		"VULNERABILITY": {"CRITICAL": 1, "HIGH": 2, "MEDIUM": 3, "LOW": 4, "OK": 10},
+		"LICENSE":       {"HIGH": 5},
+		"ACTIVITY":      {"HIGH": 6},
+	}}
+	require.Equal(t, []shared.RiskCount{
+		{Name: "securityCritical", Value: 1},
+		{Name: "securityHigh", Value: 2},
+		{Name: "securityMedium", Value: 3},
+		{Name: "securityLow", Value: 4},
+		{Name: "licenseHigh", Value: 5},
+		{Name: "licenseMedium", Value: 0},
+		{Name: "licenseLow", Value: 0},
+		{Name: "operationalHigh", Value: 0},
+		{Name: "operationalMedium", Value: 0},
+		{Name: "operationalLow", Value: 0},
+	}, profile.Counts())
+}
+
+func TestGetsTheRiskAndPolicyStatusAsMetadata(t *testing.T) {
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetRiskProfileReturns(shared.RiskProfile{Categories: map[string]map[string]int{
+		"VULNERABILITY": {"CRITICAL": 1},
+		"OPERATIONAL":   {"LOW": 2},
+	}}, nil)
+	fakeBlackduckApi.GetPolicyStatusReturns(shared.PolicyStatus{OverallStatus: "IN_VIOLATION"}, nil)
+
+	risk, err := shared.GetRisk(fakeBlackduckApi, shared.Source{Name: "project1"}, &shared.Version{Name: "1.0.0"})
+	require.NoError(t, err)
+	require.Equal(t, []shared.RiskMetadata{
+		{Name: "securityCritical", Value: "1"},
+		{Name: "securityHigh", Value: "0"},
+		{Name: "securityMedium", Value: "0"},
+		{Name: "securityLow", Value: "0"},
+		{Name: "licenseHigh", Value: "0"},
+		{Name: "licenseMedium", Value: "0"},
+		{Name: "licenseLow", Value: "0"},
+		{Name: "operationalHigh", Value: "0"},
+		{Name: "operationalMedium", Value: "0"},
+		{Name: "operationalLow", Value: "2"},
+		{Name: "policyStatus", Value: "IN_VIOLATION"},
+	}, risk)
+	_, version := fakeBlackduckApi.GetPolicyStatusArgsForCall(0)
+	require.Equal(t, "1.0.0", version.Name)
+}
+
+func TestErrorsWhenThePolicyStatusCantBeFetched(t *testing.T) {
+	fakeBlackduckApi := &sharedfakes.FakeBlackduckApi{}
+	fakeBlackduckApi.GetPolicyStatusReturns(shared.PolicyStatus{}, errors.New("GetPolicyStatus: missing link to the policy status"))
+
+	_, err := shared.GetRisk(fakeBlackduckApi, shared.Source{}, &shared.Version{})
+	require.EqualError(t, err, "GetPolicyStatus: missing link to the policy status")
+}
@@ -0,0 +1,135 @@
+package shared
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPhase        = "DEVELOPMENT"
+	DefaultDistribution = "EXTERNAL"
+
+	releaseDateLayout = "2006-01-02"
+)
+
+var (
+	versionPhases        = []string{"PLANNING", DefaultPhase, "PRERELEASE", "RELEASED", "DEPRECATED", "ARCHIVED"}
+	versionDistributions = []string{DefaultDistribution, "SAAS", "INTERNAL", "OPENSOURCE"}
+)
+
+// ProjectSettings are applied, when a missing project is created before the scan.
+type ProjectSettings struct {
+	Description     string   `json:"description"`
+	Tier            int      `json:"tier"`
+	Group           string   `json:"group"`
+	CloneCategories []string `json:"clone_categories"`
+}
+
+func (p *ProjectSettings) Configured() bool {
+	return len(p.Description) != 0 || p.Tier != 0 || len(p.Group) != 0 || len(p.CloneCategories) != 0
+}
+
+func (p *ProjectSettings) Valid() bool {
+	return p.Tier >= 0 && p.Tier <= 5
+}
+
+func (p *ProjectSettings) NewProject(name string) Project {
+	return Project{
+		Name:            name,
+		Description:     p.Description,
+		ProjectTier:     p.Tier,
+		ProjectGroup:    p.Group,
+		CloneCategories: p.CloneCategories,
+	}
+}
+
+// Apply changes the project to match the configured settings and returns whether anything changed.
+// The group has to be given by its href, as Blackduck returns the group of a project as a link.
+func (p *ProjectSettings) Apply(project *Project) (changed bool) {
+	if len(p.Description) != 0 && project.Description != p.Description {
+		project.Description = p.Description
+		changed = true
+	}
+	if p.Tier != 0 && project.ProjectTier != p.Tier {
+		project.ProjectTier = p.Tier
+		changed = true
+	}
+	if len(p.Group) != 0 && project.ProjectGroup != p.Group {
+		project.ProjectGroup = p.Group
+		changed = true
+	}
+	if len(p.CloneCategories) != 0 && strings.Join(project.CloneCategories, ",") != strings.Join(p.CloneCategories, ",") {
+		project.CloneCategories = p.CloneCategories
+		changed = true
+	}
+	return changed
+}
+
+// VersionSettings are applied, when the scanned version is created or differs from them.
+// New versions are cloned from an earlier version, when CloneFrom is set.
+type VersionSettings struct {
+	Phase           string   `json:"phase"`
+	Distribution    string   `json:"distribution"`
+	Nickname        string   `json:"nickname"`
+	ReleaseDate     string   `json:"release_date"`
+	CloneFrom       string   `json:"clone_from"`
+	CloneCategories []string `json:"clone_categories"`
+}
+
+func (v *VersionSettings) Configured() bool {
+	return len(v.Phase) != 0 || len(v.Distribution) != 0 || len(v.Nickname) != 0 || len(v.ReleaseDate) != 0 ||
+		len(v.CloneFrom) != 0
+}
+
+func (v *VersionSettings) Valid() bool {
+	if len(v.Phase) != 0 && !contains(versionPhases, v.Phase) {
+		return false
+	}
+	if len(v.Distribution) != 0 && !contains(versionDistributions, v.Distribution) {
+		return false
+	}
+	if len(v.ReleaseDate) != 0 {
+		if _, err := time.Parse(releaseDateLayout, v.ReleaseDate); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// NewVersion returns a version with the settings, which falls back to the defaults of Detect.
+func (v *VersionSettings) NewVersion(name string) Version {
+	version := Version{
+		Name:         name,
+		Phase:        DefaultPhase,
+		Distribution: DefaultDistribution,
+	}
+	v.Apply(&version)
+	return version
+}
+
+// Apply changes the version to match the configured settings and returns whether anything changed.
+func (v *VersionSettings) Apply(version *Version) (changed bool) {
+	set := func(field *string, value string) {
+		if len(value) != 0 && *field != value {
+			*field = value
+			changed = true
+		}
+	}
+	set(&version.Phase, v.Phase)
+	set(&version.Distribution, v.Distribution)
+	set(&version.Nickname, v.Nickname)
+	if len(v.ReleaseDate) != 0 && !strings.HasPrefix(version.ReleasedOn, v.ReleaseDate) {
+		version.ReleasedOn = v.ReleaseDate + "T00:00:00.000Z"
+		changed = true
+	}
+	return changed
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
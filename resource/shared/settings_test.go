@@ -0,0 +1,77 @@
+package shared_test
+
+import (
+	"github.com/elgohr/concourse-blackduck/shared"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIsValidWhenTheProjectTierIsInRange(t *testing.T) {
+	for tier, valid := range map[int]bool{-1: false, 0: true, 1: true, 5: true, 6: false} {
+		p := shared.ProjectSettings{Tier: tier}
+		require.Equal(t, valid, p.Valid(), "tier %v", tier)
+	}
+}
+
+func TestIsInvalidWhenTheVersionSettingsAreUnknown(t *testing.T) {
+	require.True(t, (&shared.VersionSettings{Phase: "RELEASED", Distribution: "SAAS", ReleaseDate: "2020-01-31"}).Valid())
+	require.False(t, (&shared.VersionSettings{Phase: "released"}).Valid())
+	require.False(t, (&shared.VersionSettings{Distribution: "PUBLIC"}).Valid())
+	require.False(t, (&shared.VersionSettings{ReleaseDate: "31.01.2020"}).Valid())
+}
+
+func TestSourceIsInvalidWithInvalidSettings(t *testing.T) {
+	s := shared.Source{Url: "http://url", Username: "user", Password: "password", Name: "name"}
+	s.Version.Phase = "UNKNOWN"
+	require.False(t, s.Valid())
+}
+
+func TestCreatesProjectsWithTheSettings(t *testing.T) {
+	p := shared.ProjectSettings{Description: "description", Tier: 2, Group: "group", CloneCategories: []string{"VULN_DATA"}}
+	require.True(t, p.Configured())
+	require.Equal(t, shared.Project{
+		Name:            "project1",
+		Description:     "description",
+		ProjectTier:     2,
+		ProjectGroup:    "group",
+		CloneCategories: []string{"VULN_DATA"},
+	}, p.NewProject("project1"))
+	require.False(t, (&shared.ProjectSettings{}).Configured())
+}
+
+func TestCreatesVersionsWithTheDefaultsOfDetect(t *testing.T) {
+	v := shared.VersionSettings{Nickname: "nick"}
+	require.Equal(t, shared.Version{
+		Name:         "1.0.0",
+		Phase:        "DEVELOPMENT",
+		Distribution: "EXTERNAL",
+		Nickname:     "nick",
+	}, v.NewVersion("1.0.0"))
+}
+
+func TestAppliesOnlyTheDifferingSettings(t *testing.T) {
+	v := shared.VersionSettings{Phase: "RELEASED", ReleaseDate: "2020-01-31"}
+	version := shared.Version{Name: "1.0.0", Phase: "DEVELOPMENT", Distribution: "SAAS"}
+	require.True(t, v.Apply(&version))
+	require.Equal(t, shared.Version{
+		Name:         "1.0.0",
+		Phase:        "RELEASED",
+		Distribution: "SAAS",
+		ReleasedOn:   "2020-01-31T00:00:00.000Z",
+	}, version)
+	require.False(t, v.Apply(&version))
+}
+
+func TestAppliesOnlyTheDifferingProjectSettings(t *testing.T) {
+	p := shared.ProjectSettings{Tier: 2, Group: "http://blackduck/api/project-groups/1", CloneCategories: []string{"VULN_DATA"}}
+	project := shared.Project{Name: "project1", Description: "description", ProjectTier: 1, CloneCategories: []string{"VULN_DATA"}}
+	require.True(t, p.Apply(&project))
+	require.Equal(t, shared.Project{
+		Name:            "project1",
+		Description:     "description",
+		ProjectTier:     2,
+		ProjectGroup:    "http://blackduck/api/project-groups/1",
+		CloneCategories: []string{"VULN_DATA"},
+	}, project)
+	require.False(t, p.Apply(&project))
+}
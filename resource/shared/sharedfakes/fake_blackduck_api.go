@@ -8,6 +8,255 @@ import (
 )
 
 type FakeBlackduckApi struct {
+	AddProjectTagStub        func(shared.Source, *shared.Project, string) error
+	addProjectTagMutex       sync.RWMutex
+	addProjectTagArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+		arg3 string
+	}
+	addProjectTagReturns struct {
+		result1 error
+	}
+	addProjectTagReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AddProjectUserGroupStub        func(shared.Source, *shared.Project, string) error
+	addProjectUserGroupMutex       sync.RWMutex
+	addProjectUserGroupArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+		arg3 string
+	}
+	addProjectUserGroupReturns struct {
+		result1 error
+	}
+	addProjectUserGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateNoticesReportStub        func(shared.Source, *shared.Version, string) (shared.Report, error)
+	createNoticesReportMutex       sync.RWMutex
+	createNoticesReportArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+		arg3 string
+	}
+	createNoticesReportReturns struct {
+		result1 shared.Report
+		result2 error
+	}
+	createNoticesReportReturnsOnCall map[int]struct {
+		result1 shared.Report
+		result2 error
+	}
+	CreateProjectStub        func(shared.Source, shared.Project) (*shared.Project, error)
+	createProjectMutex       sync.RWMutex
+	createProjectArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.Project
+	}
+	createProjectReturns struct {
+		result1 *shared.Project
+		result2 error
+	}
+	createProjectReturnsOnCall map[int]struct {
+		result1 *shared.Project
+		result2 error
+	}
+	CreateVersionStub        func(shared.Source, *shared.Project, shared.Version) (*shared.Version, error)
+	createVersionMutex       sync.RWMutex
+	createVersionArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+		arg3 shared.Version
+	}
+	createVersionReturns struct {
+		result1 *shared.Version
+		result2 error
+	}
+	createVersionReturnsOnCall map[int]struct {
+		result1 *shared.Version
+		result2 error
+	}
+	DeleteVersionStub        func(shared.Source, shared.Version) error
+	deleteVersionMutex       sync.RWMutex
+	deleteVersionArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.Version
+	}
+	deleteVersionReturns struct {
+		result1 error
+	}
+	deleteVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetBomComponentsStub        func(shared.Source, *shared.Version) ([]shared.BomComponent, error)
+	getBomComponentsMutex       sync.RWMutex
+	getBomComponentsArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}
+	getBomComponentsReturns struct {
+		result1 []shared.BomComponent
+		result2 error
+	}
+	getBomComponentsReturnsOnCall map[int]struct {
+		result1 []shared.BomComponent
+		result2 error
+	}
+	GetCodeLocationsStub        func(shared.Source, *shared.Version) ([]shared.CodeLocation, error)
+	getCodeLocationsMutex       sync.RWMutex
+	getCodeLocationsArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}
+	getCodeLocationsReturns struct {
+		result1 []shared.CodeLocation
+		result2 error
+	}
+	getCodeLocationsReturnsOnCall map[int]struct {
+		result1 []shared.CodeLocation
+		result2 error
+	}
+	GetComponentCountStub        func(shared.Source, *shared.Version) (int, error)
+	getComponentCountMutex       sync.RWMutex
+	getComponentCountArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}
+	getComponentCountReturns struct {
+		result1 int
+		result2 error
+	}
+	getComponentCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	GetComponentOriginsStub        func(shared.Source, shared.BomComponent) ([]shared.Origin, error)
+	getComponentOriginsMutex       sync.RWMutex
+	getComponentOriginsArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}
+	getComponentOriginsReturns struct {
+		result1 []shared.Origin
+		result2 error
+	}
+	getComponentOriginsReturnsOnCall map[int]struct {
+		result1 []shared.Origin
+		result2 error
+	}
+	GetComponentPolicyRulesStub        func(shared.Source, shared.BomComponent) ([]shared.PolicyRule, error)
+	getComponentPolicyRulesMutex       sync.RWMutex
+	getComponentPolicyRulesArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}
+	getComponentPolicyRulesReturns struct {
+		result1 []shared.PolicyRule
+		result2 error
+	}
+	getComponentPolicyRulesReturnsOnCall map[int]struct {
+		result1 []shared.PolicyRule
+		result2 error
+	}
+	GetComponentVersionVulnerabilitiesStub        func(shared.Source, string) ([]shared.ComponentVulnerability, error)
+	getComponentVersionVulnerabilitiesMutex       sync.RWMutex
+	getComponentVersionVulnerabilitiesArgsForCall []struct {
+		arg1 shared.Source
+		arg2 string
+	}
+	getComponentVersionVulnerabilitiesReturns struct {
+		result1 []shared.ComponentVulnerability
+		result2 error
+	}
+	getComponentVersionVulnerabilitiesReturnsOnCall map[int]struct {
+		result1 []shared.ComponentVulnerability
+		result2 error
+	}
+	GetCopyrightsStub        func(shared.Source, shared.BomOrigin) ([]shared.Copyright, error)
+	getCopyrightsMutex       sync.RWMutex
+	getCopyrightsArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.BomOrigin
+	}
+	getCopyrightsReturns struct {
+		result1 []shared.Copyright
+		result2 error
+	}
+	getCopyrightsReturnsOnCall map[int]struct {
+		result1 []shared.Copyright
+		result2 error
+	}
+	GetLicenseTextStub        func(shared.Source, shared.BomLicense) (string, error)
+	getLicenseTextMutex       sync.RWMutex
+	getLicenseTextArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.BomLicense
+	}
+	getLicenseTextReturns struct {
+		result1 string
+		result2 error
+	}
+	getLicenseTextReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetLicenseUrlStub        func(shared.Source, string) (string, error)
+	getLicenseUrlMutex       sync.RWMutex
+	getLicenseUrlArgsForCall []struct {
+		arg1 shared.Source
+		arg2 string
+	}
+	getLicenseUrlReturns struct {
+		result1 string
+		result2 error
+	}
+	getLicenseUrlReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetMatchedFilesStub        func(shared.Source, shared.BomComponent) ([]shared.MatchedFile, error)
+	getMatchedFilesMutex       sync.RWMutex
+	getMatchedFilesArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}
+	getMatchedFilesReturns struct {
+		result1 []shared.MatchedFile
+		result2 error
+	}
+	getMatchedFilesReturnsOnCall map[int]struct {
+		result1 []shared.MatchedFile
+		result2 error
+	}
+	GetPolicyRulesStub        func(shared.Source) ([]shared.PolicyRule, error)
+	getPolicyRulesMutex       sync.RWMutex
+	getPolicyRulesArgsForCall []struct {
+		arg1 shared.Source
+	}
+	getPolicyRulesReturns struct {
+		result1 []shared.PolicyRule
+		result2 error
+	}
+	getPolicyRulesReturnsOnCall map[int]struct {
+		result1 []shared.PolicyRule
+		result2 error
+	}
+	GetPolicyStatusStub        func(shared.Source, *shared.Version) (shared.PolicyStatus, error)
+	getPolicyStatusMutex       sync.RWMutex
+	getPolicyStatusArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}
+	getPolicyStatusReturns struct {
+		result1 shared.PolicyStatus
+		result2 error
+	}
+	getPolicyStatusReturnsOnCall map[int]struct {
+		result1 shared.PolicyStatus
+		result2 error
+	}
 	GetProjectByNameStub        func(shared.Source) (*shared.Project, error)
 	getProjectByNameMutex       sync.RWMutex
 	getProjectByNameArgsForCall []struct {
@@ -21,36 +270,2076 @@ type FakeBlackduckApi struct {
 		result1 *shared.Project
 		result2 error
 	}
+	GetProjectCustomFieldsStub        func(shared.Source, *shared.Project) ([]shared.CustomField, error)
+	getProjectCustomFieldsMutex       sync.RWMutex
+	getProjectCustomFieldsArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+	}
+	getProjectCustomFieldsReturns struct {
+		result1 []shared.CustomField
+		result2 error
+	}
+	getProjectCustomFieldsReturnsOnCall map[int]struct {
+		result1 []shared.CustomField
+		result2 error
+	}
+	GetProjectGroupUrlStub        func(shared.Source, string) (string, error)
+	getProjectGroupUrlMutex       sync.RWMutex
+	getProjectGroupUrlArgsForCall []struct {
+		arg1 shared.Source
+		arg2 string
+	}
+	getProjectGroupUrlReturns struct {
+		result1 string
+		result2 error
+	}
+	getProjectGroupUrlReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetProjectTagsStub        func(shared.Source, *shared.Project) ([]shared.Tag, error)
+	getProjectTagsMutex       sync.RWMutex
+	getProjectTagsArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+	}
+	getProjectTagsReturns struct {
+		result1 []shared.Tag
+		result2 error
+	}
+	getProjectTagsReturnsOnCall map[int]struct {
+		result1 []shared.Tag
+		result2 error
+	}
+	GetProjectUserGroupsStub        func(shared.Source, *shared.Project) ([]shared.UserGroup, error)
+	getProjectUserGroupsMutex       sync.RWMutex
+	getProjectUserGroupsArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+	}
+	getProjectUserGroupsReturns struct {
+		result1 []shared.UserGroup
+		result2 error
+	}
+	getProjectUserGroupsReturnsOnCall map[int]struct {
+		result1 []shared.UserGroup
+		result2 error
+	}
 	GetProjectVersionsStub        func(shared.Source, *shared.Project) ([]shared.Version, error)
 	getProjectVersionsMutex       sync.RWMutex
 	getProjectVersionsArgsForCall []struct {
 		arg1 shared.Source
-		arg2 *shared.Project
+		arg2 *shared.Project
+	}
+	getProjectVersionsReturns struct {
+		result1 []shared.Version
+		result2 error
+	}
+	getProjectVersionsReturnsOnCall map[int]struct {
+		result1 []shared.Version
+		result2 error
+	}
+	GetReportStub        func(shared.Source, shared.Report) (shared.Report, error)
+	getReportMutex       sync.RWMutex
+	getReportArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.Report
+	}
+	getReportReturns struct {
+		result1 shared.Report
+		result2 error
+	}
+	getReportReturnsOnCall map[int]struct {
+		result1 shared.Report
+		result2 error
+	}
+	GetReportContentStub        func(shared.Source, shared.Report) (string, error)
+	getReportContentMutex       sync.RWMutex
+	getReportContentArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.Report
+	}
+	getReportContentReturns struct {
+		result1 string
+		result2 error
+	}
+	getReportContentReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetRiskProfileStub        func(shared.Source, *shared.Version) (shared.RiskProfile, error)
+	getRiskProfileMutex       sync.RWMutex
+	getRiskProfileArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}
+	getRiskProfileReturns struct {
+		result1 shared.RiskProfile
+		result2 error
+	}
+	getRiskProfileReturnsOnCall map[int]struct {
+		result1 shared.RiskProfile
+		result2 error
+	}
+	GetUpgradeGuidanceStub        func(shared.Source, shared.BomComponent) (shared.UpgradeGuidance, error)
+	getUpgradeGuidanceMutex       sync.RWMutex
+	getUpgradeGuidanceArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}
+	getUpgradeGuidanceReturns struct {
+		result1 shared.UpgradeGuidance
+		result2 error
+	}
+	getUpgradeGuidanceReturnsOnCall map[int]struct {
+		result1 shared.UpgradeGuidance
+		result2 error
+	}
+	GetVulnerableComponentsStub        func(shared.Source, *shared.Version) ([]shared.VulnerableComponent, error)
+	getVulnerableComponentsMutex       sync.RWMutex
+	getVulnerableComponentsArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}
+	getVulnerableComponentsReturns struct {
+		result1 []shared.VulnerableComponent
+		result2 error
+	}
+	getVulnerableComponentsReturnsOnCall map[int]struct {
+		result1 []shared.VulnerableComponent
+		result2 error
+	}
+	RemoveProjectTagStub        func(shared.Source, *shared.Project, string) error
+	removeProjectTagMutex       sync.RWMutex
+	removeProjectTagArgsForCall []struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+		arg3 string
+	}
+	removeProjectTagReturns struct {
+		result1 error
+	}
+	removeProjectTagReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RemoveProjectUserGroupStub        func(shared.Source, shared.UserGroup) error
+	removeProjectUserGroupMutex       sync.RWMutex
+	removeProjectUserGroupArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.UserGroup
+	}
+	removeProjectUserGroupReturns struct {
+		result1 error
+	}
+	removeProjectUserGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateBomComponentStub        func(shared.Source, shared.BomComponent) error
+	updateBomComponentMutex       sync.RWMutex
+	updateBomComponentArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}
+	updateBomComponentReturns struct {
+		result1 error
+	}
+	updateBomComponentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateCustomFieldStub        func(shared.Source, shared.CustomField) error
+	updateCustomFieldMutex       sync.RWMutex
+	updateCustomFieldArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.CustomField
+	}
+	updateCustomFieldReturns struct {
+		result1 error
+	}
+	updateCustomFieldReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateProjectStub        func(shared.Source, shared.Project) error
+	updateProjectMutex       sync.RWMutex
+	updateProjectArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.Project
+	}
+	updateProjectReturns struct {
+		result1 error
+	}
+	updateProjectReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateRemediationStub        func(shared.Source, shared.VulnerableComponent, shared.Remediation) error
+	updateRemediationMutex       sync.RWMutex
+	updateRemediationArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.VulnerableComponent
+		arg3 shared.Remediation
+	}
+	updateRemediationReturns struct {
+		result1 error
+	}
+	updateRemediationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateVersionStub        func(shared.Source, shared.Version) error
+	updateVersionMutex       sync.RWMutex
+	updateVersionArgsForCall []struct {
+		arg1 shared.Source
+		arg2 shared.Version
+	}
+	updateVersionReturns struct {
+		result1 error
+	}
+	updateVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UploadBdioStub        func(shared.Source, string) (string, error)
+	uploadBdioMutex       sync.RWMutex
+	uploadBdioArgsForCall []struct {
+		arg1 shared.Source
+		arg2 string
+	}
+	uploadBdioReturns struct {
+		result1 string
+		result2 error
+	}
+	uploadBdioReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBlackduckApi) AddProjectTag(arg1 shared.Source, arg2 *shared.Project, arg3 string) error {
+	fake.addProjectTagMutex.Lock()
+	ret, specificReturn := fake.addProjectTagReturnsOnCall[len(fake.addProjectTagArgsForCall)]
+	fake.addProjectTagArgsForCall = append(fake.addProjectTagArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AddProjectTagStub
+	fakeReturns := fake.addProjectTagReturns
+	fake.recordInvocation("AddProjectTag", []interface{}{arg1, arg2, arg3})
+	fake.addProjectTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) AddProjectTagCallCount() int {
+	fake.addProjectTagMutex.RLock()
+	defer fake.addProjectTagMutex.RUnlock()
+	return len(fake.addProjectTagArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) AddProjectTagCalls(stub func(shared.Source, *shared.Project, string) error) {
+	fake.addProjectTagMutex.Lock()
+	defer fake.addProjectTagMutex.Unlock()
+	fake.AddProjectTagStub = stub
+}
+
+func (fake *FakeBlackduckApi) AddProjectTagArgsForCall(i int) (shared.Source, *shared.Project, string) {
+	fake.addProjectTagMutex.RLock()
+	defer fake.addProjectTagMutex.RUnlock()
+	argsForCall := fake.addProjectTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBlackduckApi) AddProjectTagReturns(result1 error) {
+	fake.addProjectTagMutex.Lock()
+	defer fake.addProjectTagMutex.Unlock()
+	fake.AddProjectTagStub = nil
+	fake.addProjectTagReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) AddProjectTagReturnsOnCall(i int, result1 error) {
+	fake.addProjectTagMutex.Lock()
+	defer fake.addProjectTagMutex.Unlock()
+	fake.AddProjectTagStub = nil
+	if fake.addProjectTagReturnsOnCall == nil {
+		fake.addProjectTagReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addProjectTagReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) AddProjectUserGroup(arg1 shared.Source, arg2 *shared.Project, arg3 string) error {
+	fake.addProjectUserGroupMutex.Lock()
+	ret, specificReturn := fake.addProjectUserGroupReturnsOnCall[len(fake.addProjectUserGroupArgsForCall)]
+	fake.addProjectUserGroupArgsForCall = append(fake.addProjectUserGroupArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AddProjectUserGroupStub
+	fakeReturns := fake.addProjectUserGroupReturns
+	fake.recordInvocation("AddProjectUserGroup", []interface{}{arg1, arg2, arg3})
+	fake.addProjectUserGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) AddProjectUserGroupCallCount() int {
+	fake.addProjectUserGroupMutex.RLock()
+	defer fake.addProjectUserGroupMutex.RUnlock()
+	return len(fake.addProjectUserGroupArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) AddProjectUserGroupCalls(stub func(shared.Source, *shared.Project, string) error) {
+	fake.addProjectUserGroupMutex.Lock()
+	defer fake.addProjectUserGroupMutex.Unlock()
+	fake.AddProjectUserGroupStub = stub
+}
+
+func (fake *FakeBlackduckApi) AddProjectUserGroupArgsForCall(i int) (shared.Source, *shared.Project, string) {
+	fake.addProjectUserGroupMutex.RLock()
+	defer fake.addProjectUserGroupMutex.RUnlock()
+	argsForCall := fake.addProjectUserGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBlackduckApi) AddProjectUserGroupReturns(result1 error) {
+	fake.addProjectUserGroupMutex.Lock()
+	defer fake.addProjectUserGroupMutex.Unlock()
+	fake.AddProjectUserGroupStub = nil
+	fake.addProjectUserGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) AddProjectUserGroupReturnsOnCall(i int, result1 error) {
+	fake.addProjectUserGroupMutex.Lock()
+	defer fake.addProjectUserGroupMutex.Unlock()
+	fake.AddProjectUserGroupStub = nil
+	if fake.addProjectUserGroupReturnsOnCall == nil {
+		fake.addProjectUserGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addProjectUserGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) CreateNoticesReport(arg1 shared.Source, arg2 *shared.Version, arg3 string) (shared.Report, error) {
+	fake.createNoticesReportMutex.Lock()
+	ret, specificReturn := fake.createNoticesReportReturnsOnCall[len(fake.createNoticesReportArgsForCall)]
+	fake.createNoticesReportArgsForCall = append(fake.createNoticesReportArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CreateNoticesReportStub
+	fakeReturns := fake.createNoticesReportReturns
+	fake.recordInvocation("CreateNoticesReport", []interface{}{arg1, arg2, arg3})
+	fake.createNoticesReportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) CreateNoticesReportCallCount() int {
+	fake.createNoticesReportMutex.RLock()
+	defer fake.createNoticesReportMutex.RUnlock()
+	return len(fake.createNoticesReportArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) CreateNoticesReportCalls(stub func(shared.Source, *shared.Version, string) (shared.Report, error)) {
+	fake.createNoticesReportMutex.Lock()
+	defer fake.createNoticesReportMutex.Unlock()
+	fake.CreateNoticesReportStub = stub
+}
+
+func (fake *FakeBlackduckApi) CreateNoticesReportArgsForCall(i int) (shared.Source, *shared.Version, string) {
+	fake.createNoticesReportMutex.RLock()
+	defer fake.createNoticesReportMutex.RUnlock()
+	argsForCall := fake.createNoticesReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBlackduckApi) CreateNoticesReportReturns(result1 shared.Report, result2 error) {
+	fake.createNoticesReportMutex.Lock()
+	defer fake.createNoticesReportMutex.Unlock()
+	fake.CreateNoticesReportStub = nil
+	fake.createNoticesReportReturns = struct {
+		result1 shared.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) CreateNoticesReportReturnsOnCall(i int, result1 shared.Report, result2 error) {
+	fake.createNoticesReportMutex.Lock()
+	defer fake.createNoticesReportMutex.Unlock()
+	fake.CreateNoticesReportStub = nil
+	if fake.createNoticesReportReturnsOnCall == nil {
+		fake.createNoticesReportReturnsOnCall = make(map[int]struct {
+			result1 shared.Report
+			result2 error
+		})
+	}
+	fake.createNoticesReportReturnsOnCall[i] = struct {
+		result1 shared.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) CreateProject(arg1 shared.Source, arg2 shared.Project) (*shared.Project, error) {
+	fake.createProjectMutex.Lock()
+	ret, specificReturn := fake.createProjectReturnsOnCall[len(fake.createProjectArgsForCall)]
+	fake.createProjectArgsForCall = append(fake.createProjectArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.Project
+	}{arg1, arg2})
+	stub := fake.CreateProjectStub
+	fakeReturns := fake.createProjectReturns
+	fake.recordInvocation("CreateProject", []interface{}{arg1, arg2})
+	fake.createProjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) CreateProjectCallCount() int {
+	fake.createProjectMutex.RLock()
+	defer fake.createProjectMutex.RUnlock()
+	return len(fake.createProjectArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) CreateProjectCalls(stub func(shared.Source, shared.Project) (*shared.Project, error)) {
+	fake.createProjectMutex.Lock()
+	defer fake.createProjectMutex.Unlock()
+	fake.CreateProjectStub = stub
+}
+
+func (fake *FakeBlackduckApi) CreateProjectArgsForCall(i int) (shared.Source, shared.Project) {
+	fake.createProjectMutex.RLock()
+	defer fake.createProjectMutex.RUnlock()
+	argsForCall := fake.createProjectArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) CreateProjectReturns(result1 *shared.Project, result2 error) {
+	fake.createProjectMutex.Lock()
+	defer fake.createProjectMutex.Unlock()
+	fake.CreateProjectStub = nil
+	fake.createProjectReturns = struct {
+		result1 *shared.Project
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) CreateProjectReturnsOnCall(i int, result1 *shared.Project, result2 error) {
+	fake.createProjectMutex.Lock()
+	defer fake.createProjectMutex.Unlock()
+	fake.CreateProjectStub = nil
+	if fake.createProjectReturnsOnCall == nil {
+		fake.createProjectReturnsOnCall = make(map[int]struct {
+			result1 *shared.Project
+			result2 error
+		})
+	}
+	fake.createProjectReturnsOnCall[i] = struct {
+		result1 *shared.Project
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) CreateVersion(arg1 shared.Source, arg2 *shared.Project, arg3 shared.Version) (*shared.Version, error) {
+	fake.createVersionMutex.Lock()
+	ret, specificReturn := fake.createVersionReturnsOnCall[len(fake.createVersionArgsForCall)]
+	fake.createVersionArgsForCall = append(fake.createVersionArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+		arg3 shared.Version
+	}{arg1, arg2, arg3})
+	stub := fake.CreateVersionStub
+	fakeReturns := fake.createVersionReturns
+	fake.recordInvocation("CreateVersion", []interface{}{arg1, arg2, arg3})
+	fake.createVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) CreateVersionCallCount() int {
+	fake.createVersionMutex.RLock()
+	defer fake.createVersionMutex.RUnlock()
+	return len(fake.createVersionArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) CreateVersionCalls(stub func(shared.Source, *shared.Project, shared.Version) (*shared.Version, error)) {
+	fake.createVersionMutex.Lock()
+	defer fake.createVersionMutex.Unlock()
+	fake.CreateVersionStub = stub
+}
+
+func (fake *FakeBlackduckApi) CreateVersionArgsForCall(i int) (shared.Source, *shared.Project, shared.Version) {
+	fake.createVersionMutex.RLock()
+	defer fake.createVersionMutex.RUnlock()
+	argsForCall := fake.createVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBlackduckApi) CreateVersionReturns(result1 *shared.Version, result2 error) {
+	fake.createVersionMutex.Lock()
+	defer fake.createVersionMutex.Unlock()
+	fake.CreateVersionStub = nil
+	fake.createVersionReturns = struct {
+		result1 *shared.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) CreateVersionReturnsOnCall(i int, result1 *shared.Version, result2 error) {
+	fake.createVersionMutex.Lock()
+	defer fake.createVersionMutex.Unlock()
+	fake.CreateVersionStub = nil
+	if fake.createVersionReturnsOnCall == nil {
+		fake.createVersionReturnsOnCall = make(map[int]struct {
+			result1 *shared.Version
+			result2 error
+		})
+	}
+	fake.createVersionReturnsOnCall[i] = struct {
+		result1 *shared.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) DeleteVersion(arg1 shared.Source, arg2 shared.Version) error {
+	fake.deleteVersionMutex.Lock()
+	ret, specificReturn := fake.deleteVersionReturnsOnCall[len(fake.deleteVersionArgsForCall)]
+	fake.deleteVersionArgsForCall = append(fake.deleteVersionArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.Version
+	}{arg1, arg2})
+	stub := fake.DeleteVersionStub
+	fakeReturns := fake.deleteVersionReturns
+	fake.recordInvocation("DeleteVersion", []interface{}{arg1, arg2})
+	fake.deleteVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) DeleteVersionCallCount() int {
+	fake.deleteVersionMutex.RLock()
+	defer fake.deleteVersionMutex.RUnlock()
+	return len(fake.deleteVersionArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) DeleteVersionCalls(stub func(shared.Source, shared.Version) error) {
+	fake.deleteVersionMutex.Lock()
+	defer fake.deleteVersionMutex.Unlock()
+	fake.DeleteVersionStub = stub
+}
+
+func (fake *FakeBlackduckApi) DeleteVersionArgsForCall(i int) (shared.Source, shared.Version) {
+	fake.deleteVersionMutex.RLock()
+	defer fake.deleteVersionMutex.RUnlock()
+	argsForCall := fake.deleteVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) DeleteVersionReturns(result1 error) {
+	fake.deleteVersionMutex.Lock()
+	defer fake.deleteVersionMutex.Unlock()
+	fake.DeleteVersionStub = nil
+	fake.deleteVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) DeleteVersionReturnsOnCall(i int, result1 error) {
+	fake.deleteVersionMutex.Lock()
+	defer fake.deleteVersionMutex.Unlock()
+	fake.DeleteVersionStub = nil
+	if fake.deleteVersionReturnsOnCall == nil {
+		fake.deleteVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) GetBomComponents(arg1 shared.Source, arg2 *shared.Version) ([]shared.BomComponent, error) {
+	fake.getBomComponentsMutex.Lock()
+	ret, specificReturn := fake.getBomComponentsReturnsOnCall[len(fake.getBomComponentsArgsForCall)]
+	fake.getBomComponentsArgsForCall = append(fake.getBomComponentsArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}{arg1, arg2})
+	stub := fake.GetBomComponentsStub
+	fakeReturns := fake.getBomComponentsReturns
+	fake.recordInvocation("GetBomComponents", []interface{}{arg1, arg2})
+	fake.getBomComponentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetBomComponentsCallCount() int {
+	fake.getBomComponentsMutex.RLock()
+	defer fake.getBomComponentsMutex.RUnlock()
+	return len(fake.getBomComponentsArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetBomComponentsCalls(stub func(shared.Source, *shared.Version) ([]shared.BomComponent, error)) {
+	fake.getBomComponentsMutex.Lock()
+	defer fake.getBomComponentsMutex.Unlock()
+	fake.GetBomComponentsStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetBomComponentsArgsForCall(i int) (shared.Source, *shared.Version) {
+	fake.getBomComponentsMutex.RLock()
+	defer fake.getBomComponentsMutex.RUnlock()
+	argsForCall := fake.getBomComponentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetBomComponentsReturns(result1 []shared.BomComponent, result2 error) {
+	fake.getBomComponentsMutex.Lock()
+	defer fake.getBomComponentsMutex.Unlock()
+	fake.GetBomComponentsStub = nil
+	fake.getBomComponentsReturns = struct {
+		result1 []shared.BomComponent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetBomComponentsReturnsOnCall(i int, result1 []shared.BomComponent, result2 error) {
+	fake.getBomComponentsMutex.Lock()
+	defer fake.getBomComponentsMutex.Unlock()
+	fake.GetBomComponentsStub = nil
+	if fake.getBomComponentsReturnsOnCall == nil {
+		fake.getBomComponentsReturnsOnCall = make(map[int]struct {
+			result1 []shared.BomComponent
+			result2 error
+		})
+	}
+	fake.getBomComponentsReturnsOnCall[i] = struct {
+		result1 []shared.BomComponent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetCodeLocations(arg1 shared.Source, arg2 *shared.Version) ([]shared.CodeLocation, error) {
+	fake.getCodeLocationsMutex.Lock()
+	ret, specificReturn := fake.getCodeLocationsReturnsOnCall[len(fake.getCodeLocationsArgsForCall)]
+	fake.getCodeLocationsArgsForCall = append(fake.getCodeLocationsArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}{arg1, arg2})
+	stub := fake.GetCodeLocationsStub
+	fakeReturns := fake.getCodeLocationsReturns
+	fake.recordInvocation("GetCodeLocations", []interface{}{arg1, arg2})
+	fake.getCodeLocationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetCodeLocationsCallCount() int {
+	fake.getCodeLocationsMutex.RLock()
+	defer fake.getCodeLocationsMutex.RUnlock()
+	return len(fake.getCodeLocationsArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetCodeLocationsCalls(stub func(shared.Source, *shared.Version) ([]shared.CodeLocation, error)) {
+	fake.getCodeLocationsMutex.Lock()
+	defer fake.getCodeLocationsMutex.Unlock()
+	fake.GetCodeLocationsStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetCodeLocationsArgsForCall(i int) (shared.Source, *shared.Version) {
+	fake.getCodeLocationsMutex.RLock()
+	defer fake.getCodeLocationsMutex.RUnlock()
+	argsForCall := fake.getCodeLocationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetCodeLocationsReturns(result1 []shared.CodeLocation, result2 error) {
+	fake.getCodeLocationsMutex.Lock()
+	defer fake.getCodeLocationsMutex.Unlock()
+	fake.GetCodeLocationsStub = nil
+	fake.getCodeLocationsReturns = struct {
+		result1 []shared.CodeLocation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetCodeLocationsReturnsOnCall(i int, result1 []shared.CodeLocation, result2 error) {
+	fake.getCodeLocationsMutex.Lock()
+	defer fake.getCodeLocationsMutex.Unlock()
+	fake.GetCodeLocationsStub = nil
+	if fake.getCodeLocationsReturnsOnCall == nil {
+		fake.getCodeLocationsReturnsOnCall = make(map[int]struct {
+			result1 []shared.CodeLocation
+			result2 error
+		})
+	}
+	fake.getCodeLocationsReturnsOnCall[i] = struct {
+		result1 []shared.CodeLocation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetComponentCount(arg1 shared.Source, arg2 *shared.Version) (int, error) {
+	fake.getComponentCountMutex.Lock()
+	ret, specificReturn := fake.getComponentCountReturnsOnCall[len(fake.getComponentCountArgsForCall)]
+	fake.getComponentCountArgsForCall = append(fake.getComponentCountArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}{arg1, arg2})
+	stub := fake.GetComponentCountStub
+	fakeReturns := fake.getComponentCountReturns
+	fake.recordInvocation("GetComponentCount", []interface{}{arg1, arg2})
+	fake.getComponentCountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetComponentCountCallCount() int {
+	fake.getComponentCountMutex.RLock()
+	defer fake.getComponentCountMutex.RUnlock()
+	return len(fake.getComponentCountArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetComponentCountCalls(stub func(shared.Source, *shared.Version) (int, error)) {
+	fake.getComponentCountMutex.Lock()
+	defer fake.getComponentCountMutex.Unlock()
+	fake.GetComponentCountStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetComponentCountArgsForCall(i int) (shared.Source, *shared.Version) {
+	fake.getComponentCountMutex.RLock()
+	defer fake.getComponentCountMutex.RUnlock()
+	argsForCall := fake.getComponentCountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetComponentCountReturns(result1 int, result2 error) {
+	fake.getComponentCountMutex.Lock()
+	defer fake.getComponentCountMutex.Unlock()
+	fake.GetComponentCountStub = nil
+	fake.getComponentCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetComponentCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.getComponentCountMutex.Lock()
+	defer fake.getComponentCountMutex.Unlock()
+	fake.GetComponentCountStub = nil
+	if fake.getComponentCountReturnsOnCall == nil {
+		fake.getComponentCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.getComponentCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetComponentOrigins(arg1 shared.Source, arg2 shared.BomComponent) ([]shared.Origin, error) {
+	fake.getComponentOriginsMutex.Lock()
+	ret, specificReturn := fake.getComponentOriginsReturnsOnCall[len(fake.getComponentOriginsArgsForCall)]
+	fake.getComponentOriginsArgsForCall = append(fake.getComponentOriginsArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}{arg1, arg2})
+	stub := fake.GetComponentOriginsStub
+	fakeReturns := fake.getComponentOriginsReturns
+	fake.recordInvocation("GetComponentOrigins", []interface{}{arg1, arg2})
+	fake.getComponentOriginsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetComponentOriginsCallCount() int {
+	fake.getComponentOriginsMutex.RLock()
+	defer fake.getComponentOriginsMutex.RUnlock()
+	return len(fake.getComponentOriginsArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetComponentOriginsCalls(stub func(shared.Source, shared.BomComponent) ([]shared.Origin, error)) {
+	fake.getComponentOriginsMutex.Lock()
+	defer fake.getComponentOriginsMutex.Unlock()
+	fake.GetComponentOriginsStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetComponentOriginsArgsForCall(i int) (shared.Source, shared.BomComponent) {
+	fake.getComponentOriginsMutex.RLock()
+	defer fake.getComponentOriginsMutex.RUnlock()
+	argsForCall := fake.getComponentOriginsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetComponentOriginsReturns(result1 []shared.Origin, result2 error) {
+	fake.getComponentOriginsMutex.Lock()
+	defer fake.getComponentOriginsMutex.Unlock()
+	fake.GetComponentOriginsStub = nil
+	fake.getComponentOriginsReturns = struct {
+		result1 []shared.Origin
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetComponentOriginsReturnsOnCall(i int, result1 []shared.Origin, result2 error) {
+	fake.getComponentOriginsMutex.Lock()
+	defer fake.getComponentOriginsMutex.Unlock()
+	fake.GetComponentOriginsStub = nil
+	if fake.getComponentOriginsReturnsOnCall == nil {
+		fake.getComponentOriginsReturnsOnCall = make(map[int]struct {
+			result1 []shared.Origin
+			result2 error
+		})
+	}
+	fake.getComponentOriginsReturnsOnCall[i] = struct {
+		result1 []shared.Origin
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetComponentPolicyRules(arg1 shared.Source, arg2 shared.BomComponent) ([]shared.PolicyRule, error) {
+	fake.getComponentPolicyRulesMutex.Lock()
+	ret, specificReturn := fake.getComponentPolicyRulesReturnsOnCall[len(fake.getComponentPolicyRulesArgsForCall)]
+	fake.getComponentPolicyRulesArgsForCall = append(fake.getComponentPolicyRulesArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}{arg1, arg2})
+	stub := fake.GetComponentPolicyRulesStub
+	fakeReturns := fake.getComponentPolicyRulesReturns
+	fake.recordInvocation("GetComponentPolicyRules", []interface{}{arg1, arg2})
+	fake.getComponentPolicyRulesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetComponentPolicyRulesCallCount() int {
+	fake.getComponentPolicyRulesMutex.RLock()
+	defer fake.getComponentPolicyRulesMutex.RUnlock()
+	return len(fake.getComponentPolicyRulesArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetComponentPolicyRulesCalls(stub func(shared.Source, shared.BomComponent) ([]shared.PolicyRule, error)) {
+	fake.getComponentPolicyRulesMutex.Lock()
+	defer fake.getComponentPolicyRulesMutex.Unlock()
+	fake.GetComponentPolicyRulesStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetComponentPolicyRulesArgsForCall(i int) (shared.Source, shared.BomComponent) {
+	fake.getComponentPolicyRulesMutex.RLock()
+	defer fake.getComponentPolicyRulesMutex.RUnlock()
+	argsForCall := fake.getComponentPolicyRulesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetComponentPolicyRulesReturns(result1 []shared.PolicyRule, result2 error) {
+	fake.getComponentPolicyRulesMutex.Lock()
+	defer fake.getComponentPolicyRulesMutex.Unlock()
+	fake.GetComponentPolicyRulesStub = nil
+	fake.getComponentPolicyRulesReturns = struct {
+		result1 []shared.PolicyRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetComponentPolicyRulesReturnsOnCall(i int, result1 []shared.PolicyRule, result2 error) {
+	fake.getComponentPolicyRulesMutex.Lock()
+	defer fake.getComponentPolicyRulesMutex.Unlock()
+	fake.GetComponentPolicyRulesStub = nil
+	if fake.getComponentPolicyRulesReturnsOnCall == nil {
+		fake.getComponentPolicyRulesReturnsOnCall = make(map[int]struct {
+			result1 []shared.PolicyRule
+			result2 error
+		})
+	}
+	fake.getComponentPolicyRulesReturnsOnCall[i] = struct {
+		result1 []shared.PolicyRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetComponentVersionVulnerabilities(arg1 shared.Source, arg2 string) ([]shared.ComponentVulnerability, error) {
+	fake.getComponentVersionVulnerabilitiesMutex.Lock()
+	ret, specificReturn := fake.getComponentVersionVulnerabilitiesReturnsOnCall[len(fake.getComponentVersionVulnerabilitiesArgsForCall)]
+	fake.getComponentVersionVulnerabilitiesArgsForCall = append(fake.getComponentVersionVulnerabilitiesArgsForCall, struct {
+		arg1 shared.Source
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetComponentVersionVulnerabilitiesStub
+	fakeReturns := fake.getComponentVersionVulnerabilitiesReturns
+	fake.recordInvocation("GetComponentVersionVulnerabilities", []interface{}{arg1, arg2})
+	fake.getComponentVersionVulnerabilitiesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetComponentVersionVulnerabilitiesCallCount() int {
+	fake.getComponentVersionVulnerabilitiesMutex.RLock()
+	defer fake.getComponentVersionVulnerabilitiesMutex.RUnlock()
+	return len(fake.getComponentVersionVulnerabilitiesArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetComponentVersionVulnerabilitiesCalls(stub func(shared.Source, string) ([]shared.ComponentVulnerability, error)) {
+	fake.getComponentVersionVulnerabilitiesMutex.Lock()
+	defer fake.getComponentVersionVulnerabilitiesMutex.Unlock()
+	fake.GetComponentVersionVulnerabilitiesStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetComponentVersionVulnerabilitiesArgsForCall(i int) (shared.Source, string) {
+	fake.getComponentVersionVulnerabilitiesMutex.RLock()
+	defer fake.getComponentVersionVulnerabilitiesMutex.RUnlock()
+	argsForCall := fake.getComponentVersionVulnerabilitiesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetComponentVersionVulnerabilitiesReturns(result1 []shared.ComponentVulnerability, result2 error) {
+	fake.getComponentVersionVulnerabilitiesMutex.Lock()
+	defer fake.getComponentVersionVulnerabilitiesMutex.Unlock()
+	fake.GetComponentVersionVulnerabilitiesStub = nil
+	fake.getComponentVersionVulnerabilitiesReturns = struct {
+		result1 []shared.ComponentVulnerability
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetComponentVersionVulnerabilitiesReturnsOnCall(i int, result1 []shared.ComponentVulnerability, result2 error) {
+	fake.getComponentVersionVulnerabilitiesMutex.Lock()
+	defer fake.getComponentVersionVulnerabilitiesMutex.Unlock()
+	fake.GetComponentVersionVulnerabilitiesStub = nil
+	if fake.getComponentVersionVulnerabilitiesReturnsOnCall == nil {
+		fake.getComponentVersionVulnerabilitiesReturnsOnCall = make(map[int]struct {
+			result1 []shared.ComponentVulnerability
+			result2 error
+		})
+	}
+	fake.getComponentVersionVulnerabilitiesReturnsOnCall[i] = struct {
+		result1 []shared.ComponentVulnerability
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetCopyrights(arg1 shared.Source, arg2 shared.BomOrigin) ([]shared.Copyright, error) {
+	fake.getCopyrightsMutex.Lock()
+	ret, specificReturn := fake.getCopyrightsReturnsOnCall[len(fake.getCopyrightsArgsForCall)]
+	fake.getCopyrightsArgsForCall = append(fake.getCopyrightsArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.BomOrigin
+	}{arg1, arg2})
+	stub := fake.GetCopyrightsStub
+	fakeReturns := fake.getCopyrightsReturns
+	fake.recordInvocation("GetCopyrights", []interface{}{arg1, arg2})
+	fake.getCopyrightsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetCopyrightsCallCount() int {
+	fake.getCopyrightsMutex.RLock()
+	defer fake.getCopyrightsMutex.RUnlock()
+	return len(fake.getCopyrightsArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetCopyrightsCalls(stub func(shared.Source, shared.BomOrigin) ([]shared.Copyright, error)) {
+	fake.getCopyrightsMutex.Lock()
+	defer fake.getCopyrightsMutex.Unlock()
+	fake.GetCopyrightsStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetCopyrightsArgsForCall(i int) (shared.Source, shared.BomOrigin) {
+	fake.getCopyrightsMutex.RLock()
+	defer fake.getCopyrightsMutex.RUnlock()
+	argsForCall := fake.getCopyrightsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetCopyrightsReturns(result1 []shared.Copyright, result2 error) {
+	fake.getCopyrightsMutex.Lock()
+	defer fake.getCopyrightsMutex.Unlock()
+	fake.GetCopyrightsStub = nil
+	fake.getCopyrightsReturns = struct {
+		result1 []shared.Copyright
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetCopyrightsReturnsOnCall(i int, result1 []shared.Copyright, result2 error) {
+	fake.getCopyrightsMutex.Lock()
+	defer fake.getCopyrightsMutex.Unlock()
+	fake.GetCopyrightsStub = nil
+	if fake.getCopyrightsReturnsOnCall == nil {
+		fake.getCopyrightsReturnsOnCall = make(map[int]struct {
+			result1 []shared.Copyright
+			result2 error
+		})
+	}
+	fake.getCopyrightsReturnsOnCall[i] = struct {
+		result1 []shared.Copyright
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetLicenseText(arg1 shared.Source, arg2 shared.BomLicense) (string, error) {
+	fake.getLicenseTextMutex.Lock()
+	ret, specificReturn := fake.getLicenseTextReturnsOnCall[len(fake.getLicenseTextArgsForCall)]
+	fake.getLicenseTextArgsForCall = append(fake.getLicenseTextArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.BomLicense
+	}{arg1, arg2})
+	stub := fake.GetLicenseTextStub
+	fakeReturns := fake.getLicenseTextReturns
+	fake.recordInvocation("GetLicenseText", []interface{}{arg1, arg2})
+	fake.getLicenseTextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetLicenseTextCallCount() int {
+	fake.getLicenseTextMutex.RLock()
+	defer fake.getLicenseTextMutex.RUnlock()
+	return len(fake.getLicenseTextArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetLicenseTextCalls(stub func(shared.Source, shared.BomLicense) (string, error)) {
+	fake.getLicenseTextMutex.Lock()
+	defer fake.getLicenseTextMutex.Unlock()
+	fake.GetLicenseTextStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetLicenseTextArgsForCall(i int) (shared.Source, shared.BomLicense) {
+	fake.getLicenseTextMutex.RLock()
+	defer fake.getLicenseTextMutex.RUnlock()
+	argsForCall := fake.getLicenseTextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetLicenseTextReturns(result1 string, result2 error) {
+	fake.getLicenseTextMutex.Lock()
+	defer fake.getLicenseTextMutex.Unlock()
+	fake.GetLicenseTextStub = nil
+	fake.getLicenseTextReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetLicenseTextReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getLicenseTextMutex.Lock()
+	defer fake.getLicenseTextMutex.Unlock()
+	fake.GetLicenseTextStub = nil
+	if fake.getLicenseTextReturnsOnCall == nil {
+		fake.getLicenseTextReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getLicenseTextReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetLicenseUrl(arg1 shared.Source, arg2 string) (string, error) {
+	fake.getLicenseUrlMutex.Lock()
+	ret, specificReturn := fake.getLicenseUrlReturnsOnCall[len(fake.getLicenseUrlArgsForCall)]
+	fake.getLicenseUrlArgsForCall = append(fake.getLicenseUrlArgsForCall, struct {
+		arg1 shared.Source
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetLicenseUrlStub
+	fakeReturns := fake.getLicenseUrlReturns
+	fake.recordInvocation("GetLicenseUrl", []interface{}{arg1, arg2})
+	fake.getLicenseUrlMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetLicenseUrlCallCount() int {
+	fake.getLicenseUrlMutex.RLock()
+	defer fake.getLicenseUrlMutex.RUnlock()
+	return len(fake.getLicenseUrlArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetLicenseUrlCalls(stub func(shared.Source, string) (string, error)) {
+	fake.getLicenseUrlMutex.Lock()
+	defer fake.getLicenseUrlMutex.Unlock()
+	fake.GetLicenseUrlStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetLicenseUrlArgsForCall(i int) (shared.Source, string) {
+	fake.getLicenseUrlMutex.RLock()
+	defer fake.getLicenseUrlMutex.RUnlock()
+	argsForCall := fake.getLicenseUrlArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetLicenseUrlReturns(result1 string, result2 error) {
+	fake.getLicenseUrlMutex.Lock()
+	defer fake.getLicenseUrlMutex.Unlock()
+	fake.GetLicenseUrlStub = nil
+	fake.getLicenseUrlReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetLicenseUrlReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getLicenseUrlMutex.Lock()
+	defer fake.getLicenseUrlMutex.Unlock()
+	fake.GetLicenseUrlStub = nil
+	if fake.getLicenseUrlReturnsOnCall == nil {
+		fake.getLicenseUrlReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getLicenseUrlReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetMatchedFiles(arg1 shared.Source, arg2 shared.BomComponent) ([]shared.MatchedFile, error) {
+	fake.getMatchedFilesMutex.Lock()
+	ret, specificReturn := fake.getMatchedFilesReturnsOnCall[len(fake.getMatchedFilesArgsForCall)]
+	fake.getMatchedFilesArgsForCall = append(fake.getMatchedFilesArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}{arg1, arg2})
+	stub := fake.GetMatchedFilesStub
+	fakeReturns := fake.getMatchedFilesReturns
+	fake.recordInvocation("GetMatchedFiles", []interface{}{arg1, arg2})
+	fake.getMatchedFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetMatchedFilesCallCount() int {
+	fake.getMatchedFilesMutex.RLock()
+	defer fake.getMatchedFilesMutex.RUnlock()
+	return len(fake.getMatchedFilesArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetMatchedFilesCalls(stub func(shared.Source, shared.BomComponent) ([]shared.MatchedFile, error)) {
+	fake.getMatchedFilesMutex.Lock()
+	defer fake.getMatchedFilesMutex.Unlock()
+	fake.GetMatchedFilesStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetMatchedFilesArgsForCall(i int) (shared.Source, shared.BomComponent) {
+	fake.getMatchedFilesMutex.RLock()
+	defer fake.getMatchedFilesMutex.RUnlock()
+	argsForCall := fake.getMatchedFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetMatchedFilesReturns(result1 []shared.MatchedFile, result2 error) {
+	fake.getMatchedFilesMutex.Lock()
+	defer fake.getMatchedFilesMutex.Unlock()
+	fake.GetMatchedFilesStub = nil
+	fake.getMatchedFilesReturns = struct {
+		result1 []shared.MatchedFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetMatchedFilesReturnsOnCall(i int, result1 []shared.MatchedFile, result2 error) {
+	fake.getMatchedFilesMutex.Lock()
+	defer fake.getMatchedFilesMutex.Unlock()
+	fake.GetMatchedFilesStub = nil
+	if fake.getMatchedFilesReturnsOnCall == nil {
+		fake.getMatchedFilesReturnsOnCall = make(map[int]struct {
+			result1 []shared.MatchedFile
+			result2 error
+		})
+	}
+	fake.getMatchedFilesReturnsOnCall[i] = struct {
+		result1 []shared.MatchedFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetPolicyRules(arg1 shared.Source) ([]shared.PolicyRule, error) {
+	fake.getPolicyRulesMutex.Lock()
+	ret, specificReturn := fake.getPolicyRulesReturnsOnCall[len(fake.getPolicyRulesArgsForCall)]
+	fake.getPolicyRulesArgsForCall = append(fake.getPolicyRulesArgsForCall, struct {
+		arg1 shared.Source
+	}{arg1})
+	stub := fake.GetPolicyRulesStub
+	fakeReturns := fake.getPolicyRulesReturns
+	fake.recordInvocation("GetPolicyRules", []interface{}{arg1})
+	fake.getPolicyRulesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetPolicyRulesCallCount() int {
+	fake.getPolicyRulesMutex.RLock()
+	defer fake.getPolicyRulesMutex.RUnlock()
+	return len(fake.getPolicyRulesArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetPolicyRulesCalls(stub func(shared.Source) ([]shared.PolicyRule, error)) {
+	fake.getPolicyRulesMutex.Lock()
+	defer fake.getPolicyRulesMutex.Unlock()
+	fake.GetPolicyRulesStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetPolicyRulesArgsForCall(i int) shared.Source {
+	fake.getPolicyRulesMutex.RLock()
+	defer fake.getPolicyRulesMutex.RUnlock()
+	argsForCall := fake.getPolicyRulesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBlackduckApi) GetPolicyRulesReturns(result1 []shared.PolicyRule, result2 error) {
+	fake.getPolicyRulesMutex.Lock()
+	defer fake.getPolicyRulesMutex.Unlock()
+	fake.GetPolicyRulesStub = nil
+	fake.getPolicyRulesReturns = struct {
+		result1 []shared.PolicyRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetPolicyRulesReturnsOnCall(i int, result1 []shared.PolicyRule, result2 error) {
+	fake.getPolicyRulesMutex.Lock()
+	defer fake.getPolicyRulesMutex.Unlock()
+	fake.GetPolicyRulesStub = nil
+	if fake.getPolicyRulesReturnsOnCall == nil {
+		fake.getPolicyRulesReturnsOnCall = make(map[int]struct {
+			result1 []shared.PolicyRule
+			result2 error
+		})
+	}
+	fake.getPolicyRulesReturnsOnCall[i] = struct {
+		result1 []shared.PolicyRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetPolicyStatus(arg1 shared.Source, arg2 *shared.Version) (shared.PolicyStatus, error) {
+	fake.getPolicyStatusMutex.Lock()
+	ret, specificReturn := fake.getPolicyStatusReturnsOnCall[len(fake.getPolicyStatusArgsForCall)]
+	fake.getPolicyStatusArgsForCall = append(fake.getPolicyStatusArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}{arg1, arg2})
+	stub := fake.GetPolicyStatusStub
+	fakeReturns := fake.getPolicyStatusReturns
+	fake.recordInvocation("GetPolicyStatus", []interface{}{arg1, arg2})
+	fake.getPolicyStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetPolicyStatusCallCount() int {
+	fake.getPolicyStatusMutex.RLock()
+	defer fake.getPolicyStatusMutex.RUnlock()
+	return len(fake.getPolicyStatusArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetPolicyStatusCalls(stub func(shared.Source, *shared.Version) (shared.PolicyStatus, error)) {
+	fake.getPolicyStatusMutex.Lock()
+	defer fake.getPolicyStatusMutex.Unlock()
+	fake.GetPolicyStatusStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetPolicyStatusArgsForCall(i int) (shared.Source, *shared.Version) {
+	fake.getPolicyStatusMutex.RLock()
+	defer fake.getPolicyStatusMutex.RUnlock()
+	argsForCall := fake.getPolicyStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetPolicyStatusReturns(result1 shared.PolicyStatus, result2 error) {
+	fake.getPolicyStatusMutex.Lock()
+	defer fake.getPolicyStatusMutex.Unlock()
+	fake.GetPolicyStatusStub = nil
+	fake.getPolicyStatusReturns = struct {
+		result1 shared.PolicyStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetPolicyStatusReturnsOnCall(i int, result1 shared.PolicyStatus, result2 error) {
+	fake.getPolicyStatusMutex.Lock()
+	defer fake.getPolicyStatusMutex.Unlock()
+	fake.GetPolicyStatusStub = nil
+	if fake.getPolicyStatusReturnsOnCall == nil {
+		fake.getPolicyStatusReturnsOnCall = make(map[int]struct {
+			result1 shared.PolicyStatus
+			result2 error
+		})
+	}
+	fake.getPolicyStatusReturnsOnCall[i] = struct {
+		result1 shared.PolicyStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectByName(arg1 shared.Source) (*shared.Project, error) {
+	fake.getProjectByNameMutex.Lock()
+	ret, specificReturn := fake.getProjectByNameReturnsOnCall[len(fake.getProjectByNameArgsForCall)]
+	fake.getProjectByNameArgsForCall = append(fake.getProjectByNameArgsForCall, struct {
+		arg1 shared.Source
+	}{arg1})
+	stub := fake.GetProjectByNameStub
+	fakeReturns := fake.getProjectByNameReturns
+	fake.recordInvocation("GetProjectByName", []interface{}{arg1})
+	fake.getProjectByNameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetProjectByNameCallCount() int {
+	fake.getProjectByNameMutex.RLock()
+	defer fake.getProjectByNameMutex.RUnlock()
+	return len(fake.getProjectByNameArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetProjectByNameCalls(stub func(shared.Source) (*shared.Project, error)) {
+	fake.getProjectByNameMutex.Lock()
+	defer fake.getProjectByNameMutex.Unlock()
+	fake.GetProjectByNameStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetProjectByNameArgsForCall(i int) shared.Source {
+	fake.getProjectByNameMutex.RLock()
+	defer fake.getProjectByNameMutex.RUnlock()
+	argsForCall := fake.getProjectByNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBlackduckApi) GetProjectByNameReturns(result1 *shared.Project, result2 error) {
+	fake.getProjectByNameMutex.Lock()
+	defer fake.getProjectByNameMutex.Unlock()
+	fake.GetProjectByNameStub = nil
+	fake.getProjectByNameReturns = struct {
+		result1 *shared.Project
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectByNameReturnsOnCall(i int, result1 *shared.Project, result2 error) {
+	fake.getProjectByNameMutex.Lock()
+	defer fake.getProjectByNameMutex.Unlock()
+	fake.GetProjectByNameStub = nil
+	if fake.getProjectByNameReturnsOnCall == nil {
+		fake.getProjectByNameReturnsOnCall = make(map[int]struct {
+			result1 *shared.Project
+			result2 error
+		})
+	}
+	fake.getProjectByNameReturnsOnCall[i] = struct {
+		result1 *shared.Project
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectCustomFields(arg1 shared.Source, arg2 *shared.Project) ([]shared.CustomField, error) {
+	fake.getProjectCustomFieldsMutex.Lock()
+	ret, specificReturn := fake.getProjectCustomFieldsReturnsOnCall[len(fake.getProjectCustomFieldsArgsForCall)]
+	fake.getProjectCustomFieldsArgsForCall = append(fake.getProjectCustomFieldsArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+	}{arg1, arg2})
+	stub := fake.GetProjectCustomFieldsStub
+	fakeReturns := fake.getProjectCustomFieldsReturns
+	fake.recordInvocation("GetProjectCustomFields", []interface{}{arg1, arg2})
+	fake.getProjectCustomFieldsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetProjectCustomFieldsCallCount() int {
+	fake.getProjectCustomFieldsMutex.RLock()
+	defer fake.getProjectCustomFieldsMutex.RUnlock()
+	return len(fake.getProjectCustomFieldsArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetProjectCustomFieldsCalls(stub func(shared.Source, *shared.Project) ([]shared.CustomField, error)) {
+	fake.getProjectCustomFieldsMutex.Lock()
+	defer fake.getProjectCustomFieldsMutex.Unlock()
+	fake.GetProjectCustomFieldsStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetProjectCustomFieldsArgsForCall(i int) (shared.Source, *shared.Project) {
+	fake.getProjectCustomFieldsMutex.RLock()
+	defer fake.getProjectCustomFieldsMutex.RUnlock()
+	argsForCall := fake.getProjectCustomFieldsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetProjectCustomFieldsReturns(result1 []shared.CustomField, result2 error) {
+	fake.getProjectCustomFieldsMutex.Lock()
+	defer fake.getProjectCustomFieldsMutex.Unlock()
+	fake.GetProjectCustomFieldsStub = nil
+	fake.getProjectCustomFieldsReturns = struct {
+		result1 []shared.CustomField
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectCustomFieldsReturnsOnCall(i int, result1 []shared.CustomField, result2 error) {
+	fake.getProjectCustomFieldsMutex.Lock()
+	defer fake.getProjectCustomFieldsMutex.Unlock()
+	fake.GetProjectCustomFieldsStub = nil
+	if fake.getProjectCustomFieldsReturnsOnCall == nil {
+		fake.getProjectCustomFieldsReturnsOnCall = make(map[int]struct {
+			result1 []shared.CustomField
+			result2 error
+		})
+	}
+	fake.getProjectCustomFieldsReturnsOnCall[i] = struct {
+		result1 []shared.CustomField
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectGroupUrl(arg1 shared.Source, arg2 string) (string, error) {
+	fake.getProjectGroupUrlMutex.Lock()
+	ret, specificReturn := fake.getProjectGroupUrlReturnsOnCall[len(fake.getProjectGroupUrlArgsForCall)]
+	fake.getProjectGroupUrlArgsForCall = append(fake.getProjectGroupUrlArgsForCall, struct {
+		arg1 shared.Source
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetProjectGroupUrlStub
+	fakeReturns := fake.getProjectGroupUrlReturns
+	fake.recordInvocation("GetProjectGroupUrl", []interface{}{arg1, arg2})
+	fake.getProjectGroupUrlMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetProjectGroupUrlCallCount() int {
+	fake.getProjectGroupUrlMutex.RLock()
+	defer fake.getProjectGroupUrlMutex.RUnlock()
+	return len(fake.getProjectGroupUrlArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetProjectGroupUrlCalls(stub func(shared.Source, string) (string, error)) {
+	fake.getProjectGroupUrlMutex.Lock()
+	defer fake.getProjectGroupUrlMutex.Unlock()
+	fake.GetProjectGroupUrlStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetProjectGroupUrlArgsForCall(i int) (shared.Source, string) {
+	fake.getProjectGroupUrlMutex.RLock()
+	defer fake.getProjectGroupUrlMutex.RUnlock()
+	argsForCall := fake.getProjectGroupUrlArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetProjectGroupUrlReturns(result1 string, result2 error) {
+	fake.getProjectGroupUrlMutex.Lock()
+	defer fake.getProjectGroupUrlMutex.Unlock()
+	fake.GetProjectGroupUrlStub = nil
+	fake.getProjectGroupUrlReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectGroupUrlReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getProjectGroupUrlMutex.Lock()
+	defer fake.getProjectGroupUrlMutex.Unlock()
+	fake.GetProjectGroupUrlStub = nil
+	if fake.getProjectGroupUrlReturnsOnCall == nil {
+		fake.getProjectGroupUrlReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getProjectGroupUrlReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectTags(arg1 shared.Source, arg2 *shared.Project) ([]shared.Tag, error) {
+	fake.getProjectTagsMutex.Lock()
+	ret, specificReturn := fake.getProjectTagsReturnsOnCall[len(fake.getProjectTagsArgsForCall)]
+	fake.getProjectTagsArgsForCall = append(fake.getProjectTagsArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+	}{arg1, arg2})
+	stub := fake.GetProjectTagsStub
+	fakeReturns := fake.getProjectTagsReturns
+	fake.recordInvocation("GetProjectTags", []interface{}{arg1, arg2})
+	fake.getProjectTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetProjectTagsCallCount() int {
+	fake.getProjectTagsMutex.RLock()
+	defer fake.getProjectTagsMutex.RUnlock()
+	return len(fake.getProjectTagsArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetProjectTagsCalls(stub func(shared.Source, *shared.Project) ([]shared.Tag, error)) {
+	fake.getProjectTagsMutex.Lock()
+	defer fake.getProjectTagsMutex.Unlock()
+	fake.GetProjectTagsStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetProjectTagsArgsForCall(i int) (shared.Source, *shared.Project) {
+	fake.getProjectTagsMutex.RLock()
+	defer fake.getProjectTagsMutex.RUnlock()
+	argsForCall := fake.getProjectTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetProjectTagsReturns(result1 []shared.Tag, result2 error) {
+	fake.getProjectTagsMutex.Lock()
+	defer fake.getProjectTagsMutex.Unlock()
+	fake.GetProjectTagsStub = nil
+	fake.getProjectTagsReturns = struct {
+		result1 []shared.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectTagsReturnsOnCall(i int, result1 []shared.Tag, result2 error) {
+	fake.getProjectTagsMutex.Lock()
+	defer fake.getProjectTagsMutex.Unlock()
+	fake.GetProjectTagsStub = nil
+	if fake.getProjectTagsReturnsOnCall == nil {
+		fake.getProjectTagsReturnsOnCall = make(map[int]struct {
+			result1 []shared.Tag
+			result2 error
+		})
+	}
+	fake.getProjectTagsReturnsOnCall[i] = struct {
+		result1 []shared.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectUserGroups(arg1 shared.Source, arg2 *shared.Project) ([]shared.UserGroup, error) {
+	fake.getProjectUserGroupsMutex.Lock()
+	ret, specificReturn := fake.getProjectUserGroupsReturnsOnCall[len(fake.getProjectUserGroupsArgsForCall)]
+	fake.getProjectUserGroupsArgsForCall = append(fake.getProjectUserGroupsArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+	}{arg1, arg2})
+	stub := fake.GetProjectUserGroupsStub
+	fakeReturns := fake.getProjectUserGroupsReturns
+	fake.recordInvocation("GetProjectUserGroups", []interface{}{arg1, arg2})
+	fake.getProjectUserGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetProjectUserGroupsCallCount() int {
+	fake.getProjectUserGroupsMutex.RLock()
+	defer fake.getProjectUserGroupsMutex.RUnlock()
+	return len(fake.getProjectUserGroupsArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetProjectUserGroupsCalls(stub func(shared.Source, *shared.Project) ([]shared.UserGroup, error)) {
+	fake.getProjectUserGroupsMutex.Lock()
+	defer fake.getProjectUserGroupsMutex.Unlock()
+	fake.GetProjectUserGroupsStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetProjectUserGroupsArgsForCall(i int) (shared.Source, *shared.Project) {
+	fake.getProjectUserGroupsMutex.RLock()
+	defer fake.getProjectUserGroupsMutex.RUnlock()
+	argsForCall := fake.getProjectUserGroupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetProjectUserGroupsReturns(result1 []shared.UserGroup, result2 error) {
+	fake.getProjectUserGroupsMutex.Lock()
+	defer fake.getProjectUserGroupsMutex.Unlock()
+	fake.GetProjectUserGroupsStub = nil
+	fake.getProjectUserGroupsReturns = struct {
+		result1 []shared.UserGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectUserGroupsReturnsOnCall(i int, result1 []shared.UserGroup, result2 error) {
+	fake.getProjectUserGroupsMutex.Lock()
+	defer fake.getProjectUserGroupsMutex.Unlock()
+	fake.GetProjectUserGroupsStub = nil
+	if fake.getProjectUserGroupsReturnsOnCall == nil {
+		fake.getProjectUserGroupsReturnsOnCall = make(map[int]struct {
+			result1 []shared.UserGroup
+			result2 error
+		})
+	}
+	fake.getProjectUserGroupsReturnsOnCall[i] = struct {
+		result1 []shared.UserGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectVersions(arg1 shared.Source, arg2 *shared.Project) ([]shared.Version, error) {
+	fake.getProjectVersionsMutex.Lock()
+	ret, specificReturn := fake.getProjectVersionsReturnsOnCall[len(fake.getProjectVersionsArgsForCall)]
+	fake.getProjectVersionsArgsForCall = append(fake.getProjectVersionsArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Project
+	}{arg1, arg2})
+	stub := fake.GetProjectVersionsStub
+	fakeReturns := fake.getProjectVersionsReturns
+	fake.recordInvocation("GetProjectVersions", []interface{}{arg1, arg2})
+	fake.getProjectVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetProjectVersionsCallCount() int {
+	fake.getProjectVersionsMutex.RLock()
+	defer fake.getProjectVersionsMutex.RUnlock()
+	return len(fake.getProjectVersionsArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetProjectVersionsCalls(stub func(shared.Source, *shared.Project) ([]shared.Version, error)) {
+	fake.getProjectVersionsMutex.Lock()
+	defer fake.getProjectVersionsMutex.Unlock()
+	fake.GetProjectVersionsStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetProjectVersionsArgsForCall(i int) (shared.Source, *shared.Project) {
+	fake.getProjectVersionsMutex.RLock()
+	defer fake.getProjectVersionsMutex.RUnlock()
+	argsForCall := fake.getProjectVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetProjectVersionsReturns(result1 []shared.Version, result2 error) {
+	fake.getProjectVersionsMutex.Lock()
+	defer fake.getProjectVersionsMutex.Unlock()
+	fake.GetProjectVersionsStub = nil
+	fake.getProjectVersionsReturns = struct {
+		result1 []shared.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetProjectVersionsReturnsOnCall(i int, result1 []shared.Version, result2 error) {
+	fake.getProjectVersionsMutex.Lock()
+	defer fake.getProjectVersionsMutex.Unlock()
+	fake.GetProjectVersionsStub = nil
+	if fake.getProjectVersionsReturnsOnCall == nil {
+		fake.getProjectVersionsReturnsOnCall = make(map[int]struct {
+			result1 []shared.Version
+			result2 error
+		})
+	}
+	fake.getProjectVersionsReturnsOnCall[i] = struct {
+		result1 []shared.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetReport(arg1 shared.Source, arg2 shared.Report) (shared.Report, error) {
+	fake.getReportMutex.Lock()
+	ret, specificReturn := fake.getReportReturnsOnCall[len(fake.getReportArgsForCall)]
+	fake.getReportArgsForCall = append(fake.getReportArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.Report
+	}{arg1, arg2})
+	stub := fake.GetReportStub
+	fakeReturns := fake.getReportReturns
+	fake.recordInvocation("GetReport", []interface{}{arg1, arg2})
+	fake.getReportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetReportCallCount() int {
+	fake.getReportMutex.RLock()
+	defer fake.getReportMutex.RUnlock()
+	return len(fake.getReportArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetReportCalls(stub func(shared.Source, shared.Report) (shared.Report, error)) {
+	fake.getReportMutex.Lock()
+	defer fake.getReportMutex.Unlock()
+	fake.GetReportStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetReportArgsForCall(i int) (shared.Source, shared.Report) {
+	fake.getReportMutex.RLock()
+	defer fake.getReportMutex.RUnlock()
+	argsForCall := fake.getReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetReportReturns(result1 shared.Report, result2 error) {
+	fake.getReportMutex.Lock()
+	defer fake.getReportMutex.Unlock()
+	fake.GetReportStub = nil
+	fake.getReportReturns = struct {
+		result1 shared.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetReportReturnsOnCall(i int, result1 shared.Report, result2 error) {
+	fake.getReportMutex.Lock()
+	defer fake.getReportMutex.Unlock()
+	fake.GetReportStub = nil
+	if fake.getReportReturnsOnCall == nil {
+		fake.getReportReturnsOnCall = make(map[int]struct {
+			result1 shared.Report
+			result2 error
+		})
+	}
+	fake.getReportReturnsOnCall[i] = struct {
+		result1 shared.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetReportContent(arg1 shared.Source, arg2 shared.Report) (string, error) {
+	fake.getReportContentMutex.Lock()
+	ret, specificReturn := fake.getReportContentReturnsOnCall[len(fake.getReportContentArgsForCall)]
+	fake.getReportContentArgsForCall = append(fake.getReportContentArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.Report
+	}{arg1, arg2})
+	stub := fake.GetReportContentStub
+	fakeReturns := fake.getReportContentReturns
+	fake.recordInvocation("GetReportContent", []interface{}{arg1, arg2})
+	fake.getReportContentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetReportContentCallCount() int {
+	fake.getReportContentMutex.RLock()
+	defer fake.getReportContentMutex.RUnlock()
+	return len(fake.getReportContentArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetReportContentCalls(stub func(shared.Source, shared.Report) (string, error)) {
+	fake.getReportContentMutex.Lock()
+	defer fake.getReportContentMutex.Unlock()
+	fake.GetReportContentStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetReportContentArgsForCall(i int) (shared.Source, shared.Report) {
+	fake.getReportContentMutex.RLock()
+	defer fake.getReportContentMutex.RUnlock()
+	argsForCall := fake.getReportContentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetReportContentReturns(result1 string, result2 error) {
+	fake.getReportContentMutex.Lock()
+	defer fake.getReportContentMutex.Unlock()
+	fake.GetReportContentStub = nil
+	fake.getReportContentReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetReportContentReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getReportContentMutex.Lock()
+	defer fake.getReportContentMutex.Unlock()
+	fake.GetReportContentStub = nil
+	if fake.getReportContentReturnsOnCall == nil {
+		fake.getReportContentReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getReportContentReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetRiskProfile(arg1 shared.Source, arg2 *shared.Version) (shared.RiskProfile, error) {
+	fake.getRiskProfileMutex.Lock()
+	ret, specificReturn := fake.getRiskProfileReturnsOnCall[len(fake.getRiskProfileArgsForCall)]
+	fake.getRiskProfileArgsForCall = append(fake.getRiskProfileArgsForCall, struct {
+		arg1 shared.Source
+		arg2 *shared.Version
+	}{arg1, arg2})
+	stub := fake.GetRiskProfileStub
+	fakeReturns := fake.getRiskProfileReturns
+	fake.recordInvocation("GetRiskProfile", []interface{}{arg1, arg2})
+	fake.getRiskProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetRiskProfileCallCount() int {
+	fake.getRiskProfileMutex.RLock()
+	defer fake.getRiskProfileMutex.RUnlock()
+	return len(fake.getRiskProfileArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetRiskProfileCalls(stub func(shared.Source, *shared.Version) (shared.RiskProfile, error)) {
+	fake.getRiskProfileMutex.Lock()
+	defer fake.getRiskProfileMutex.Unlock()
+	fake.GetRiskProfileStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetRiskProfileArgsForCall(i int) (shared.Source, *shared.Version) {
+	fake.getRiskProfileMutex.RLock()
+	defer fake.getRiskProfileMutex.RUnlock()
+	argsForCall := fake.getRiskProfileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetRiskProfileReturns(result1 shared.RiskProfile, result2 error) {
+	fake.getRiskProfileMutex.Lock()
+	defer fake.getRiskProfileMutex.Unlock()
+	fake.GetRiskProfileStub = nil
+	fake.getRiskProfileReturns = struct {
+		result1 shared.RiskProfile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetRiskProfileReturnsOnCall(i int, result1 shared.RiskProfile, result2 error) {
+	fake.getRiskProfileMutex.Lock()
+	defer fake.getRiskProfileMutex.Unlock()
+	fake.GetRiskProfileStub = nil
+	if fake.getRiskProfileReturnsOnCall == nil {
+		fake.getRiskProfileReturnsOnCall = make(map[int]struct {
+			result1 shared.RiskProfile
+			result2 error
+		})
+	}
+	fake.getRiskProfileReturnsOnCall[i] = struct {
+		result1 shared.RiskProfile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetUpgradeGuidance(arg1 shared.Source, arg2 shared.BomComponent) (shared.UpgradeGuidance, error) {
+	fake.getUpgradeGuidanceMutex.Lock()
+	ret, specificReturn := fake.getUpgradeGuidanceReturnsOnCall[len(fake.getUpgradeGuidanceArgsForCall)]
+	fake.getUpgradeGuidanceArgsForCall = append(fake.getUpgradeGuidanceArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}{arg1, arg2})
+	stub := fake.GetUpgradeGuidanceStub
+	fakeReturns := fake.getUpgradeGuidanceReturns
+	fake.recordInvocation("GetUpgradeGuidance", []interface{}{arg1, arg2})
+	fake.getUpgradeGuidanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
-	getProjectVersionsReturns struct {
-		result1 []shared.Version
-		result2 error
+	if specificReturn {
+		return ret.result1, ret.result2
 	}
-	getProjectVersionsReturnsOnCall map[int]struct {
-		result1 []shared.Version
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBlackduckApi) GetUpgradeGuidanceCallCount() int {
+	fake.getUpgradeGuidanceMutex.RLock()
+	defer fake.getUpgradeGuidanceMutex.RUnlock()
+	return len(fake.getUpgradeGuidanceArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) GetUpgradeGuidanceCalls(stub func(shared.Source, shared.BomComponent) (shared.UpgradeGuidance, error)) {
+	fake.getUpgradeGuidanceMutex.Lock()
+	defer fake.getUpgradeGuidanceMutex.Unlock()
+	fake.GetUpgradeGuidanceStub = stub
+}
+
+func (fake *FakeBlackduckApi) GetUpgradeGuidanceArgsForCall(i int) (shared.Source, shared.BomComponent) {
+	fake.getUpgradeGuidanceMutex.RLock()
+	defer fake.getUpgradeGuidanceMutex.RUnlock()
+	argsForCall := fake.getUpgradeGuidanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) GetUpgradeGuidanceReturns(result1 shared.UpgradeGuidance, result2 error) {
+	fake.getUpgradeGuidanceMutex.Lock()
+	defer fake.getUpgradeGuidanceMutex.Unlock()
+	fake.GetUpgradeGuidanceStub = nil
+	fake.getUpgradeGuidanceReturns = struct {
+		result1 shared.UpgradeGuidance
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBlackduckApi) GetUpgradeGuidanceReturnsOnCall(i int, result1 shared.UpgradeGuidance, result2 error) {
+	fake.getUpgradeGuidanceMutex.Lock()
+	defer fake.getUpgradeGuidanceMutex.Unlock()
+	fake.GetUpgradeGuidanceStub = nil
+	if fake.getUpgradeGuidanceReturnsOnCall == nil {
+		fake.getUpgradeGuidanceReturnsOnCall = make(map[int]struct {
+			result1 shared.UpgradeGuidance
+			result2 error
+		})
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	fake.getUpgradeGuidanceReturnsOnCall[i] = struct {
+		result1 shared.UpgradeGuidance
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeBlackduckApi) GetProjectByName(arg1 shared.Source) (*shared.Project, error) {
-	fake.getProjectByNameMutex.Lock()
-	ret, specificReturn := fake.getProjectByNameReturnsOnCall[len(fake.getProjectByNameArgsForCall)]
-	fake.getProjectByNameArgsForCall = append(fake.getProjectByNameArgsForCall, struct {
+func (fake *FakeBlackduckApi) GetVulnerableComponents(arg1 shared.Source, arg2 *shared.Version) ([]shared.VulnerableComponent, error) {
+	fake.getVulnerableComponentsMutex.Lock()
+	ret, specificReturn := fake.getVulnerableComponentsReturnsOnCall[len(fake.getVulnerableComponentsArgsForCall)]
+	fake.getVulnerableComponentsArgsForCall = append(fake.getVulnerableComponentsArgsForCall, struct {
 		arg1 shared.Source
-	}{arg1})
-	stub := fake.GetProjectByNameStub
-	fakeReturns := fake.getProjectByNameReturns
-	fake.recordInvocation("GetProjectByName", []interface{}{arg1})
-	fake.getProjectByNameMutex.Unlock()
+		arg2 *shared.Version
+	}{arg1, arg2})
+	stub := fake.GetVulnerableComponentsStub
+	fakeReturns := fake.getVulnerableComponentsReturns
+	fake.recordInvocation("GetVulnerableComponents", []interface{}{arg1, arg2})
+	fake.getVulnerableComponentsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -58,62 +2347,498 @@ func (fake *FakeBlackduckApi) GetProjectByName(arg1 shared.Source) (*shared.Proj
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeBlackduckApi) GetProjectByNameCallCount() int {
-	fake.getProjectByNameMutex.RLock()
-	defer fake.getProjectByNameMutex.RUnlock()
-	return len(fake.getProjectByNameArgsForCall)
+func (fake *FakeBlackduckApi) GetVulnerableComponentsCallCount() int {
+	fake.getVulnerableComponentsMutex.RLock()
+	defer fake.getVulnerableComponentsMutex.RUnlock()
+	return len(fake.getVulnerableComponentsArgsForCall)
 }
 
-func (fake *FakeBlackduckApi) GetProjectByNameCalls(stub func(shared.Source) (*shared.Project, error)) {
-	fake.getProjectByNameMutex.Lock()
-	defer fake.getProjectByNameMutex.Unlock()
-	fake.GetProjectByNameStub = stub
+func (fake *FakeBlackduckApi) GetVulnerableComponentsCalls(stub func(shared.Source, *shared.Version) ([]shared.VulnerableComponent, error)) {
+	fake.getVulnerableComponentsMutex.Lock()
+	defer fake.getVulnerableComponentsMutex.Unlock()
+	fake.GetVulnerableComponentsStub = stub
 }
 
-func (fake *FakeBlackduckApi) GetProjectByNameArgsForCall(i int) shared.Source {
-	fake.getProjectByNameMutex.RLock()
-	defer fake.getProjectByNameMutex.RUnlock()
-	argsForCall := fake.getProjectByNameArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeBlackduckApi) GetVulnerableComponentsArgsForCall(i int) (shared.Source, *shared.Version) {
+	fake.getVulnerableComponentsMutex.RLock()
+	defer fake.getVulnerableComponentsMutex.RUnlock()
+	argsForCall := fake.getVulnerableComponentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeBlackduckApi) GetProjectByNameReturns(result1 *shared.Project, result2 error) {
-	fake.getProjectByNameMutex.Lock()
-	defer fake.getProjectByNameMutex.Unlock()
-	fake.GetProjectByNameStub = nil
-	fake.getProjectByNameReturns = struct {
-		result1 *shared.Project
+func (fake *FakeBlackduckApi) GetVulnerableComponentsReturns(result1 []shared.VulnerableComponent, result2 error) {
+	fake.getVulnerableComponentsMutex.Lock()
+	defer fake.getVulnerableComponentsMutex.Unlock()
+	fake.GetVulnerableComponentsStub = nil
+	fake.getVulnerableComponentsReturns = struct {
+		result1 []shared.VulnerableComponent
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeBlackduckApi) GetProjectByNameReturnsOnCall(i int, result1 *shared.Project, result2 error) {
-	fake.getProjectByNameMutex.Lock()
-	defer fake.getProjectByNameMutex.Unlock()
-	fake.GetProjectByNameStub = nil
-	if fake.getProjectByNameReturnsOnCall == nil {
-		fake.getProjectByNameReturnsOnCall = make(map[int]struct {
-			result1 *shared.Project
+func (fake *FakeBlackduckApi) GetVulnerableComponentsReturnsOnCall(i int, result1 []shared.VulnerableComponent, result2 error) {
+	fake.getVulnerableComponentsMutex.Lock()
+	defer fake.getVulnerableComponentsMutex.Unlock()
+	fake.GetVulnerableComponentsStub = nil
+	if fake.getVulnerableComponentsReturnsOnCall == nil {
+		fake.getVulnerableComponentsReturnsOnCall = make(map[int]struct {
+			result1 []shared.VulnerableComponent
 			result2 error
 		})
 	}
-	fake.getProjectByNameReturnsOnCall[i] = struct {
-		result1 *shared.Project
+	fake.getVulnerableComponentsReturnsOnCall[i] = struct {
+		result1 []shared.VulnerableComponent
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeBlackduckApi) GetProjectVersions(arg1 shared.Source, arg2 *shared.Project) ([]shared.Version, error) {
-	fake.getProjectVersionsMutex.Lock()
-	ret, specificReturn := fake.getProjectVersionsReturnsOnCall[len(fake.getProjectVersionsArgsForCall)]
-	fake.getProjectVersionsArgsForCall = append(fake.getProjectVersionsArgsForCall, struct {
+func (fake *FakeBlackduckApi) RemoveProjectTag(arg1 shared.Source, arg2 *shared.Project, arg3 string) error {
+	fake.removeProjectTagMutex.Lock()
+	ret, specificReturn := fake.removeProjectTagReturnsOnCall[len(fake.removeProjectTagArgsForCall)]
+	fake.removeProjectTagArgsForCall = append(fake.removeProjectTagArgsForCall, struct {
 		arg1 shared.Source
 		arg2 *shared.Project
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RemoveProjectTagStub
+	fakeReturns := fake.removeProjectTagReturns
+	fake.recordInvocation("RemoveProjectTag", []interface{}{arg1, arg2, arg3})
+	fake.removeProjectTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectTagCallCount() int {
+	fake.removeProjectTagMutex.RLock()
+	defer fake.removeProjectTagMutex.RUnlock()
+	return len(fake.removeProjectTagArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectTagCalls(stub func(shared.Source, *shared.Project, string) error) {
+	fake.removeProjectTagMutex.Lock()
+	defer fake.removeProjectTagMutex.Unlock()
+	fake.RemoveProjectTagStub = stub
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectTagArgsForCall(i int) (shared.Source, *shared.Project, string) {
+	fake.removeProjectTagMutex.RLock()
+	defer fake.removeProjectTagMutex.RUnlock()
+	argsForCall := fake.removeProjectTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectTagReturns(result1 error) {
+	fake.removeProjectTagMutex.Lock()
+	defer fake.removeProjectTagMutex.Unlock()
+	fake.RemoveProjectTagStub = nil
+	fake.removeProjectTagReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectTagReturnsOnCall(i int, result1 error) {
+	fake.removeProjectTagMutex.Lock()
+	defer fake.removeProjectTagMutex.Unlock()
+	fake.RemoveProjectTagStub = nil
+	if fake.removeProjectTagReturnsOnCall == nil {
+		fake.removeProjectTagReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeProjectTagReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectUserGroup(arg1 shared.Source, arg2 shared.UserGroup) error {
+	fake.removeProjectUserGroupMutex.Lock()
+	ret, specificReturn := fake.removeProjectUserGroupReturnsOnCall[len(fake.removeProjectUserGroupArgsForCall)]
+	fake.removeProjectUserGroupArgsForCall = append(fake.removeProjectUserGroupArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.UserGroup
 	}{arg1, arg2})
-	stub := fake.GetProjectVersionsStub
-	fakeReturns := fake.getProjectVersionsReturns
-	fake.recordInvocation("GetProjectVersions", []interface{}{arg1, arg2})
-	fake.getProjectVersionsMutex.Unlock()
+	stub := fake.RemoveProjectUserGroupStub
+	fakeReturns := fake.removeProjectUserGroupReturns
+	fake.recordInvocation("RemoveProjectUserGroup", []interface{}{arg1, arg2})
+	fake.removeProjectUserGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectUserGroupCallCount() int {
+	fake.removeProjectUserGroupMutex.RLock()
+	defer fake.removeProjectUserGroupMutex.RUnlock()
+	return len(fake.removeProjectUserGroupArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectUserGroupCalls(stub func(shared.Source, shared.UserGroup) error) {
+	fake.removeProjectUserGroupMutex.Lock()
+	defer fake.removeProjectUserGroupMutex.Unlock()
+	fake.RemoveProjectUserGroupStub = stub
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectUserGroupArgsForCall(i int) (shared.Source, shared.UserGroup) {
+	fake.removeProjectUserGroupMutex.RLock()
+	defer fake.removeProjectUserGroupMutex.RUnlock()
+	argsForCall := fake.removeProjectUserGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectUserGroupReturns(result1 error) {
+	fake.removeProjectUserGroupMutex.Lock()
+	defer fake.removeProjectUserGroupMutex.Unlock()
+	fake.RemoveProjectUserGroupStub = nil
+	fake.removeProjectUserGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) RemoveProjectUserGroupReturnsOnCall(i int, result1 error) {
+	fake.removeProjectUserGroupMutex.Lock()
+	defer fake.removeProjectUserGroupMutex.Unlock()
+	fake.RemoveProjectUserGroupStub = nil
+	if fake.removeProjectUserGroupReturnsOnCall == nil {
+		fake.removeProjectUserGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeProjectUserGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateBomComponent(arg1 shared.Source, arg2 shared.BomComponent) error {
+	fake.updateBomComponentMutex.Lock()
+	ret, specificReturn := fake.updateBomComponentReturnsOnCall[len(fake.updateBomComponentArgsForCall)]
+	fake.updateBomComponentArgsForCall = append(fake.updateBomComponentArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.BomComponent
+	}{arg1, arg2})
+	stub := fake.UpdateBomComponentStub
+	fakeReturns := fake.updateBomComponentReturns
+	fake.recordInvocation("UpdateBomComponent", []interface{}{arg1, arg2})
+	fake.updateBomComponentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) UpdateBomComponentCallCount() int {
+	fake.updateBomComponentMutex.RLock()
+	defer fake.updateBomComponentMutex.RUnlock()
+	return len(fake.updateBomComponentArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) UpdateBomComponentCalls(stub func(shared.Source, shared.BomComponent) error) {
+	fake.updateBomComponentMutex.Lock()
+	defer fake.updateBomComponentMutex.Unlock()
+	fake.UpdateBomComponentStub = stub
+}
+
+func (fake *FakeBlackduckApi) UpdateBomComponentArgsForCall(i int) (shared.Source, shared.BomComponent) {
+	fake.updateBomComponentMutex.RLock()
+	defer fake.updateBomComponentMutex.RUnlock()
+	argsForCall := fake.updateBomComponentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) UpdateBomComponentReturns(result1 error) {
+	fake.updateBomComponentMutex.Lock()
+	defer fake.updateBomComponentMutex.Unlock()
+	fake.UpdateBomComponentStub = nil
+	fake.updateBomComponentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateBomComponentReturnsOnCall(i int, result1 error) {
+	fake.updateBomComponentMutex.Lock()
+	defer fake.updateBomComponentMutex.Unlock()
+	fake.UpdateBomComponentStub = nil
+	if fake.updateBomComponentReturnsOnCall == nil {
+		fake.updateBomComponentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateBomComponentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateCustomField(arg1 shared.Source, arg2 shared.CustomField) error {
+	fake.updateCustomFieldMutex.Lock()
+	ret, specificReturn := fake.updateCustomFieldReturnsOnCall[len(fake.updateCustomFieldArgsForCall)]
+	fake.updateCustomFieldArgsForCall = append(fake.updateCustomFieldArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.CustomField
+	}{arg1, arg2})
+	stub := fake.UpdateCustomFieldStub
+	fakeReturns := fake.updateCustomFieldReturns
+	fake.recordInvocation("UpdateCustomField", []interface{}{arg1, arg2})
+	fake.updateCustomFieldMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) UpdateCustomFieldCallCount() int {
+	fake.updateCustomFieldMutex.RLock()
+	defer fake.updateCustomFieldMutex.RUnlock()
+	return len(fake.updateCustomFieldArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) UpdateCustomFieldCalls(stub func(shared.Source, shared.CustomField) error) {
+	fake.updateCustomFieldMutex.Lock()
+	defer fake.updateCustomFieldMutex.Unlock()
+	fake.UpdateCustomFieldStub = stub
+}
+
+func (fake *FakeBlackduckApi) UpdateCustomFieldArgsForCall(i int) (shared.Source, shared.CustomField) {
+	fake.updateCustomFieldMutex.RLock()
+	defer fake.updateCustomFieldMutex.RUnlock()
+	argsForCall := fake.updateCustomFieldArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) UpdateCustomFieldReturns(result1 error) {
+	fake.updateCustomFieldMutex.Lock()
+	defer fake.updateCustomFieldMutex.Unlock()
+	fake.UpdateCustomFieldStub = nil
+	fake.updateCustomFieldReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateCustomFieldReturnsOnCall(i int, result1 error) {
+	fake.updateCustomFieldMutex.Lock()
+	defer fake.updateCustomFieldMutex.Unlock()
+	fake.UpdateCustomFieldStub = nil
+	if fake.updateCustomFieldReturnsOnCall == nil {
+		fake.updateCustomFieldReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateCustomFieldReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateProject(arg1 shared.Source, arg2 shared.Project) error {
+	fake.updateProjectMutex.Lock()
+	ret, specificReturn := fake.updateProjectReturnsOnCall[len(fake.updateProjectArgsForCall)]
+	fake.updateProjectArgsForCall = append(fake.updateProjectArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.Project
+	}{arg1, arg2})
+	stub := fake.UpdateProjectStub
+	fakeReturns := fake.updateProjectReturns
+	fake.recordInvocation("UpdateProject", []interface{}{arg1, arg2})
+	fake.updateProjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) UpdateProjectCallCount() int {
+	fake.updateProjectMutex.RLock()
+	defer fake.updateProjectMutex.RUnlock()
+	return len(fake.updateProjectArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) UpdateProjectCalls(stub func(shared.Source, shared.Project) error) {
+	fake.updateProjectMutex.Lock()
+	defer fake.updateProjectMutex.Unlock()
+	fake.UpdateProjectStub = stub
+}
+
+func (fake *FakeBlackduckApi) UpdateProjectArgsForCall(i int) (shared.Source, shared.Project) {
+	fake.updateProjectMutex.RLock()
+	defer fake.updateProjectMutex.RUnlock()
+	argsForCall := fake.updateProjectArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) UpdateProjectReturns(result1 error) {
+	fake.updateProjectMutex.Lock()
+	defer fake.updateProjectMutex.Unlock()
+	fake.UpdateProjectStub = nil
+	fake.updateProjectReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateProjectReturnsOnCall(i int, result1 error) {
+	fake.updateProjectMutex.Lock()
+	defer fake.updateProjectMutex.Unlock()
+	fake.UpdateProjectStub = nil
+	if fake.updateProjectReturnsOnCall == nil {
+		fake.updateProjectReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateProjectReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateRemediation(arg1 shared.Source, arg2 shared.VulnerableComponent, arg3 shared.Remediation) error {
+	fake.updateRemediationMutex.Lock()
+	ret, specificReturn := fake.updateRemediationReturnsOnCall[len(fake.updateRemediationArgsForCall)]
+	fake.updateRemediationArgsForCall = append(fake.updateRemediationArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.VulnerableComponent
+		arg3 shared.Remediation
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateRemediationStub
+	fakeReturns := fake.updateRemediationReturns
+	fake.recordInvocation("UpdateRemediation", []interface{}{arg1, arg2, arg3})
+	fake.updateRemediationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) UpdateRemediationCallCount() int {
+	fake.updateRemediationMutex.RLock()
+	defer fake.updateRemediationMutex.RUnlock()
+	return len(fake.updateRemediationArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) UpdateRemediationCalls(stub func(shared.Source, shared.VulnerableComponent, shared.Remediation) error) {
+	fake.updateRemediationMutex.Lock()
+	defer fake.updateRemediationMutex.Unlock()
+	fake.UpdateRemediationStub = stub
+}
+
+func (fake *FakeBlackduckApi) UpdateRemediationArgsForCall(i int) (shared.Source, shared.VulnerableComponent, shared.Remediation) {
+	fake.updateRemediationMutex.RLock()
+	defer fake.updateRemediationMutex.RUnlock()
+	argsForCall := fake.updateRemediationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBlackduckApi) UpdateRemediationReturns(result1 error) {
+	fake.updateRemediationMutex.Lock()
+	defer fake.updateRemediationMutex.Unlock()
+	fake.UpdateRemediationStub = nil
+	fake.updateRemediationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateRemediationReturnsOnCall(i int, result1 error) {
+	fake.updateRemediationMutex.Lock()
+	defer fake.updateRemediationMutex.Unlock()
+	fake.UpdateRemediationStub = nil
+	if fake.updateRemediationReturnsOnCall == nil {
+		fake.updateRemediationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateRemediationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateVersion(arg1 shared.Source, arg2 shared.Version) error {
+	fake.updateVersionMutex.Lock()
+	ret, specificReturn := fake.updateVersionReturnsOnCall[len(fake.updateVersionArgsForCall)]
+	fake.updateVersionArgsForCall = append(fake.updateVersionArgsForCall, struct {
+		arg1 shared.Source
+		arg2 shared.Version
+	}{arg1, arg2})
+	stub := fake.UpdateVersionStub
+	fakeReturns := fake.updateVersionReturns
+	fake.recordInvocation("UpdateVersion", []interface{}{arg1, arg2})
+	fake.updateVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBlackduckApi) UpdateVersionCallCount() int {
+	fake.updateVersionMutex.RLock()
+	defer fake.updateVersionMutex.RUnlock()
+	return len(fake.updateVersionArgsForCall)
+}
+
+func (fake *FakeBlackduckApi) UpdateVersionCalls(stub func(shared.Source, shared.Version) error) {
+	fake.updateVersionMutex.Lock()
+	defer fake.updateVersionMutex.Unlock()
+	fake.UpdateVersionStub = stub
+}
+
+func (fake *FakeBlackduckApi) UpdateVersionArgsForCall(i int) (shared.Source, shared.Version) {
+	fake.updateVersionMutex.RLock()
+	defer fake.updateVersionMutex.RUnlock()
+	argsForCall := fake.updateVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBlackduckApi) UpdateVersionReturns(result1 error) {
+	fake.updateVersionMutex.Lock()
+	defer fake.updateVersionMutex.Unlock()
+	fake.UpdateVersionStub = nil
+	fake.updateVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UpdateVersionReturnsOnCall(i int, result1 error) {
+	fake.updateVersionMutex.Lock()
+	defer fake.updateVersionMutex.Unlock()
+	fake.UpdateVersionStub = nil
+	if fake.updateVersionReturnsOnCall == nil {
+		fake.updateVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlackduckApi) UploadBdio(arg1 shared.Source, arg2 string) (string, error) {
+	fake.uploadBdioMutex.Lock()
+	ret, specificReturn := fake.uploadBdioReturnsOnCall[len(fake.uploadBdioArgsForCall)]
+	fake.uploadBdioArgsForCall = append(fake.uploadBdioArgsForCall, struct {
+		arg1 shared.Source
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UploadBdioStub
+	fakeReturns := fake.uploadBdioReturns
+	fake.recordInvocation("UploadBdio", []interface{}{arg1, arg2})
+	fake.uploadBdioMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -123,47 +2848,47 @@ func (fake *FakeBlackduckApi) GetProjectVersions(arg1 shared.Source, arg2 *share
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeBlackduckApi) GetProjectVersionsCallCount() int {
-	fake.getProjectVersionsMutex.RLock()
-	defer fake.getProjectVersionsMutex.RUnlock()
-	return len(fake.getProjectVersionsArgsForCall)
+func (fake *FakeBlackduckApi) UploadBdioCallCount() int {
+	fake.uploadBdioMutex.RLock()
+	defer fake.uploadBdioMutex.RUnlock()
+	return len(fake.uploadBdioArgsForCall)
 }
 
-func (fake *FakeBlackduckApi) GetProjectVersionsCalls(stub func(shared.Source, *shared.Project) ([]shared.Version, error)) {
-	fake.getProjectVersionsMutex.Lock()
-	defer fake.getProjectVersionsMutex.Unlock()
-	fake.GetProjectVersionsStub = stub
+func (fake *FakeBlackduckApi) UploadBdioCalls(stub func(shared.Source, string) (string, error)) {
+	fake.uploadBdioMutex.Lock()
+	defer fake.uploadBdioMutex.Unlock()
+	fake.UploadBdioStub = stub
 }
 
-func (fake *FakeBlackduckApi) GetProjectVersionsArgsForCall(i int) (shared.Source, *shared.Project) {
-	fake.getProjectVersionsMutex.RLock()
-	defer fake.getProjectVersionsMutex.RUnlock()
-	argsForCall := fake.getProjectVersionsArgsForCall[i]
+func (fake *FakeBlackduckApi) UploadBdioArgsForCall(i int) (shared.Source, string) {
+	fake.uploadBdioMutex.RLock()
+	defer fake.uploadBdioMutex.RUnlock()
+	argsForCall := fake.uploadBdioArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeBlackduckApi) GetProjectVersionsReturns(result1 []shared.Version, result2 error) {
-	fake.getProjectVersionsMutex.Lock()
-	defer fake.getProjectVersionsMutex.Unlock()
-	fake.GetProjectVersionsStub = nil
-	fake.getProjectVersionsReturns = struct {
-		result1 []shared.Version
+func (fake *FakeBlackduckApi) UploadBdioReturns(result1 string, result2 error) {
+	fake.uploadBdioMutex.Lock()
+	defer fake.uploadBdioMutex.Unlock()
+	fake.UploadBdioStub = nil
+	fake.uploadBdioReturns = struct {
+		result1 string
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeBlackduckApi) GetProjectVersionsReturnsOnCall(i int, result1 []shared.Version, result2 error) {
-	fake.getProjectVersionsMutex.Lock()
-	defer fake.getProjectVersionsMutex.Unlock()
-	fake.GetProjectVersionsStub = nil
-	if fake.getProjectVersionsReturnsOnCall == nil {
-		fake.getProjectVersionsReturnsOnCall = make(map[int]struct {
-			result1 []shared.Version
+func (fake *FakeBlackduckApi) UploadBdioReturnsOnCall(i int, result1 string, result2 error) {
+	fake.uploadBdioMutex.Lock()
+	defer fake.uploadBdioMutex.Unlock()
+	fake.UploadBdioStub = nil
+	if fake.uploadBdioReturnsOnCall == nil {
+		fake.uploadBdioReturnsOnCall = make(map[int]struct {
+			result1 string
 			result2 error
 		})
 	}
-	fake.getProjectVersionsReturnsOnCall[i] = struct {
-		result1 []shared.Version
+	fake.uploadBdioReturnsOnCall[i] = struct {
+		result1 string
 		result2 error
 	}{result1, result2}
 }
@@ -171,10 +2896,80 @@ func (fake *FakeBlackduckApi) GetProjectVersionsReturnsOnCall(i int, result1 []s
 func (fake *FakeBlackduckApi) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.addProjectTagMutex.RLock()
+	defer fake.addProjectTagMutex.RUnlock()
+	fake.addProjectUserGroupMutex.RLock()
+	defer fake.addProjectUserGroupMutex.RUnlock()
+	fake.createNoticesReportMutex.RLock()
+	defer fake.createNoticesReportMutex.RUnlock()
+	fake.createProjectMutex.RLock()
+	defer fake.createProjectMutex.RUnlock()
+	fake.createVersionMutex.RLock()
+	defer fake.createVersionMutex.RUnlock()
+	fake.deleteVersionMutex.RLock()
+	defer fake.deleteVersionMutex.RUnlock()
+	fake.getBomComponentsMutex.RLock()
+	defer fake.getBomComponentsMutex.RUnlock()
+	fake.getCodeLocationsMutex.RLock()
+	defer fake.getCodeLocationsMutex.RUnlock()
+	fake.getComponentCountMutex.RLock()
+	defer fake.getComponentCountMutex.RUnlock()
+	fake.getComponentOriginsMutex.RLock()
+	defer fake.getComponentOriginsMutex.RUnlock()
+	fake.getComponentPolicyRulesMutex.RLock()
+	defer fake.getComponentPolicyRulesMutex.RUnlock()
+	fake.getComponentVersionVulnerabilitiesMutex.RLock()
+	defer fake.getComponentVersionVulnerabilitiesMutex.RUnlock()
+	fake.getCopyrightsMutex.RLock()
+	defer fake.getCopyrightsMutex.RUnlock()
+	fake.getLicenseTextMutex.RLock()
+	defer fake.getLicenseTextMutex.RUnlock()
+	fake.getLicenseUrlMutex.RLock()
+	defer fake.getLicenseUrlMutex.RUnlock()
+	fake.getMatchedFilesMutex.RLock()
+	defer fake.getMatchedFilesMutex.RUnlock()
+	fake.getPolicyRulesMutex.RLock()
+	defer fake.getPolicyRulesMutex.RUnlock()
+	fake.getPolicyStatusMutex.RLock()
+	defer fake.getPolicyStatusMutex.RUnlock()
 	fake.getProjectByNameMutex.RLock()
 	defer fake.getProjectByNameMutex.RUnlock()
+	fake.getProjectCustomFieldsMutex.RLock()
+	defer fake.getProjectCustomFieldsMutex.RUnlock()
+	fake.getProjectGroupUrlMutex.RLock()
+	defer fake.getProjectGroupUrlMutex.RUnlock()
+	fake.getProjectTagsMutex.RLock()
+	defer fake.getProjectTagsMutex.RUnlock()
+	fake.getProjectUserGroupsMutex.RLock()
+	defer fake.getProjectUserGroupsMutex.RUnlock()
 	fake.getProjectVersionsMutex.RLock()
 	defer fake.getProjectVersionsMutex.RUnlock()
+	fake.getReportMutex.RLock()
+	defer fake.getReportMutex.RUnlock()
+	fake.getReportContentMutex.RLock()
+	defer fake.getReportContentMutex.RUnlock()
+	fake.getRiskProfileMutex.RLock()
+	defer fake.getRiskProfileMutex.RUnlock()
+	fake.getUpgradeGuidanceMutex.RLock()
+	defer fake.getUpgradeGuidanceMutex.RUnlock()
+	fake.getVulnerableComponentsMutex.RLock()
+	defer fake.getVulnerableComponentsMutex.RUnlock()
+	fake.removeProjectTagMutex.RLock()
+	defer fake.removeProjectTagMutex.RUnlock()
+	fake.removeProjectUserGroupMutex.RLock()
+	defer fake.removeProjectUserGroupMutex.RUnlock()
+	fake.updateBomComponentMutex.RLock()
+	defer fake.updateBomComponentMutex.RUnlock()
+	fake.updateCustomFieldMutex.RLock()
+	defer fake.updateCustomFieldMutex.RUnlock()
+	fake.updateProjectMutex.RLock()
+	defer fake.updateProjectMutex.RUnlock()
+	fake.updateRemediationMutex.RLock()
+	defer fake.updateRemediationMutex.RUnlock()
+	fake.updateVersionMutex.RLock()
+	defer fake.updateVersionMutex.RUnlock()
+	fake.uploadBdioMutex.RLock()
+	defer fake.uploadBdioMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -12,16 +12,20 @@ type Request struct {
 }
 
 type Source struct {
-	Url           string `json:"url"`
-	Username      string `json:"username"`
-	Password      string `json:"password"`
-	Name          string `json:"name"`
-	Token         string `json:"token"`
-	Insecure      bool   `json:"insecure"`
-	ProxyHost     string `json:"proxy-host"`
-	ProxyPort     string `json:"proxy-port"`
-	ProxyUsername string `json:"proxy-username"`
-	ProxyPassword string `json:"proxy-password"`
+	Url           string          `json:"url"`
+	Username      string          `json:"username"`
+	Password      string          `json:"password"`
+	Name          string          `json:"name"`
+	Token         string          `json:"token"`
+	Insecure      bool            `json:"insecure"`
+	ProxyHost     string          `json:"proxy-host"`
+	ProxyPort     string          `json:"proxy-port"`
+	ProxyUsername string          `json:"proxy-username"`
+	ProxyPassword string          `json:"proxy-password"`
+	DetectVersion string          `json:"detect_version"`
+	Project       ProjectSettings `json:"project"`
+	Version       VersionSettings `json:"version"`
+	Retention     Retention       `json:"retention"`
 }
 
 func (s *Source) Valid() bool {
@@ -29,6 +33,9 @@ func (s *Source) Valid() bool {
 	return len(s.Username) != 0 &&
 		len(s.Password) != 0 &&
 		len(s.Name) != 0 &&
+		s.Project.Valid() &&
+		s.Version.Valid() &&
+		s.Retention.Valid() &&
 		err == nil
 }
 
@@ -40,3 +47,12 @@ func (s *Source) GetProjectUrl() string {
 	u.Path = path.Join(u.Path, "api/projects")
 	return u.String() + "?q=name:" + s.Name
 }
+
+func (s *Source) GetApiUrl(endpoint string) string {
+	u, err := url.ParseRequestURI(s.Url)
+	if err != nil {
+		return ""
+	}
+	u.Path = path.Join(u.Path, "api", endpoint)
+	return u.String()
+}
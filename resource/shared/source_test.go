@@ -123,3 +123,13 @@ func TestProjectUrlIsEmptyWhenUrlWasSetIncorrectly(t *testing.T) {
 		t.Errorf("Should've been empty, but was %v", url)
 	}
 }
+
+func TestReturnsApiUrl(t *testing.T) {
+	s := shared.Source{
+		Url: "http://url/blackduck/",
+	}
+	url := s.GetApiUrl("scan/data")
+	if url != "http://url/blackduck/api/scan/data" {
+		t.Errorf("Should've appended URL with the api endpoint, but was %v", url)
+	}
+}
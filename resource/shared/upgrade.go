@@ -0,0 +1,24 @@
+package shared
+
+// UpgradeGuidance recommends versions of a component version, which have less vulnerabilities.
+// ShortTerm is the closest such version, LongTerm the one with the least vulnerabilities. Either can be missing.
+type UpgradeGuidance struct {
+	ShortTerm *UpgradeVersion `json:"shortTerm"`
+	LongTerm  *UpgradeVersion `json:"longTerm"`
+}
+
+// UpgradeVersion is a recommended component version. Version is its href.
+type UpgradeVersion struct {
+	Version     string `json:"version"`
+	VersionName string `json:"versionName"`
+}
+
+type ComponentVulnerabilityList struct {
+	Vulnerabilities []ComponentVulnerability `json:"items"`
+}
+
+// ComponentVulnerability is a vulnerability of a component version, regardless of any BOM.
+type ComponentVulnerability struct {
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+}
@@ -3,11 +3,18 @@ package shared
 import "time"
 
 type VersionList struct {
-	Versions []Version `json:"items"`
+	TotalCount int       `json:"totalCount"`
+	Versions   []Version `json:"items"`
 }
 
 type Version struct {
-	Name    string    `json:"versionName"`
-	Phase   string    `json:"phase"`
-	Updated time.Time `json:"settingUpdatedAt"`
+	Name         string    `json:"versionName"`
+	Phase        string    `json:"phase"`
+	Distribution string    `json:"distribution"`
+	Nickname     string    `json:"nickname"`
+	ReleasedOn   string    `json:"releasedOn"`
+	CloneFrom    string    `json:"cloneFromReleaseUrl"`
+	Created      time.Time `json:"createdAt"`
+	Updated      time.Time `json:"settingUpdatedAt"`
+	Meta         Meta      `json:"_meta"`
 }
@@ -0,0 +1,41 @@
+package shared
+
+// RemediationStatuses, which Blackduck accepts for vulnerabilities.
+var RemediationStatuses = []string{
+	"NEW", "NEEDS_REVIEW", "REMEDIATION_REQUIRED", "REMEDIATION_COMPLETE", "MITIGATED",
+	"PATCHED", "IGNORED", "DUPLICATE", "NOT_AFFECTED", "AFFECTED",
+}
+
+type VulnerableComponentList struct {
+	VulnerableComponents []VulnerableComponent `json:"items"`
+}
+
+// VulnerableComponent is a single vulnerability of a component in the BOM. Its href updates the remediation.
+type VulnerableComponent struct {
+	ComponentName        string                       `json:"componentName"`
+	ComponentVersionName string                       `json:"componentVersionName"`
+	Vulnerability        VulnerabilityWithRemediation `json:"vulnerabilityWithRemediation"`
+	Meta                 Meta                         `json:"_meta"`
+}
+
+type VulnerabilityWithRemediation struct {
+	Name               string  `json:"vulnerabilityName"`
+	Description        string  `json:"description"`
+	Severity           string  `json:"severity"`
+	OverallScore       float64 `json:"overallScore"`
+	RemediationStatus  string  `json:"remediationStatus"`
+	RemediationComment string  `json:"remediationComment"`
+}
+
+// resolvedStatuses are remediation statuses, which don't leave a risk open.
+var resolvedStatuses = []string{"REMEDIATION_COMPLETE", "MITIGATED", "PATCHED", "IGNORED", "DUPLICATE", "NOT_AFFECTED"}
+
+// Open tells whether the vulnerability still needs to be remediated.
+func (v VulnerabilityWithRemediation) Open() bool {
+	return !contains(resolvedStatuses, v.RemediationStatus)
+}
+
+type Remediation struct {
+	Status  string `json:"remediationStatus"`
+	Comment string `json:"comment"`
+}